@@ -196,6 +196,7 @@ func NewIxoApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bo
 	// init params keeper and subspaces (for custom ixo modules)
 	projectSubspace := app.paramsKeeper.Subspace(project.DefaultParamspace)
 	bondsSubspace := app.paramsKeeper.Subspace(bonds.DefaultParamspace)
+	didSubspace := app.paramsKeeper.Subspace(did.DefaultParamspace)
 
 	// add keepers (for standard Cosmos modules)
 	app.accountKeeper = auth.NewAccountKeeper(app.cdc, keys[auth.StoreKey], authSubspace, auth.ProtoBaseAccount)
@@ -230,7 +231,7 @@ func NewIxoApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bo
 	)
 
 	// add keepers (for custom ixo modules)
-	app.didKeeper = did.NewKeeper(app.cdc, keys[did.StoreKey])
+	app.didKeeper = did.NewKeeper(app.cdc, keys[did.StoreKey], didSubspace)
 	app.paymentsKeeper = payments.NewKeeper(app.cdc, keys[payments.StoreKey],
 		app.bankKeeper, app.didKeeper, paymentsReservedIdPrefixes)
 	app.projectKeeper = project.NewKeeper(app.cdc, keys[project.StoreKey], projectSubspace,
@@ -241,6 +242,14 @@ func NewIxoApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bo
 	app.treasuryKeeper = treasury.NewKeeper(app.cdc, keys[treasury.StoreKey], app.bankKeeper,
 		app.oraclesKeeper, app.supplyKeeper, app.didKeeper)
 
+	// register the did hooks
+	// NOTE: only the did module's keeper calls these hooks, so the keepers
+	// passed to the other modules above do not need to contain them
+	app.didKeeper = *app.didKeeper.SetHooks(
+		did.NewMultiDidHooks(app.bondsKeeper.Hooks(),
+			app.projectKeeper.Hooks(), app.paymentsKeeper.Hooks()),
+	)
+
 	// NOTE: Any module instantiated in the module manager that is later modified
 	// must be passed by reference here.
 	app.mm = module.NewManager(
@@ -258,7 +267,7 @@ func NewIxoApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bo
 		staking.NewAppModule(app.stakingKeeper, app.distrKeeper, app.accountKeeper, app.supplyKeeper),
 
 		// Custom ixo AppModules
-		did.NewAppModule(app.didKeeper),
+		did.NewAppModule(app.didKeeper, app.bankKeeper, app.accountKeeper),
 		payments.NewAppModule(app.paymentsKeeper, app.bankKeeper),
 		project.NewAppModule(app.projectKeeper, app.paymentsKeeper, app.bankKeeper),
 		bonds.NewAppModule(app.bondsKeeper, app.accountKeeper),
@@ -280,7 +289,7 @@ func NewIxoApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bo
 		// Standard Cosmos modules
 		crisis.ModuleName, gov.ModuleName, staking.ModuleName,
 		// Custom ixo modules
-		bonds.ModuleName, payments.ModuleName,
+		bonds.ModuleName, payments.ModuleName, did.ModuleName,
 	)
 
 	app.mm.SetOrderInitGenesis(
@@ -294,7 +303,8 @@ func NewIxoApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bo
 	)
 
 	app.mm.RegisterInvariants(&app.crisisKeeper)
-	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
+	// Messages signed by a delegate on behalf of a DID record both DIDs
+	app.mm.RegisterRoutes(ixo.NewDelegationRouter(app.Router()), app.QueryRouter())
 
 	// initialize stores
 	app.MountKVStores(keys)
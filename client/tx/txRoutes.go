@@ -23,8 +23,9 @@ func RegisterTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 }
 
 type SignDataReq struct {
-	Msg    string `json:"msg" yaml:"msg"`
-	PubKey string `json:"pub_key" yaml:"pub_key"`
+	Msg     string `json:"msg" yaml:"msg"`
+	PubKey  string `json:"pub_key" yaml:"pub_key"`
+	KeyType string `json:"key_type" yaml:"key_type"`
 }
 
 type SignDataResponse struct {
@@ -77,7 +78,7 @@ func SignDataRequest(cliCtx context.CLIContext) http.HandlerFunc {
 				project.MsgCreateProjectTotalFee)
 		default:
 			// Deduce and set signer address
-			signerAddress := did.VerifyKeyToAddr(req.PubKey)
+			signerAddress := did.PubKeyToAddr(req.KeyType, req.PubKey)
 			cliCtx = cliCtx.WithFromAddress(signerAddress)
 
 			txBldr, err := utils.PrepareTxBuilder(auth.NewTxBuilderFromCLI(), cliCtx)
@@ -6,6 +6,7 @@ import (
 	authrest "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/ixofoundation/ixo-blockchain/client/tx"
+	didcli "github.com/ixofoundation/ixo-blockchain/x/did/client/cli"
 	"os"
 	"path"
 
@@ -60,6 +61,8 @@ func main() {
 		lcd.ServeCommand(cdc, registerRoutes),
 		client.LineBreak,
 		keys.Commands(),
+		didcli.GetKeysCmd(cdc),
+		didcli.GetVcCmd(cdc),
 		client.LineBreak,
 		version.Cmd,
 		client.NewCompletionCmd(rootCmd, true),
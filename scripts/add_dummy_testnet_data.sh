@@ -156,7 +156,7 @@ ixocli tx did add-did-doc "$SHAUN_DID_FULL" --broadcast-mode block --gas-prices=
 
 # Adding KYC credentials
 echo "Adding KYC credential 1/1..."
-ixocli tx did add-kyc-credential "$MIGUEL_DID" "$FRANCESCO_DID_FULL" --broadcast-mode block --gas-prices="$GAS_PRICES" -y
+ixocli tx did add-credential "$MIGUEL_DID" "Credential,ProofOfKYC" "$FRANCESCO_DID_FULL" --broadcast-mode block --gas-prices="$GAS_PRICES" -y
 
 # ----------------------------------------------------------------------------------------- mints/burns
 # Mint and burn ixo tokens
@@ -6,31 +6,57 @@ import (
 )
 
 const (
-	FlagToken                  = "token"
-	FlagName                   = "name"
-	FlagDescription            = "description"
-	FlagFunctionType           = "function-type"
-	FlagFunctionParameters     = "function-parameters"
-	FlagReserveTokens          = "reserve-tokens"
-	FlagTxFeePercentage        = "tx-fee-percentage"
-	FlagExitFeePercentage      = "exit-fee-percentage"
-	FlagFeeAddress             = "fee-address"
-	FlagMaxSupply              = "max-supply"
-	FlagOrderQuantityLimits    = "order-quantity-limits"
-	FlagSanityRate             = "sanity-rate"
-	FlagSanityMarginPercentage = "sanity-margin-percentage"
-	FlagAllowSells             = "allow-sells"
-	FlagBatchBlocks            = "batch-blocks"
-	FlagOutcomePayment         = "outcome-payment"
-	FlagBondDid                = "bond-did"
-	FlagCreatorDid             = "creator-did"
-	FlagEditorDid              = "editor-did"
+	FlagToken                       = "token"
+	FlagName                        = "name"
+	FlagDescription                 = "description"
+	FlagFunctionType                = "function-type"
+	FlagFunctionParameters          = "function-parameters"
+	FlagReserveTokens               = "reserve-tokens"
+	FlagReserveWeights              = "reserve-weights"
+	FlagTxFeePercentage             = "tx-fee-percentage"
+	FlagExitFeePercentage           = "exit-fee-percentage"
+	FlagImbalanceFeePercentage      = "imbalance-fee-percentage"
+	FlagImbalanceFeeExponent        = "imbalance-fee-exponent"
+	FlagFeeAddress                  = "fee-address"
+	FlagMaxSupply                   = "max-supply"
+	FlagOrderQuantityLimits         = "order-quantity-limits"
+	FlagSanityRate                  = "sanity-rate"
+	FlagSanityMarginPercentage      = "sanity-margin-percentage"
+	FlagAllowSells                  = "allow-sells"
+	FlagBatchBlocks                 = "batch-blocks"
+	FlagOutcomePayment              = "outcome-payment"
+	FlagDisplayDenom                = "display-denom"
+	FlagExponent                    = "exponent"
+	FlagSymbol                      = "symbol"
+	FlagIconUri                     = "icon-uri"
+	FlagWebsite                     = "website"
+	FlagSettlementClaimBlocks       = "settlement-claim-blocks"
+	FlagUnclaimedReserveAddress     = "unclaimed-reserve-address"
+	FlagRevealBlocks                = "reveal-blocks"
+	FlagUnrevealedPenaltyPercentage = "unrevealed-penalty-percentage"
+	FlagInstantExecution            = "instant-execution"
+	FlagRequiredCredentialTypes     = "required-credential-types"
+	FlagTrustedIssuers              = "trusted-issuers"
+	FlagMinReturns                  = "min-returns"
+	FlagBondDid                     = "bond-did"
+	FlagCreatorDid                  = "creator-did"
+	FlagEditorDid                   = "editor-did"
+	FlagRecipientDid                = "recipient-did"
+	FlagRecipientAddress            = "recipient-address"
+	FlagAmount                      = "amount"
+	FlagPayoutDid                   = "payout-did"
+	FlagPayoutAddress               = "payout-address"
+	FlagEscrow                      = "escrow"
 )
 
 var (
 	fsBondGeneral = flag.NewFlagSet("", flag.ContinueOnError)
 	fsBondCreate  = flag.NewFlagSet("", flag.ContinueOnError)
 	fsBondEdit    = flag.NewFlagSet("", flag.ContinueOnError)
+	fsOrder       = flag.NewFlagSet("", flag.ContinueOnError)
+	fsWithdraw    = flag.NewFlagSet("", flag.ContinueOnError)
+	fsCommit      = flag.NewFlagSet("", flag.ContinueOnError)
+	fsMinReturns  = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -42,8 +68,11 @@ func init() {
 	fsBondCreate.String(FlagFunctionType, "", "The type of function that the bond will be")
 	fsBondCreate.String(FlagFunctionParameters, "", "The parameters that will define the function")
 	fsBondCreate.String(FlagReserveTokens, "", "The token(s) that will serve as the reserve token(s)")
+	fsBondCreate.String(FlagReserveWeights, "", "The reserve tokens charged per unit of price for each reserve token (e.g. '1.0res,0.5rez'; default: 1.0 each)")
 	fsBondCreate.String(FlagTxFeePercentage, "", "The percentage fee charged on buys and sells")
 	fsBondCreate.String(FlagExitFeePercentage, "", "The percentage fee charged on sells")
+	fsBondCreate.String(FlagImbalanceFeePercentage, "0", "The max additional fee percentage charged on the unmatched buys or sells of imbalanced batches")
+	fsBondCreate.String(FlagImbalanceFeeExponent, "0", "The exponent of the imbalance fee curve (1: linear), required if there is an imbalance fee")
 	fsBondCreate.String(FlagFeeAddress, "", "The address that will hold any charged fees")
 	fsBondCreate.String(FlagMaxSupply, "", "The maximum supply that can be achieved")
 	fsBondCreate.String(FlagOrderQuantityLimits, "", "The max number of tokens bought/sold/swapped per order")
@@ -52,6 +81,18 @@ func init() {
 	fsBondCreate.Bool(FlagAllowSells, false, "Whether or not sells will be allowed")
 	fsBondCreate.String(FlagBatchBlocks, "", "The duration in terms of blocks of each orders batch")
 	fsBondCreate.String(FlagOutcomePayment, "", "The payment that would be required to transition the bond to settlement")
+	fsBondCreate.String(FlagDisplayDenom, "", "The denomination used to display the bond token (e.g. 'abc' for 'uabc')")
+	fsBondCreate.String(FlagExponent, "0", "The exponent relating the display denomination to the bond token")
+	fsBondCreate.String(FlagSymbol, "", "The bond token's ticker symbol")
+	fsBondCreate.String(FlagIconUri, "", "URI of the bond token's icon")
+	fsBondCreate.String(FlagWebsite, "", "The bond's website")
+	fsBondCreate.String(FlagSettlementClaimBlocks, "0", "The number of blocks after settlement after which unclaimed reserve is swept")
+	fsBondCreate.String(FlagUnclaimedReserveAddress, "", "The address that unclaimed reserve is swept to after the settlement claim blocks")
+	fsBondCreate.String(FlagRevealBlocks, "0", "For swappers, the number of blocks in which committed orders can be revealed (0: commit-reveal disabled)")
+	fsBondCreate.String(FlagUnrevealedPenaltyPercentage, "0", "For swappers, the percentage of the escrow charged for orders that are not revealed")
+	fsBondCreate.Bool(FlagInstantExecution, false, "For swappers, whether or not orders are performed instantly instead of in batches")
+	fsBondCreate.String(FlagRequiredCredentialTypes, "", "The credential types (e.g. 'ProofOfKYC') that buyers' DIDs are required to hold")
+	fsBondCreate.String(FlagTrustedIssuers, "", "The DIDs of the issuers whose credentials are accepted (default: any issuer)")
 	fsBondCreate.String(FlagBondDid, "", "Bond's DID")
 	fsBondCreate.String(FlagCreatorDid, "", "Bond creator's DID")
 
@@ -60,6 +101,22 @@ func init() {
 	fsBondEdit.String(FlagOrderQuantityLimits, types.DoNotModifyField, "The max number of tokens bought/sold/swapped per order")
 	fsBondEdit.String(FlagSanityRate, types.DoNotModifyField, "For swappers, this is the typical t1 per t2 rate")
 	fsBondEdit.String(FlagSanityMarginPercentage, types.DoNotModifyField, "For swappers, this is the acceptable deviation from the sanity rate")
+	fsBondEdit.String(FlagDisplayDenom, types.DoNotModifyField, "The denomination used to display the bond token (e.g. 'abc' for 'uabc')")
+	fsBondEdit.String(FlagExponent, types.DoNotModifyField, "The exponent relating the display denomination to the bond token")
+	fsBondEdit.String(FlagSymbol, types.DoNotModifyField, "The bond token's ticker symbol")
+	fsBondEdit.String(FlagIconUri, types.DoNotModifyField, "URI of the bond token's icon")
+	fsBondEdit.String(FlagWebsite, types.DoNotModifyField, "The bond's website")
 	fsBondEdit.String(FlagBondDid, "", "Bond's DID")
 	fsBondEdit.String(FlagEditorDid, "", "Bond editor's DID")
+
+	fsOrder.String(FlagRecipientDid, "", "DID that will receive the order's returns (optional)")
+	fsOrder.String(FlagRecipientAddress, "", "Address that will receive the order's returns (optional)")
+
+	fsWithdraw.String(FlagAmount, "", "The amount of bond tokens to withdraw the share of (default: all)")
+	fsWithdraw.String(FlagPayoutDid, "", "DID that will receive the withdrawn share (optional)")
+	fsWithdraw.String(FlagPayoutAddress, "", "Address that will receive the withdrawn share (optional)")
+
+	fsMinReturns.String(FlagMinReturns, "", "The minimum returns for the order to be performed, for bonds with instant execution (optional)")
+
+	fsCommit.String(FlagEscrow, "", "The amount to escrow, which can exceed the from amount to hide it (default: from amount)")
 }
@@ -24,8 +24,10 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	bondsQueryCmd.AddCommand(client.GetCommands(
 		GetCmdBonds(storeKey, cdc),
 		GetCmdBond(storeKey, cdc),
+		GetCmdBondMetadata(storeKey, cdc),
 		GetCmdBatch(storeKey, cdc),
 		GetCmdLastBatch(storeKey, cdc),
+		GetCmdOrderCommits(storeKey, cdc),
 		GetCmdCurrentPrice(storeKey, cdc),
 		GetCmdCurrentReserve(storeKey, cdc),
 		GetCmdCustomPrice(storeKey, cdc),
@@ -95,6 +97,40 @@ func GetCmdBond(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+func GetCmdBondMetadata(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "bond-metadata [bond-token]",
+		Short: "Query token metadata of a bond by its token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/bond_metadata/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryBondMetadata
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
 func GetCmdBatch(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "batch [bond-did]",
@@ -163,6 +199,40 @@ func GetCmdLastBatch(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+func GetCmdOrderCommits(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "order-commits [bond-did]",
+		Short: "Query a bond's pending order commits",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondDid := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/order_commits/%s",
+					queryRoute, bondDid), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out []types.OrderCommit
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
 func GetCmdCurrentPrice(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "current-price [bond-did]",
@@ -12,6 +12,7 @@ import (
 	"github.com/ixofoundation/ixo-blockchain/x/ixo"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"strconv"
 	"strings"
 )
 
@@ -32,6 +33,8 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		GetCmdSwap(cdc),
 		GetCmdMakeOutcomePayment(cdc),
 		GetCmdWithdrawShare(cdc),
+		GetCmdCommitOrder(cdc),
+		GetCmdRevealOrder(cdc),
 	)...)
 
 	return bondsTxCmd
@@ -48,16 +51,31 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 			_functionType := viper.GetString(FlagFunctionType)
 			_functionParameters := viper.GetString(FlagFunctionParameters)
 			_reserveTokens := viper.GetString(FlagReserveTokens)
+			_reserveWeights := viper.GetString(FlagReserveWeights)
 			_txFeePercentage := viper.GetString(FlagTxFeePercentage)
 			_exitFeePercentage := viper.GetString(FlagExitFeePercentage)
+			_imbalanceFeePercentage := viper.GetString(FlagImbalanceFeePercentage)
+			_imbalanceFeeExponent := viper.GetString(FlagImbalanceFeeExponent)
 			_feeAddress := viper.GetString(FlagFeeAddress)
 			_maxSupply := viper.GetString(FlagMaxSupply)
 			_orderQuantityLimits := viper.GetString(FlagOrderQuantityLimits)
 			_sanityRate := viper.GetString(FlagSanityRate)
 			_sanityMarginPercentage := viper.GetString(FlagSanityMarginPercentage)
 			_allowSells := viper.GetBool(FlagAllowSells)
+			_instantExecution := viper.GetBool(FlagInstantExecution)
 			_batchBlocks := viper.GetString(FlagBatchBlocks)
 			_outcomePayment := viper.GetString(FlagOutcomePayment)
+			_displayDenom := viper.GetString(FlagDisplayDenom)
+			_exponent := viper.GetString(FlagExponent)
+			_symbol := viper.GetString(FlagSymbol)
+			_iconUri := viper.GetString(FlagIconUri)
+			_website := viper.GetString(FlagWebsite)
+			_settlementClaimBlocks := viper.GetString(FlagSettlementClaimBlocks)
+			_unclaimedReserveAddress := viper.GetString(FlagUnclaimedReserveAddress)
+			_revealBlocks := viper.GetString(FlagRevealBlocks)
+			_unrevealedPenalty := viper.GetString(FlagUnrevealedPenaltyPercentage)
+			_requiredCredentialTypes := viper.GetString(FlagRequiredCredentialTypes)
+			_trustedIssuers := viper.GetString(FlagTrustedIssuers)
 			_bondDid := viper.GetString(FlagBondDid)
 			_creatorDid := viper.GetString(FlagCreatorDid)
 
@@ -70,6 +88,12 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 			// Parse reserve tokens
 			reserveTokens := strings.Split(_reserveTokens, ",")
 
+			// Parse reserve weights (optional)
+			reserveWeights, err := sdk.ParseDecCoins(_reserveWeights)
+			if err != nil {
+				return err
+			}
+
 			// Parse tx fee percentage
 			txFeePercentage, err := sdk.NewDecFromStr(_txFeePercentage)
 			if err != nil {
@@ -82,6 +106,16 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 				return fmt.Errorf(types.ErrArgumentMissingOrNonFloat(types.DefaultCodespace, "exit fee percentage").Error())
 			}
 
+			// Parse imbalance fee percentage and exponent
+			imbalanceFeePercentage, err := sdk.NewDecFromStr(_imbalanceFeePercentage)
+			if err != nil {
+				return fmt.Errorf(types.ErrArgumentMissingOrNonFloat(types.DefaultCodespace, "imbalance fee percentage").Error())
+			}
+			imbalanceFeeExponent, err := sdk.ParseUint(_imbalanceFeeExponent)
+			if err != nil {
+				return types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "imbalance fee exponent")
+			}
+
 			// Parse fee address
 			feeAddress, err := sdk.AccAddressFromBech32(_feeAddress)
 			if err != nil {
@@ -127,6 +161,45 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			// Parse exponent
+			exponent, err := strconv.ParseUint(_exponent, 10, 32)
+			if err != nil {
+				return types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "exponent")
+			}
+
+			metadata := types.NewBondTokenMetadata(_displayDenom,
+				uint32(exponent), _symbol, _iconUri, _website)
+
+			// Parse settlement claim blocks
+			settlementClaimBlocks, err := sdk.ParseUint(_settlementClaimBlocks)
+			if err != nil {
+				return types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "settlement claim blocks")
+			}
+
+			// Parse unclaimed reserve address (optional)
+			var unclaimedReserveAddress sdk.AccAddress
+			if _unclaimedReserveAddress != "" {
+				unclaimedReserveAddress, err = sdk.AccAddressFromBech32(_unclaimedReserveAddress)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Parse reveal blocks
+			revealBlocks, err := sdk.ParseUint(_revealBlocks)
+			if err != nil {
+				return types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "reveal blocks")
+			}
+
+			// Parse unrevealed penalty percentage
+			unrevealedPenalty, err := sdk.NewDecFromStr(_unrevealedPenalty)
+			if err != nil {
+				return fmt.Errorf(types.ErrArgumentMissingOrNonFloat(types.DefaultCodespace, "unrevealed penalty percentage").Error())
+			}
+
+			// Parse access policy (optional)
+			accessPolicy := client2.ParseAccessPolicy(_requiredCredentialTypes, _trustedIssuers)
+
 			cliCtx := context.NewCLIContext().WithCodec(cdc).
 				WithFromAddress(creatorDid.Address())
 
@@ -134,7 +207,10 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 				creatorDid.Did, _functionType, functionParams, reserveTokens,
 				txFeePercentage, exitFeePercentage, feeAddress, maxSupply,
 				orderQuantityLimits, sanityRate, sanityMarginPercentage,
-				_allowSells, batchBlocks, outcomePayment, _bondDid)
+				_allowSells, batchBlocks, outcomePayment, _bondDid, metadata,
+				settlementClaimBlocks, unclaimedReserveAddress, revealBlocks,
+				unrevealedPenalty, reserveWeights, _instantExecution,
+				imbalanceFeePercentage, imbalanceFeeExponent, accessPolicy)
 
 			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, creatorDid)
 		},
@@ -175,6 +251,11 @@ func GetCmdEditBond(cdc *codec.Codec) *cobra.Command {
 			_orderQuantityLimits := viper.GetString(FlagOrderQuantityLimits)
 			_sanityRate := viper.GetString(FlagSanityRate)
 			_sanityMarginPercentage := viper.GetString(FlagSanityMarginPercentage)
+			_displayDenom := viper.GetString(FlagDisplayDenom)
+			_exponent := viper.GetString(FlagExponent)
+			_symbol := viper.GetString(FlagSymbol)
+			_iconUri := viper.GetString(FlagIconUri)
+			_website := viper.GetString(FlagWebsite)
 			_bondDid := viper.GetString(FlagBondDid)
 			_editorDid := viper.GetString(FlagEditorDid)
 
@@ -189,7 +270,8 @@ func GetCmdEditBond(cdc *codec.Codec) *cobra.Command {
 
 			msg := types.NewMsgEditBond(
 				_token, _name, _description, _orderQuantityLimits, _sanityRate,
-				_sanityMarginPercentage, editorDid.Did, _bondDid)
+				_sanityMarginPercentage, _displayDenom, _exponent, _symbol,
+				_iconUri, _website, editorDid.Did, _bondDid)
 			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, editorDid)
 		},
 	}
@@ -230,15 +312,22 @@ func GetCmdBuy(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			recipientDid, recipientAddr, err := parseRecipient()
+			if err != nil {
+				return err
+			}
+
 			cliCtx := context.NewCLIContext().WithCodec(cdc).
 				WithFromAddress(buyerDid.Address())
 
-			msg := types.NewMsgBuy(
-				buyerDid.Did, bondCoinWithAmount, maxPrices, args[2])
+			msg := types.NewMsgBuy(buyerDid.Did, bondCoinWithAmount,
+				maxPrices, args[2], recipientDid, recipientAddr)
 
 			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, buyerDid)
 		},
 	}
+
+	cmd.Flags().AddFlagSet(fsOrder)
 	return cmd
 }
 
@@ -261,14 +350,28 @@ func GetCmdSell(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			recipientDid, recipientAddr, err := parseRecipient()
+			if err != nil {
+				return err
+			}
+
+			minReturns, err := sdk.ParseCoins(viper.GetString(FlagMinReturns))
+			if err != nil {
+				return err
+			}
+
 			cliCtx := context.NewCLIContext().WithCodec(cdc).
 				WithFromAddress(sellerDid.Address())
 
-			msg := types.NewMsgSell(sellerDid.Did, bondCoinWithAmount, args[1])
+			msg := types.NewMsgSell(sellerDid.Did, bondCoinWithAmount,
+				args[1], recipientDid, recipientAddr, minReturns)
 
 			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, sellerDid)
 		},
 	}
+
+	cmd.Flags().AddFlagSet(fsOrder)
+	cmd.Flags().AddFlagSet(fsMinReturns)
 	return cmd
 }
 
@@ -294,14 +397,28 @@ func GetCmdSwap(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			recipientDid, recipientAddr, err := parseRecipient()
+			if err != nil {
+				return err
+			}
+
+			minReturns, err := sdk.ParseCoins(viper.GetString(FlagMinReturns))
+			if err != nil {
+				return err
+			}
+
 			cliCtx := context.NewCLIContext().WithCodec(cdc).
 				WithFromAddress(swapperDid.Address())
 
-			msg := types.NewMsgSwap(swapperDid.Did, from, args[2], args[3])
+			msg := types.NewMsgSwap(swapperDid.Did, from, args[2], args[3],
+				recipientDid, recipientAddr, minReturns)
 
 			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, swapperDid)
 		},
 	}
+
+	cmd.Flags().AddFlagSet(fsOrder)
+	cmd.Flags().AddFlagSet(fsMinReturns)
 	return cmd
 }
 
@@ -344,13 +461,141 @@ func GetCmdWithdrawShare(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			// Parse amount (optional, all bond tokens owned by default)
+			var amount sdk.Coin
+			if _amount := viper.GetString(FlagAmount); _amount != "" {
+				amount, err = sdk.ParseCoin(_amount)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Parse payout address (optional)
+			var payoutAddress sdk.AccAddress
+			if _payoutAddress := viper.GetString(FlagPayoutAddress); _payoutAddress != "" {
+				payoutAddress, err = sdk.AccAddressFromBech32(_payoutAddress)
+				if err != nil {
+					return err
+				}
+			}
+
 			cliCtx := context.NewCLIContext().WithCodec(cdc).
 				WithFromAddress(recipientDid.Address())
 
-			msg := types.NewMsgWithdrawShare(recipientDid.Did, args[0])
+			msg := types.NewMsgWithdrawShare(recipientDid.Did, args[0],
+				amount, viper.GetString(FlagPayoutDid), payoutAddress)
 
 			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, recipientDid)
 		},
 	}
+
+	cmd.Flags().AddFlagSet(fsWithdraw)
 	return cmd
 }
+
+func GetCmdCommitOrder(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "commit-order [from-amount] [from-token] [to-token] [salt] [bond-did] [swapper-did]",
+		Example: "" +
+			"commit-order 100 res1 res2 s3cr3t U7GK8p8rVhJMKhBVRCJJ8c <swapper-ixo-did>\n" +
+			"commit-order 100 res1 res2 s3cr3t U7GK8p8rVhJMKhBVRCJJ8c <swapper-ixo-did> --escrow=150res1",
+		Short: "Commit to a swap order by submitting its hash and escrowing funds",
+		Args:  cobra.ExactArgs(6),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			// Check that from amount and token can be parsed to a coin
+			from, err := client2.ParseTwoPartCoin(args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			// Parse escrow (defaults to from amount)
+			escrow := from
+			_escrow := viper.GetString(FlagEscrow)
+			if _escrow != "" {
+				escrow, err = sdk.ParseCoin(_escrow)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Parse swapper's ixo DID
+			swapperDid, err := did.UnmarshalIxoDid(args[5])
+			if err != nil {
+				return err
+			}
+
+			recipientDid, recipientAddr, err := parseRecipient()
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(swapperDid.Address())
+
+			commitHash := types.GetSwapOrderCommitHash(swapperDid.Did, args[4],
+				from, args[2], recipientDid, recipientAddr, args[3])
+			msg := types.NewMsgCommitOrder(swapperDid.Did, escrow, commitHash, args[4])
+
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, swapperDid)
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsOrder)
+	cmd.Flags().AddFlagSet(fsCommit)
+	return cmd
+}
+
+func GetCmdRevealOrder(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "reveal-order [from-amount] [from-token] [to-token] [salt] [bond-did] [swapper-did]",
+		Example: "reveal-order 100 res1 res2 s3cr3t U7GK8p8rVhJMKhBVRCJJ8c <swapper-ixo-did>",
+		Short:   "Reveal a previously committed swap order",
+		Args:    cobra.ExactArgs(6),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			// Check that from amount and token can be parsed to a coin
+			from, err := client2.ParseTwoPartCoin(args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			// Parse swapper's ixo DID
+			swapperDid, err := did.UnmarshalIxoDid(args[5])
+			if err != nil {
+				return err
+			}
+
+			recipientDid, recipientAddr, err := parseRecipient()
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(swapperDid.Address())
+
+			msg := types.NewMsgRevealOrder(swapperDid.Did, from, args[2],
+				args[4], recipientDid, recipientAddr, args[3])
+
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, swapperDid)
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsOrder)
+	return cmd
+}
+
+func parseRecipient() (recipientDid did.Did, recipientAddr sdk.AccAddress, err error) {
+	recipientDid = viper.GetString(FlagRecipientDid)
+
+	// Parse recipient address (if specified)
+	_recipientAddress := viper.GetString(FlagRecipientAddress)
+	if _recipientAddress != "" {
+		recipientAddr, err = sdk.AccAddressFromBech32(_recipientAddress)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	return recipientDid, recipientAddr, nil
+}
@@ -3,6 +3,7 @@ package client
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
 	"strings"
 )
 
@@ -59,6 +60,13 @@ func ParseFunctionParams(fnParamsStr string) (fnParams types.FunctionParams, err
 	return functionParams, nil
 }
 
+func ParseAccessPolicy(requiredCredentialTypesStr, trustedIssuersStr string) did.AccessPolicy {
+	// Split "a,b" into ["a","b"] (if not empty)
+	requiredCredentialTypes := splitParameters(requiredCredentialTypesStr)
+	trustedIssuers := splitParameters(trustedIssuersStr)
+	return did.NewAccessPolicy(requiredCredentialTypes, trustedIssuers)
+}
+
 func ParseTwoPartCoin(amount, denom string) (coin sdk.Coin, err error) {
 	coin, err = sdk.ParseCoin(amount + denom)
 	if err != nil {
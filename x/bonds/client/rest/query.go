@@ -16,6 +16,11 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute st
 		"/bonds", queryBondsHandler(cliCtx, queryRoute),
 	).Methods("GET")
 
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/metadata/{%s}", RestBondToken),
+		queryBondMetadataHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		fmt.Sprintf("/bonds/{%s}", RestBondDid),
 		queryBondHandler(cliCtx, queryRoute),
@@ -31,6 +36,11 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute st
 		queryLastBatchHandler(cliCtx, queryRoute),
 	).Methods("GET")
 
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/order_commits", RestBondDid),
+		queryOrderCommitsHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		fmt.Sprintf("/bonds/{%s}/current_price", RestBondDid),
 		queryCurrentPriceHandler(cliCtx, queryRoute),
@@ -96,6 +106,23 @@ func queryBondHandler(cliCtx context.CLIContext, queryRoute string) http.Handler
 	}
 }
 
+func queryBondMetadataHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/bond_metadata/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryBatchHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -130,6 +157,23 @@ func queryLastBatchHandler(cliCtx context.CLIContext, queryRoute string) http.Ha
 	}
 }
 
+func queryOrderCommitsHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondDid := vars[RestBondDid]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/order_commits/%s",
+				queryRoute, bondDid), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryCurrentPriceHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
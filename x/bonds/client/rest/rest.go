@@ -11,6 +11,7 @@ import (
 //noinspection GoNameStartsWithPackageName
 const (
 	RestBondDid             = "bond_did"
+	RestBondToken           = "bond_token"
 	RestBondAmount          = "bond_amount"
 	RestFromTokenWithAmount = "from_token_with_amount"
 	RestToToken             = "to_token"
@@ -9,6 +9,7 @@ import (
 	"github.com/ixofoundation/ixo-blockchain/x/bonds/client"
 	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/types"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -20,28 +21,45 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/bonds/swap", swapRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/make_outcome_payment", makeOutcomePaymentRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/bonds/withdraw_share", withdrawShareRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/commit_order", commitOrderRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/bonds/reveal_order", revealOrderRequestHandler(cliCtx)).Methods("POST")
 }
 
 type createBondReq struct {
-	BaseReq                rest.BaseReq `json:"base_req" yaml:"base_req"`
-	Token                  string       `json:"token" yaml:"token"`
-	Name                   string       `json:"name" yaml:"name"`
-	Description            string       `json:"description" yaml:"description"`
-	FunctionType           string       `json:"function_type" yaml:"function_type"`
-	FunctionParameters     string       `json:"function_parameters" yaml:"function_parameters"`
-	ReserveTokens          string       `json:"reserve_tokens" yaml:"reserve_tokens"`
-	TxFeePercentage        string       `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
-	ExitFeePercentage      string       `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
-	FeeAddress             string       `json:"fee_address" yaml:"fee_address"`
-	MaxSupply              string       `json:"max_supply" yaml:"max_supply"`
-	OrderQuantityLimits    string       `json:"order_quantity_limits" yaml:"order_quantity_limits"`
-	SanityRate             string       `json:"sanity_rate" yaml:"sanity_rate"`
-	SanityMarginPercentage string       `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
-	AllowSells             string       `json:"allow_sells" yaml:"allow_sells"`
-	BatchBlocks            string       `json:"batch_blocks" yaml:"batch_blocks"`
-	OutcomePayment         string       `json:"outcome_payment" yaml:"outcome_payment"`
-	BondDid                string       `json:"bond_did" yaml:"bond_did"`
-	CreatorDid             string       `json:"creator_did" yaml:"creator_did"`
+	BaseReq                     rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Token                       string       `json:"token" yaml:"token"`
+	Name                        string       `json:"name" yaml:"name"`
+	Description                 string       `json:"description" yaml:"description"`
+	FunctionType                string       `json:"function_type" yaml:"function_type"`
+	FunctionParameters          string       `json:"function_parameters" yaml:"function_parameters"`
+	ReserveTokens               string       `json:"reserve_tokens" yaml:"reserve_tokens"`
+	ReserveWeights              string       `json:"reserve_weights" yaml:"reserve_weights"`
+	TxFeePercentage             string       `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	ExitFeePercentage           string       `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
+	ImbalanceFeePercentage      string       `json:"imbalance_fee_percentage" yaml:"imbalance_fee_percentage"`
+	ImbalanceFeeExponent        string       `json:"imbalance_fee_exponent" yaml:"imbalance_fee_exponent"`
+	FeeAddress                  string       `json:"fee_address" yaml:"fee_address"`
+	MaxSupply                   string       `json:"max_supply" yaml:"max_supply"`
+	OrderQuantityLimits         string       `json:"order_quantity_limits" yaml:"order_quantity_limits"`
+	SanityRate                  string       `json:"sanity_rate" yaml:"sanity_rate"`
+	SanityMarginPercentage      string       `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	AllowSells                  string       `json:"allow_sells" yaml:"allow_sells"`
+	BatchBlocks                 string       `json:"batch_blocks" yaml:"batch_blocks"`
+	OutcomePayment              string       `json:"outcome_payment" yaml:"outcome_payment"`
+	DisplayDenom                string       `json:"display_denom" yaml:"display_denom"`
+	Exponent                    string       `json:"exponent" yaml:"exponent"`
+	Symbol                      string       `json:"symbol" yaml:"symbol"`
+	IconUri                     string       `json:"icon_uri" yaml:"icon_uri"`
+	Website                     string       `json:"website" yaml:"website"`
+	SettlementClaimBlocks       string       `json:"settlement_claim_blocks" yaml:"settlement_claim_blocks"`
+	UnclaimedReserveAddress     string       `json:"unclaimed_reserve_address" yaml:"unclaimed_reserve_address"`
+	RevealBlocks                string       `json:"reveal_blocks" yaml:"reveal_blocks"`
+	UnrevealedPenaltyPercentage string       `json:"unrevealed_penalty_percentage" yaml:"unrevealed_penalty_percentage"`
+	InstantExecution            string       `json:"instant_execution" yaml:"instant_execution"`
+	RequiredCredentialTypes     string       `json:"required_credential_types" yaml:"required_credential_types"`
+	TrustedIssuers              string       `json:"trusted_issuers" yaml:"trusted_issuers"`
+	BondDid                     string       `json:"bond_did" yaml:"bond_did"`
+	CreatorDid                  string       `json:"creator_did" yaml:"creator_did"`
 }
 
 func createBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -66,6 +84,13 @@ func createBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		// Parse reserve tokens
 		reserveTokens := strings.Split(req.ReserveTokens, ",")
 
+		// Parse reserve weights (optional)
+		reserveWeights, err2 := sdk.ParseDecCoins(req.ReserveWeights)
+		if err2 != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+			return
+		}
+
 		// Parse tx fee percentage
 		txFeePercentageDec, err := sdk.NewDecFromStr(req.TxFeePercentage)
 		if err != nil {
@@ -82,6 +107,28 @@ func createBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		// Parse imbalance fee percentage (optional, zero by default)
+		imbalanceFeePercentage := sdk.ZeroDec()
+		if req.ImbalanceFeePercentage != "" {
+			imbalanceFeePercentage, err = sdk.NewDecFromStr(req.ImbalanceFeePercentage)
+			if err != nil {
+				err = types.ErrArgumentMissingOrNonFloat(types.DefaultCodespace, "imbalance fee percentage")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Parse imbalance fee exponent (optional, zero by default)
+		imbalanceFeeExponent := sdk.ZeroUint()
+		if req.ImbalanceFeeExponent != "" {
+			imbalanceFeeExponent, err2 = sdk.ParseUint(req.ImbalanceFeeExponent)
+			if err2 != nil {
+				err := types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "imbalance fee exponent")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
 		// Parse fee address
 		feeAddress, err2 := sdk.AccAddressFromBech32(req.FeeAddress)
 		if err2 != nil {
@@ -130,6 +177,22 @@ func createBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		// Parse instantExecution (optional; default: false)
+		var instantExecution bool
+		instantExecutionStrLower := strings.ToLower(req.InstantExecution)
+		if instantExecutionStrLower == "true" {
+			instantExecution = true
+		} else if instantExecutionStrLower == "false" || instantExecutionStrLower == "" {
+			instantExecution = false
+		} else {
+			err := types.ErrArgumentMissingOrNonBoolean(types.DefaultCodespace, "instant_execution")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse access policy (optional)
+		accessPolicy := client.ParseAccessPolicy(req.RequiredCredentialTypes, req.TrustedIssuers)
+
 		// Parse batch blocks
 		batchBlocks, err2 := sdk.ParseUint(req.BatchBlocks)
 		if err2 != nil {
@@ -145,11 +208,68 @@ func createBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		// Parse exponent (optional, zero by default)
+		var exponent uint64
+		if req.Exponent != "" {
+			exponent, err2 = strconv.ParseUint(req.Exponent, 10, 32)
+			if err2 != nil {
+				err := types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "exponent")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		metadata := types.NewBondTokenMetadata(req.DisplayDenom,
+			uint32(exponent), req.Symbol, req.IconUri, req.Website)
+
+		// Parse settlement claim blocks (optional, zero by default)
+		settlementClaimBlocks := sdk.ZeroUint()
+		if req.SettlementClaimBlocks != "" {
+			settlementClaimBlocks, err2 = sdk.ParseUint(req.SettlementClaimBlocks)
+			if err2 != nil {
+				err := types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "settlement claim blocks")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Parse unclaimed reserve address (optional)
+		unclaimedReserveAddress, err2 := parseRecipientAddress(req.UnclaimedReserveAddress)
+		if err2 != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err2.Error())
+			return
+		}
+
+		// Parse reveal blocks (optional, zero by default)
+		revealBlocks := sdk.ZeroUint()
+		if req.RevealBlocks != "" {
+			revealBlocks, err2 = sdk.ParseUint(req.RevealBlocks)
+			if err2 != nil {
+				err := types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "reveal blocks")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		// Parse unrevealed penalty percentage (optional, zero by default)
+		unrevealedPenalty := sdk.ZeroDec()
+		if req.UnrevealedPenaltyPercentage != "" {
+			unrevealedPenalty, err = sdk.NewDecFromStr(req.UnrevealedPenaltyPercentage)
+			if err != nil {
+				err = types.ErrArgumentMissingOrNonFloat(types.DefaultCodespace, "unrevealed penalty percentage")
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
 		msg := types.NewMsgCreateBond(req.Token, req.Name, req.Description,
 			req.CreatorDid, req.FunctionType, functionParams, reserveTokens,
 			txFeePercentageDec, exitFeePercentageDec, feeAddress, maxSupply,
 			orderQuantityLimits, sanityRate, sanityMarginPercentage,
-			allowSells, batchBlocks, outcomePayment, req.BondDid)
+			allowSells, batchBlocks, outcomePayment, req.BondDid, metadata,
+			settlementClaimBlocks, unclaimedReserveAddress, revealBlocks,
+			unrevealedPenalty, reserveWeights, instantExecution,
+			imbalanceFeePercentage, imbalanceFeeExponent, accessPolicy)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -167,6 +287,11 @@ type editBondReq struct {
 	OrderQuantityLimits    string       `json:"order_quantity_limits" yaml:"order_quantity_limits"`
 	SanityRate             string       `json:"sanity_rate" yaml:"sanity_rate"`
 	SanityMarginPercentage string       `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	DisplayDenom           string       `json:"display_denom" yaml:"display_denom"`
+	Exponent               string       `json:"exponent" yaml:"exponent"`
+	Symbol                 string       `json:"symbol" yaml:"symbol"`
+	IconUri                string       `json:"icon_uri" yaml:"icon_uri"`
+	Website                string       `json:"website" yaml:"website"`
 	BondDid                string       `json:"bond_did" yaml:"bond_did"`
 	EditorDid              string       `json:"editor_did" yaml:"editor_did"`
 }
@@ -185,7 +310,8 @@ func editBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 
 		msg := types.NewMsgEditBond(req.Token, req.Name, req.Description,
 			req.OrderQuantityLimits, req.SanityRate,
-			req.SanityMarginPercentage, req.EditorDid, req.BondDid)
+			req.SanityMarginPercentage, req.DisplayDenom, req.Exponent,
+			req.Symbol, req.IconUri, req.Website, req.EditorDid, req.BondDid)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -196,12 +322,14 @@ func editBondRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 }
 
 type buyReq struct {
-	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
-	BondToken  string       `json:"bond_token" yaml:"bond_token"`
-	BondAmount string       `json:"bond_amount" yaml:"bond_amount"`
-	MaxPrices  string       `json:"max_prices" yaml:"max_prices"`
-	BondDid    string       `json:"bond_did" yaml:"bond_did"`
-	BuyerDid   string       `json:"buyer_did" yaml:"buyer_did"`
+	BaseReq          rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken        string       `json:"bond_token" yaml:"bond_token"`
+	BondAmount       string       `json:"bond_amount" yaml:"bond_amount"`
+	MaxPrices        string       `json:"max_prices" yaml:"max_prices"`
+	BondDid          string       `json:"bond_did" yaml:"bond_did"`
+	BuyerDid         string       `json:"buyer_did" yaml:"buyer_did"`
+	RecipientDid     string       `json:"recipient_did" yaml:"recipient_did"`
+	RecipientAddress string       `json:"recipient_address" yaml:"recipient_address"`
 }
 
 func buyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -228,7 +356,14 @@ func buyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgBuy(req.BuyerDid, bondCoin, maxPrices, req.BondDid)
+		recipientAddr, err := parseRecipientAddress(req.RecipientAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgBuy(req.BuyerDid, bondCoin, maxPrices,
+			req.BondDid, req.RecipientDid, recipientAddr)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -239,11 +374,14 @@ func buyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 }
 
 type sellReq struct {
-	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
-	BondToken  string       `json:"bond_token" yaml:"bond_token"`
-	BondAmount string       `json:"bond_amount" yaml:"bond_amount"`
-	BondDid    string       `json:"bond_did" yaml:"bond_did"`
-	SellerDid  string       `json:"seller_did" yaml:"seller_did"`
+	BaseReq          rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken        string       `json:"bond_token" yaml:"bond_token"`
+	BondAmount       string       `json:"bond_amount" yaml:"bond_amount"`
+	BondDid          string       `json:"bond_did" yaml:"bond_did"`
+	SellerDid        string       `json:"seller_did" yaml:"seller_did"`
+	RecipientDid     string       `json:"recipient_did" yaml:"recipient_did"`
+	RecipientAddress string       `json:"recipient_address" yaml:"recipient_address"`
+	MinReturns       string       `json:"min_returns" yaml:"min_returns"`
 }
 
 func sellRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -264,7 +402,20 @@ func sellRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgSell(req.SellerDid, bondCoin, req.BondDid)
+		recipientAddr, err := parseRecipientAddress(req.RecipientAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		minReturns, err := sdk.ParseCoins(req.MinReturns)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSell(req.SellerDid, bondCoin, req.BondDid,
+			req.RecipientDid, recipientAddr, minReturns)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -275,12 +426,15 @@ func sellRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 }
 
 type swapReq struct {
-	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
-	FromAmount string       `json:"from_amount" yaml:"from_amount"`
-	FromToken  string       `json:"from_token" yaml:"from_token"`
-	ToToken    string       `json:"to_token" yaml:"to_token"`
-	BondDid    string       `json:"bond_did" yaml:"bond_did"`
-	SwapperDid string       `json:"swapper_did" yaml:"swapper_did"`
+	BaseReq          rest.BaseReq `json:"base_req" yaml:"base_req"`
+	FromAmount       string       `json:"from_amount" yaml:"from_amount"`
+	FromToken        string       `json:"from_token" yaml:"from_token"`
+	ToToken          string       `json:"to_token" yaml:"to_token"`
+	BondDid          string       `json:"bond_did" yaml:"bond_did"`
+	SwapperDid       string       `json:"swapper_did" yaml:"swapper_did"`
+	RecipientDid     string       `json:"recipient_did" yaml:"recipient_did"`
+	RecipientAddress string       `json:"recipient_address" yaml:"recipient_address"`
+	MinReturns       string       `json:"min_returns" yaml:"min_returns"`
 }
 
 func swapRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -302,7 +456,20 @@ func swapRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgSwap(req.SwapperDid, fromCoin, req.ToToken, req.BondDid)
+		recipientAddr, err := parseRecipientAddress(req.RecipientAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		minReturns, err := sdk.ParseCoins(req.MinReturns)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSwap(req.SwapperDid, fromCoin, req.ToToken,
+			req.BondDid, req.RecipientDid, recipientAddr, minReturns)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -341,9 +508,12 @@ func makeOutcomePaymentRequestHandler(cliCtx context.CLIContext) http.HandlerFun
 }
 
 type withdrawShareReq struct {
-	BaseReq      rest.BaseReq `json:"base_req" yaml:"base_req"`
-	BondDid      string       `json:"bond_did" yaml:"bond_did"`
-	RecipientDid string       `json:"recipient_did" yaml:"recipient_did"`
+	BaseReq       rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondDid       string       `json:"bond_did" yaml:"bond_did"`
+	RecipientDid  string       `json:"recipient_did" yaml:"recipient_did"`
+	Amount        string       `json:"amount" yaml:"amount"`
+	PayoutDid     string       `json:"payout_did" yaml:"payout_did"`
+	PayoutAddress string       `json:"payout_address" yaml:"payout_address"`
 }
 
 func withdrawShareRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -358,7 +528,110 @@ func withdrawShareRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgWithdrawShare(req.RecipientDid, req.BondDid)
+		// Parse amount (optional, all bond tokens owned by default)
+		var amount sdk.Coin
+		if req.Amount != "" {
+			var err error
+			amount, err = sdk.ParseCoin(req.Amount)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		payoutAddress, err := parseRecipientAddress(req.PayoutAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgWithdrawShare(req.RecipientDid, req.BondDid,
+			amount, req.PayoutDid, payoutAddress)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type commitOrderReq struct {
+	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Escrow     string       `json:"escrow" yaml:"escrow"`
+	CommitHash string       `json:"commit_hash" yaml:"commit_hash"`
+	BondDid    string       `json:"bond_did" yaml:"bond_did"`
+	SwapperDid string       `json:"swapper_did" yaml:"swapper_did"`
+}
+
+func commitOrderRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req commitOrderReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		// Parse escrow
+		escrow, err := sdk.ParseCoin(req.Escrow)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCommitOrder(req.SwapperDid, escrow, req.CommitHash, req.BondDid)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type revealOrderReq struct {
+	BaseReq          rest.BaseReq `json:"base_req" yaml:"base_req"`
+	FromAmount       string       `json:"from_amount" yaml:"from_amount"`
+	FromToken        string       `json:"from_token" yaml:"from_token"`
+	ToToken          string       `json:"to_token" yaml:"to_token"`
+	Salt             string       `json:"salt" yaml:"salt"`
+	BondDid          string       `json:"bond_did" yaml:"bond_did"`
+	SwapperDid       string       `json:"swapper_did" yaml:"swapper_did"`
+	RecipientDid     string       `json:"recipient_did" yaml:"recipient_did"`
+	RecipientAddress string       `json:"recipient_address" yaml:"recipient_address"`
+}
+
+func revealOrderRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req revealOrderReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		// Check that from amount and token can be parsed to a coin
+		fromCoin, err := client.ParseTwoPartCoin(req.FromAmount, req.FromToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		recipientAddr, err := parseRecipientAddress(req.RecipientAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgRevealOrder(req.SwapperDid, fromCoin, req.ToToken,
+			req.BondDid, req.RecipientDid, recipientAddr, req.Salt)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -367,3 +640,10 @@ func withdrawShareRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
 	}
 }
+
+func parseRecipientAddress(recipientAddress string) (sdk.AccAddress, error) {
+	if recipientAddress == "" {
+		return nil, nil
+	}
+	return sdk.AccAddressFromBech32(recipientAddress)
+}
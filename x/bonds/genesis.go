@@ -8,6 +8,7 @@ import (
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 	// Initialise bonds
 	for _, b := range data.Bonds {
+		b.ApplyDefaults()
 		keeper.SetBond(ctx, b.BondDid, b)
 		keeper.SetBondDid(ctx, b.Token, b.BondDid)
 	}
@@ -17,28 +18,36 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 		keeper.SetBatch(ctx, b.BondDid, b)
 	}
 
+	// Initialise order commits
+	for _, oc := range data.OrderCommits {
+		keeper.AddOrderCommit(ctx, oc.BondDid, oc)
+	}
+
 	// Initialise params
 	keeper.SetParams(ctx, data.Params)
 }
 
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	// Export bonds and batches
+	// Export bonds, batches, and order commits
 	var bonds []types.Bond
 	var batches []types.Batch
+	var orderCommits []types.OrderCommit
 	iterator := k.GetBondIterator(ctx)
 	for ; iterator.Valid(); iterator.Next() {
 		bond := k.MustGetBondByKey(ctx, iterator.Key())
 		batch := k.MustGetBatch(ctx, bond.BondDid)
 		bonds = append(bonds, bond)
 		batches = append(batches, batch)
+		orderCommits = append(orderCommits, k.GetOrderCommits(ctx, bond.BondDid)...)
 	}
 
 	// Export params
 	params := k.GetParams(ctx)
 
 	return GenesisState{
-		Bonds:   bonds,
-		Batches: batches,
-		Params:  params,
+		Bonds:        bonds,
+		Batches:      batches,
+		Params:       params,
+		OrderCommits: orderCommits,
 	}
 }
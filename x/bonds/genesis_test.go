@@ -0,0 +1,51 @@
+package bonds
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/keeper"
+	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/types"
+)
+
+func TestInitGenesisBondWithoutNewFields(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	creatorDid, _ := keeper.AddTestDid(ctx, k, keeper.TestCreatorSeed, nil)
+
+	bond := keeper.NewTestBond(creatorDid)
+	batch := types.NewBatch(bond.BondDid, bond.Token, bond.BatchBlocks)
+	genesisState := NewGenesisState(
+		[]types.Bond{bond}, []types.Batch{batch}, types.DefaultParams(), nil)
+
+	// Remove the fields that bonds exported from an older version do not have
+	var raw map[string]interface{}
+	require.Nil(t, json.Unmarshal(ModuleCdc.MustMarshalJSON(genesisState), &raw))
+	rawBond := raw["bonds"].([]interface{})[0].(map[string]interface{})
+	for _, field := range []string{"settlement_claim_blocks",
+		"reveal_blocks", "unrevealed_penalty_percentage", "reserve_weights",
+		"imbalance_fee_percentage", "imbalance_fee_exponent"} {
+		delete(rawBond, field)
+	}
+	bz, err := json.Marshal(raw)
+	require.Nil(t, err)
+
+	var oldGenesisState GenesisState
+	ModuleCdc.MustUnmarshalJSON(bz, &oldGenesisState)
+	require.Nil(t, ValidateGenesis(oldGenesisState))
+	InitGenesis(ctx, k, oldGenesisState)
+
+	// Unset fields default to zero, disabling the corresponding features
+	imported := k.MustGetBond(ctx, bond.BondDid)
+	require.Equal(t, sdk.ZeroUint(), imported.SettlementClaimBlocks)
+	require.Equal(t, sdk.ZeroUint(), imported.RevealBlocks)
+	require.Equal(t, sdk.ZeroDec(), imported.UnrevealedPenaltyPercentage)
+	require.Equal(t, sdk.ZeroDec(), imported.ImbalanceFeePercentage)
+	require.Equal(t, sdk.ZeroUint(), imported.ImbalanceFeeExponent)
+	require.False(t, imported.CommitRevealEnabled())
+	require.False(t, imported.DynamicFeesEnabled())
+
+	require.NotPanics(t, func() { EndBlocker(ctx, k) })
+}
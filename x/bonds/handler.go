@@ -5,6 +5,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/keeper"
 	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"strconv"
 	"strings"
@@ -28,6 +29,10 @@ func NewHandler(keeper keeper.Keeper) sdk.Handler {
 			return handleMsgMakeOutcomePayment(ctx, keeper, msg)
 		case types.MsgWithdrawShare:
 			return handleMsgWithdrawShare(ctx, keeper, msg)
+		case types.MsgCommitOrder:
+			return handleMsgCommitOrder(ctx, keeper, msg)
+		case types.MsgRevealOrder:
+			return handleMsgRevealOrder(ctx, keeper, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized bonds Msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -42,7 +47,22 @@ func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) []abci.ValidatorUpdate {
 		bond := keeper.MustGetBondByKey(ctx, iterator.Key())
 		batch := keeper.MustGetBatch(ctx, bond.BondDid)
 
+		// If settlement claim deadline reached, sweep unclaimed reserve
+		if bond.SettlementClaimDeadlineReached(ctx.BlockHeight()) {
+			err := keeper.SweepUnclaimedReserve(ctx, bond.BondDid)
+			if err != nil {
+				keeper.Logger(ctx).Error(fmt.Sprintf(
+					"failed to sweep unclaimed reserve of %s: %s", bond.Token, err.Error()))
+			}
+		}
+
+		// Add revealed orders to batch and expire unrevealed order commits
+		if bond.CommitRevealEnabled() {
+			keeper.ProcessOrderCommits(ctx, bond.BondDid)
+		}
+
 		// Subtract one block
+		batch = keeper.MustGetBatch(ctx, bond.BondDid)
 		batch.BlocksRemaining = batch.BlocksRemaining.SubUint64(1)
 		keeper.SetBatch(ctx, bond.BondDid, batch)
 
@@ -81,6 +101,8 @@ func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) []abci.ValidatorUpdate {
 func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCreateBond) sdk.Result {
 	if keeper.BankKeeper.BlacklistedAddr(msg.FeeAddress) {
 		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not allowed to receive transactions", msg.FeeAddress)).Result()
+	} else if keeper.BankKeeper.BlacklistedAddr(msg.UnclaimedReserveAddress) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not allowed to receive transactions", msg.UnclaimedReserveAddress)).Result()
 	}
 
 	// Check that bond and bond DID do not already exist
@@ -130,7 +152,11 @@ func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCre
 		msg.TxFeePercentage, msg.ExitFeePercentage, msg.FeeAddress,
 		msg.MaxSupply, msg.OrderQuantityLimits, msg.SanityRate,
 		msg.SanityMarginPercentage, msg.AllowSells, msg.BatchBlocks,
-		msg.OutcomePayment, state, msg.BondDid)
+		msg.OutcomePayment, state, msg.BondDid, msg.Metadata,
+		msg.SettlementClaimBlocks, msg.UnclaimedReserveAddress,
+		msg.RevealBlocks, msg.UnrevealedPenaltyPercentage, msg.ReserveWeights,
+		msg.InstantExecution, msg.ImbalanceFeePercentage, msg.ImbalanceFeeExponent,
+		msg.AccessPolicy)
 
 	keeper.SetBond(ctx, bond.BondDid, bond)
 	keeper.SetBondDid(ctx, bond.Token, bond.BondDid)
@@ -150,6 +176,7 @@ func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCre
 			sdk.NewAttribute(types.AttributeKeyFunctionType, msg.FunctionType),
 			sdk.NewAttribute(types.AttributeKeyFunctionParameters, msg.FunctionParameters.String()),
 			sdk.NewAttribute(types.AttributeKeyReserveTokens, types.StringsToString(msg.ReserveTokens)),
+			sdk.NewAttribute(types.AttributeKeyReserveWeights, msg.ReserveWeights.String()),
 			sdk.NewAttribute(types.AttributeKeyTxFeePercentage, msg.TxFeePercentage.String()),
 			sdk.NewAttribute(types.AttributeKeyExitFeePercentage, msg.ExitFeePercentage.String()),
 			sdk.NewAttribute(types.AttributeKeyFeeAddress, msg.FeeAddress.String()),
@@ -161,6 +188,20 @@ func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCre
 			sdk.NewAttribute(types.AttributeKeyBatchBlocks, msg.BatchBlocks.String()),
 			sdk.NewAttribute(types.AttributeKeyOutcomePayment, msg.OutcomePayment.String()),
 			sdk.NewAttribute(types.AttributeKeyState, state),
+			sdk.NewAttribute(types.AttributeKeyDisplayDenom, msg.Metadata.DisplayDenom),
+			sdk.NewAttribute(types.AttributeKeyExponent, strconv.FormatUint(uint64(msg.Metadata.Exponent), 10)),
+			sdk.NewAttribute(types.AttributeKeySymbol, msg.Metadata.Symbol),
+			sdk.NewAttribute(types.AttributeKeyIconUri, msg.Metadata.IconUri),
+			sdk.NewAttribute(types.AttributeKeyWebsite, msg.Metadata.Website),
+			sdk.NewAttribute(types.AttributeKeySettlementClaimBlocks, msg.SettlementClaimBlocks.String()),
+			sdk.NewAttribute(types.AttributeKeyUnclaimedReserveAddress, msg.UnclaimedReserveAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyRevealBlocks, msg.RevealBlocks.String()),
+			sdk.NewAttribute(types.AttributeKeyUnrevealedPenaltyPercentage, msg.UnrevealedPenaltyPercentage.String()),
+			sdk.NewAttribute(types.AttributeKeyInstantExecution, strconv.FormatBool(msg.InstantExecution)),
+			sdk.NewAttribute(types.AttributeKeyImbalanceFeePercentage, msg.ImbalanceFeePercentage.String()),
+			sdk.NewAttribute(types.AttributeKeyImbalanceFeeExponent, msg.ImbalanceFeeExponent.String()),
+			sdk.NewAttribute(types.AttributeKeyRequiredCredentialTypes, types.StringsToString(msg.AccessPolicy.RequiredCredentialTypes)),
+			sdk.NewAttribute(types.AttributeKeyTrustedIssuers, types.StringsToString(msg.AccessPolicy.TrustedIssuers)),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -224,6 +265,35 @@ func handleMsgEditBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgEditB
 		bond.SanityMarginPercentage = sanityMarginPercentage
 	}
 
+	if msg.DisplayDenom != types.DoNotModifyField {
+		bond.Metadata.DisplayDenom = msg.DisplayDenom
+	}
+	if msg.Exponent != types.DoNotModifyField {
+		var exponent uint64
+		if msg.Exponent != "" {
+			parsedExponent, err := strconv.ParseUint(msg.Exponent, 10, 32)
+			if err != nil {
+				return types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "exponent").Result()
+			}
+			exponent = parsedExponent
+		}
+		bond.Metadata.Exponent = uint32(exponent)
+	}
+	if msg.Symbol != types.DoNotModifyField {
+		bond.Metadata.Symbol = msg.Symbol
+	}
+	if msg.IconUri != types.DoNotModifyField {
+		bond.Metadata.IconUri = msg.IconUri
+	}
+	if msg.Website != types.DoNotModifyField {
+		bond.Metadata.Website = msg.Website
+	}
+
+	// Validate resultant bond token metadata
+	if err := bond.Metadata.Validate(); err != nil {
+		return err.Result()
+	}
+
 	logger := keeper.Logger(ctx)
 	logger.Info(fmt.Sprintf("bond %s edited by %s",
 		msg.BondDid, msg.EditorDid))
@@ -240,6 +310,11 @@ func handleMsgEditBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgEditB
 			sdk.NewAttribute(types.AttributeKeyOrderQuantityLimits, msg.OrderQuantityLimits),
 			sdk.NewAttribute(types.AttributeKeySanityRate, msg.SanityRate),
 			sdk.NewAttribute(types.AttributeKeySanityMarginPercentage, msg.SanityMarginPercentage),
+			sdk.NewAttribute(types.AttributeKeyDisplayDenom, msg.DisplayDenom),
+			sdk.NewAttribute(types.AttributeKeyExponent, msg.Exponent),
+			sdk.NewAttribute(types.AttributeKeySymbol, msg.Symbol),
+			sdk.NewAttribute(types.AttributeKeyIconUri, msg.IconUri),
+			sdk.NewAttribute(types.AttributeKeyWebsite, msg.Website),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -264,6 +339,19 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) sdk.R
 		return types.ErrBondTokenDoesNotMatchBond(types.DefaultCodespace).Result()
 	}
 
+	// Check that buyer and recipient hold the credentials required by the
+	// bond (if any). A raw recipient address cannot hold credentials, so it
+	// cannot be used to pass bond tokens on to a DID outside of the policy.
+	if err := keeper.DidKeeper.CheckAccessPolicy(ctx, msg.BuyerDid, bond.AccessPolicy); err != nil {
+		return err.Result()
+	} else if !bond.AccessPolicy.IsEmpty() && !msg.RecipientAddress.Empty() {
+		return sdk.ErrUnauthorized("recipient address cannot be used for a bond with an access policy").Result()
+	} else if strings.TrimSpace(msg.RecipientDid) != "" {
+		if err := keeper.DidKeeper.CheckAccessPolicy(ctx, msg.RecipientDid, bond.AccessPolicy); err != nil {
+			return err.Result()
+		}
+	}
+
 	// Check current state is HATCH/OPEN, max prices, order quantity limits
 	if bond.State != types.OpenState && bond.State != types.HatchState {
 		return types.ErrInvalidStateForAction(types.DefaultCodespace).Result()
@@ -280,27 +368,41 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) sdk.R
 		return performFirstSwapperFunctionBuy(ctx, keeper, msg)
 	}
 
+	// Get recipient address (if specified)
+	recipientAddr, err := getRecipientAddress(ctx, keeper, msg.RecipientDid, msg.RecipientAddress)
+	if err != nil {
+		return err.Result()
+	}
+
 	// Take max that buyer is willing to pay (enforces maxPrice <= balance)
-	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, buyerAddr,
+	err = keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, buyerAddr,
 		types.BatchesIntermediaryAccount, msg.MaxPrices)
 	if err != nil {
 		return err.Result()
 	}
 
 	// Create order
-	order := types.NewBuyOrder(msg.BuyerDid, msg.Amount, msg.MaxPrices)
+	order := types.NewBuyOrder(msg.BuyerDid, msg.Amount, msg.MaxPrices, recipientAddr)
 
-	// Get buy price and check if can add buy order to batch
-	buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterBuy(ctx, bond.BondDid, order)
-	if err != nil {
-		return err.Result()
-	}
+	if bond.InstantExecution {
+		// Perform buy order instantly (does not get added to batch)
+		err = keeper.PerformBuyInstantly(ctx, bond.BondDid, order)
+		if err != nil {
+			return err.Result()
+		}
+	} else {
+		// Get buy price and check if can add buy order to batch
+		buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterBuy(ctx, bond.BondDid, order)
+		if err != nil {
+			return err.Result()
+		}
 
-	// Add buy order to batch
-	keeper.AddBuyOrder(ctx, bond.BondDid, order, buyPrices, sellPrices)
+		// Add buy order to batch
+		keeper.AddBuyOrder(ctx, bond.BondDid, order, buyPrices, sellPrices)
 
-	// Cancel unfulfillable orders
-	keeper.CancelUnfulfillableOrders(ctx, bond.BondDid)
+		// Cancel unfulfillable orders
+		keeper.CancelUnfulfillableOrders(ctx, bond.BondDid)
+	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
@@ -308,6 +410,7 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) sdk.R
 			sdk.NewAttribute(types.AttributeKeyBondDid, msg.BondDid),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
 			sdk.NewAttribute(types.AttributeKeyMaxPrices, msg.MaxPrices.String()),
+			sdk.NewAttribute(types.AttributeKeyRecipient, order.GetRecipient(buyerAddr).String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -339,8 +442,16 @@ func performFirstSwapperFunctionBuy(ctx sdk.Context, keeper keeper.Keeper, msg t
 		return types.ErrValuesViolateSanityRate(types.DefaultCodespace).Result()
 	}
 
+	// Get recipient address (if specified)
+	recipientAddr, err := getRecipientAddress(ctx, keeper, msg.RecipientDid, msg.RecipientAddress)
+	if err != nil {
+		return err.Result()
+	} else if recipientAddr.Empty() {
+		recipientAddr = buyerAddr
+	}
+
 	// Use max prices as the amount to send to the liquidity pool (i.e. price)
-	err := keeper.DepositReserve(ctx, bond.BondDid, buyerAddr, msg.MaxPrices)
+	err = keeper.DepositReserve(ctx, bond.BondDid, buyerAddr, msg.MaxPrices)
 	if err != nil {
 		return err.Result()
 	}
@@ -352,9 +463,9 @@ func performFirstSwapperFunctionBuy(ctx sdk.Context, keeper keeper.Keeper, msg t
 		return err.Result()
 	}
 
-	// Send bond tokens to buyer
+	// Send bond tokens to recipient (buyer unless otherwise specified)
 	err = keeper.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-		types.BondsMintBurnAccount, buyerAddr, sdk.Coins{msg.Amount})
+		types.BondsMintBurnAccount, recipientAddr, sdk.Coins{msg.Amount})
 	if err != nil {
 		return err.Result()
 	}
@@ -368,6 +479,7 @@ func performFirstSwapperFunctionBuy(ctx sdk.Context, keeper keeper.Keeper, msg t
 			sdk.NewAttribute(types.AttributeKeyBondDid, msg.BondDid),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
 			sdk.NewAttribute(types.AttributeKeyChargedPrices, msg.MaxPrices.String()),
+			sdk.NewAttribute(types.AttributeKeyRecipient, recipientAddr.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -387,6 +499,11 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) sdk
 		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.BondDid).Result()
 	}
 
+	// Check that seller holds the credentials required by the bond (if any)
+	if err := keeper.DidKeeper.CheckAccessPolicy(ctx, msg.SellerDid, bond.AccessPolicy); err != nil {
+		return err.Result()
+	}
+
 	// Check sells allowed, current state is OPEN, and order limits not exceeded
 	if !bond.AllowSells {
 		return types.ErrBondDoesNotAllowSelling(types.DefaultCodespace).Result()
@@ -401,8 +518,19 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) sdk
 		return types.ErrBondTokenDoesNotMatchBond(types.DefaultCodespace).Result()
 	}
 
+	// Check that min returns only specified if instant execution enabled
+	if !msg.MinReturns.Empty() && !bond.InstantExecution {
+		return types.ErrMinReturnsRequireInstantExecution(types.DefaultCodespace).Result()
+	}
+
+	// Get recipient address (if specified)
+	recipientAddr, err := getRecipientAddress(ctx, keeper, msg.RecipientDid, msg.RecipientAddress)
+	if err != nil {
+		return err.Result()
+	}
+
 	// Send coins to be burned from seller (enforces sellAmount <= balance)
-	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, sellerAddr,
+	err = keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, sellerAddr,
 		types.BondsMintBurnAccount, sdk.Coins{msg.Amount})
 	if err != nil {
 		return err.Result()
@@ -416,16 +544,24 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) sdk
 	}
 
 	// Create order
-	order := types.NewSellOrder(msg.SellerDid, msg.Amount)
+	order := types.NewSellOrder(msg.SellerDid, msg.Amount, recipientAddr, msg.MinReturns)
 
-	// Get sell price and check if can add sell order to batch
-	buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterSell(ctx, bond.BondDid, order)
-	if err != nil {
-		return err.Result()
-	}
+	if bond.InstantExecution {
+		// Perform sell order instantly (does not get added to batch)
+		err = keeper.PerformSellInstantly(ctx, bond.BondDid, order)
+		if err != nil {
+			return err.Result()
+		}
+	} else {
+		// Get sell price and check if can add sell order to batch
+		buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterSell(ctx, bond.BondDid, order)
+		if err != nil {
+			return err.Result()
+		}
 
-	// Add sell order to batch
-	keeper.AddSellOrder(ctx, bond.BondDid, order, buyPrices, sellPrices)
+		// Add sell order to batch
+		keeper.AddSellOrder(ctx, bond.BondDid, order, buyPrices, sellPrices)
+	}
 
 	//// Cancel unfulfillable orders (Note: no need)
 	//keeper.CancelUnfulfillableOrders(ctx, bond.BondDid)
@@ -435,6 +571,8 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) sdk
 			types.EventTypeSell,
 			sdk.NewAttribute(types.AttributeKeyBondDid, msg.BondDid),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyRecipient, order.GetRecipient(sellerAddr).String()),
+			sdk.NewAttribute(types.AttributeKeyMinReturns, msg.MinReturns.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -461,6 +599,16 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) sdk
 		return types.ErrInvalidStateForAction(types.DefaultCodespace).Result()
 	}
 
+	// Check that swapper holds the credentials required by the bond (if any)
+	if err := keeper.DidKeeper.CheckAccessPolicy(ctx, msg.SwapperDid, bond.AccessPolicy); err != nil {
+		return err.Result()
+	}
+
+	// Confirm that swaps do not have to be submitted using commit-reveal
+	if bond.CommitRevealEnabled() {
+		return types.ErrCommitRevealRequired(types.DefaultCodespace).Result()
+	}
+
 	// Check that from and to use reserve token names
 	fromAndTo := sdk.NewCoins(msg.From, sdk.NewCoin(msg.ToToken, sdk.OneInt()))
 	fromAndToDenoms := msg.From.Denom + "," + msg.ToToken
@@ -473,18 +621,37 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) sdk
 		return types.ErrOrderQuantityLimitExceeded(types.DefaultCodespace).Result()
 	}
 
+	// Check that min returns only specified if instant execution enabled
+	if !msg.MinReturns.Empty() && !bond.InstantExecution {
+		return types.ErrMinReturnsRequireInstantExecution(types.DefaultCodespace).Result()
+	}
+
+	// Get recipient address (if specified)
+	recipientAddr, err := getRecipientAddress(ctx, keeper, msg.RecipientDid, msg.RecipientAddress)
+	if err != nil {
+		return err.Result()
+	}
+
 	// Take coins to be swapped from swapper (enforces swapAmount <= balance)
-	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, swapperAddr,
+	err = keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, swapperAddr,
 		types.BatchesIntermediaryAccount, sdk.Coins{msg.From})
 	if err != nil {
 		return err.Result()
 	}
 
 	// Create order
-	order := types.NewSwapOrder(msg.SwapperDid, msg.From, msg.ToToken)
+	order := types.NewSwapOrder(msg.SwapperDid, msg.From, msg.ToToken, recipientAddr, msg.MinReturns)
 
-	// Add swap order to batch
-	keeper.AddSwapOrder(ctx, bond.BondDid, order)
+	if bond.InstantExecution {
+		// Perform swap order instantly (does not get added to batch)
+		err = keeper.PerformSwapInstantly(ctx, bond.BondDid, order)
+		if err != nil {
+			return err.Result()
+		}
+	} else {
+		// Add swap order to batch
+		keeper.AddSwapOrder(ctx, bond.BondDid, order)
+	}
 
 	//// Cancel unfulfillable orders (Note: no need)
 	//keeper.CancelUnfulfillableOrders(ctx, bond.BondDid)
@@ -496,6 +663,8 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) sdk
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.From.Amount.String()),
 			sdk.NewAttribute(types.AttributeKeySwapFromToken, msg.From.Denom),
 			sdk.NewAttribute(types.AttributeKeySwapToToken, msg.ToToken),
+			sdk.NewAttribute(types.AttributeKeyRecipient, order.GetRecipient(swapperAddr).String()),
+			sdk.NewAttribute(types.AttributeKeyMinReturns, msg.MinReturns.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -531,11 +700,21 @@ func handleMsgMakeOutcomePayment(ctx sdk.Context, keeper keeper.Keeper, msg type
 	// Set bond state to SETTLE
 	keeper.SetBondState(ctx, bond.BondDid, types.SettleState)
 
+	// Set settlement claim deadline (if any), after which unclaimed reserve
+	// is swept to the unclaimed reserve address and the bond is closed
+	bond = keeper.MustGetBond(ctx, bond.BondDid)
+	if bond.HasSettlementClaimDeadline() {
+		bond.SettlementDeadline = ctx.BlockHeight() +
+			int64(bond.SettlementClaimBlocks.Uint64())
+		keeper.SetBond(ctx, bond.BondDid, bond)
+	}
+
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeMakeOutcomePayment,
 			sdk.NewAttribute(types.AttributeKeyBondDid, msg.BondDid),
 			sdk.NewAttribute(types.AttributeKeyAddress, senderAddr.String()),
+			sdk.NewAttribute(types.AttributeKeySettlementDeadline, strconv.FormatInt(bond.SettlementDeadline, 10)),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -560,47 +739,68 @@ func handleMsgWithdrawShare(ctx sdk.Context, keeper keeper.Keeper, msg types.Msg
 		return types.ErrInvalidStateForAction(types.DefaultCodespace).Result()
 	}
 
+	// Get payout address (recipient unless otherwise specified)
+	payoutAddr, err := getRecipientAddress(ctx, keeper, msg.PayoutDid, msg.PayoutAddress)
+	if err != nil {
+		return err.Result()
+	} else if payoutAddr.Empty() {
+		payoutAddr = recipientAddr
+	}
+
 	// Get number of bond tokens owned by the recipient
 	bondTokensOwnedAmount := keeper.BankKeeper.GetCoins(ctx, recipientAddr).AmountOf(bond.Token)
 	if bondTokensOwnedAmount.IsZero() {
 		return types.ErrNoBondTokensOwned(types.DefaultCodespace).Result()
 	}
-	bondTokensOwned := sdk.NewCoin(bond.Token, bondTokensOwnedAmount)
+
+	// Withdraw all bond tokens owned unless an amount was specified
+	bondTokensToWithdraw := sdk.NewCoin(bond.Token, bondTokensOwnedAmount)
+	if !msg.WithdrawsAll() {
+		if msg.Amount.Denom != bond.Token {
+			return types.ErrBondTokenDoesNotMatchBond(types.DefaultCodespace).Result()
+		} else if msg.Amount.Amount.GT(bondTokensOwnedAmount) {
+			return sdk.ErrInsufficientCoins(fmt.Sprintf(
+				"insufficient bond tokens owned: %s < %s", bondTokensOwnedAmount, msg.Amount)).Result()
+		}
+		bondTokensToWithdraw = msg.Amount
+	}
 
 	// Send coins to be burned from recipient
-	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(
-		ctx, recipientAddr, types.BondsMintBurnAccount, sdk.NewCoins(bondTokensOwned))
+	err = keeper.SupplyKeeper.SendCoinsFromAccountToModule(
+		ctx, recipientAddr, types.BondsMintBurnAccount, sdk.NewCoins(bondTokensToWithdraw))
 	if err != nil {
 		return err.Result()
 	}
 
 	// Burn bond tokens
 	err = keeper.SupplyKeeper.BurnCoins(ctx, types.BondsMintBurnAccount,
-		sdk.NewCoins(sdk.NewCoin(bond.Token, bondTokensOwnedAmount)))
+		sdk.NewCoins(bondTokensToWithdraw))
 	if err != nil {
 		return err.Result()
 	}
 
 	// Calculate amount owned
 	remainingReserve := keeper.GetReserveBalances(ctx, bond.BondDid)
-	bondTokensShare := bondTokensOwnedAmount.ToDec().QuoInt(bond.CurrentSupply.Amount)
+	bondTokensShare := bondTokensToWithdraw.Amount.ToDec().QuoInt(bond.CurrentSupply.Amount)
 	reserveOwedDec := sdk.NewDecCoins(remainingReserve).MulDec(bondTokensShare)
 	reserveOwed, _ := reserveOwedDec.TruncateDecimal()
 
-	// Send coins owed to recipient
-	err = keeper.WithdrawReserve(ctx, bond.BondDid, recipientAddr, reserveOwed)
+	// Send coins owed to payout address
+	err = keeper.WithdrawReserve(ctx, bond.BondDid, payoutAddr, reserveOwed)
 	if err != nil {
 		return err.Result()
 	}
 
 	// Update supply
-	keeper.SetCurrentSupply(ctx, bond.BondDid, bond.CurrentSupply.Sub(bondTokensOwned))
+	keeper.SetCurrentSupply(ctx, bond.BondDid, bond.CurrentSupply.Sub(bondTokensToWithdraw))
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeWithdrawShare,
 			sdk.NewAttribute(types.AttributeKeyBondDid, msg.BondDid),
 			sdk.NewAttribute(types.AttributeKeyAddress, recipientAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyRecipient, payoutAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyBondTokensWithdrawn, bondTokensToWithdraw.Amount.String()),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, reserveOwed.String()),
 		),
 		sdk.NewEvent(
@@ -612,3 +812,183 @@ func handleMsgWithdrawShare(ctx sdk.Context, keeper keeper.Keeper, msg types.Msg
 
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
+
+func getRecipientAddress(ctx sdk.Context, keeper keeper.Keeper, recipientDid did.Did,
+	recipientAddress sdk.AccAddress) (sdk.AccAddress, sdk.Error) {
+
+	// Get recipient DID's address (if a recipient DID was specified)
+	if strings.TrimSpace(recipientDid) != "" {
+		recipientDidDoc, err := keeper.DidKeeper.GetDidDoc(ctx, recipientDid)
+		if err != nil {
+			return nil, err
+		} else if recipientDidDoc.IsDeactivated() {
+			return nil, sdk.ErrUnauthorized("recipient did is deactivated")
+		}
+		recipientAddress = recipientDidDoc.Address()
+	}
+
+	// Ensure recipient is not a blacklisted address
+	if !recipientAddress.Empty() && keeper.BankKeeper.BlacklistedAddr(recipientAddress) {
+		return nil, sdk.ErrUnauthorized(fmt.Sprintf(
+			"%s is not allowed to receive transactions", recipientAddress))
+	}
+
+	return recipientAddress, nil
+}
+
+func handleMsgCommitOrder(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCommitOrder) sdk.Result {
+	swapperAddr := keeper.DidKeeper.MustGetDidDoc(ctx, msg.SwapperDid).Address()
+
+	bond, found := keeper.GetBond(ctx, msg.BondDid)
+	if !found {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.BondDid).Result()
+	}
+
+	// Confirm that function type is swapper_function and state is OPEN
+	if bond.FunctionType != types.SwapperFunction {
+		return types.ErrFunctionNotAvailableForFunctionType(types.DefaultCodespace).Result()
+	} else if bond.State != types.OpenState {
+		return types.ErrInvalidStateForAction(types.DefaultCodespace).Result()
+	}
+
+	// Check that swapper holds the credentials required by the bond (if any)
+	if err := keeper.DidKeeper.CheckAccessPolicy(ctx, msg.SwapperDid, bond.AccessPolicy); err != nil {
+		return err.Result()
+	}
+
+	// Confirm that commit-reveal is enabled for the bond
+	if !bond.CommitRevealEnabled() {
+		return types.ErrCommitRevealNotEnabled(types.DefaultCodespace).Result()
+	}
+
+	// Check that escrow uses a reserve token name
+	if msg.Escrow.Denom != bond.ReserveTokens[0] &&
+		msg.Escrow.Denom != bond.ReserveTokens[1] {
+		return types.ErrTokenIsNotAValidReserveToken(types.DefaultCodespace, msg.Escrow.Denom).Result()
+	}
+
+	// Check that commit hash not already used by the swapper. Commits are
+	// stored per swapper, so that others cannot reuse the hash to block it.
+	if keeper.OrderCommitExists(ctx, bond.BondDid, msg.SwapperDid, msg.CommitHash) {
+		return types.ErrOrderCommitAlreadyExists(types.DefaultCodespace, msg.CommitHash).Result()
+	}
+
+	// Take escrow from swapper (enforces escrow <= balance)
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, swapperAddr,
+		types.BatchesIntermediaryAccount, sdk.Coins{msg.Escrow})
+	if err != nil {
+		return err.Result()
+	}
+
+	// Add order commit
+	commit := types.NewOrderCommit(bond.BondDid, msg.SwapperDid,
+		msg.CommitHash, msg.Escrow, ctx.BlockHeight())
+	keeper.AddOrderCommit(ctx, bond.BondDid, commit)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCommitOrder,
+			sdk.NewAttribute(types.AttributeKeyBondDid, bond.BondDid),
+			sdk.NewAttribute(types.AttributeKeyCommitHash, msg.CommitHash),
+			sdk.NewAttribute(types.AttributeKeyEscrow, msg.Escrow.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.SwapperDid),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgRevealOrder(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgRevealOrder) sdk.Result {
+	swapperAddr := keeper.DidKeeper.MustGetDidDoc(ctx, msg.SwapperDid).Address()
+
+	bond, found := keeper.GetBond(ctx, msg.BondDid)
+	if !found {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.BondDid).Result()
+	}
+
+	// Confirm that state is OPEN and that commit-reveal is enabled
+	if bond.State != types.OpenState {
+		return types.ErrInvalidStateForAction(types.DefaultCodespace).Result()
+	} else if !bond.CommitRevealEnabled() {
+		return types.ErrCommitRevealNotEnabled(types.DefaultCodespace).Result()
+	}
+
+	// Get the swapper's order commit that the revealed order hashes to
+	commitHash := msg.GetCommitHash()
+	commit, found := keeper.GetOrderCommit(ctx, bond.BondDid, msg.SwapperDid, commitHash)
+	if !found {
+		return types.ErrOrderCommitDoesNotExist(types.DefaultCodespace, commitHash).Result()
+	} else if commit.Revealed {
+		return types.ErrOrderCommitAlreadyRevealed(types.DefaultCodespace, commitHash).Result()
+	}
+
+	// Check that reveal window is open
+	if !commit.RevealWindowOpen(ctx.BlockHeight(), bond.RevealBlocks) {
+		return types.ErrRevealWindowNotOpen(types.DefaultCodespace).Result()
+	}
+
+	// Check that from amount fits in escrow
+	if msg.From.Denom != commit.Escrow.Denom || commit.Escrow.IsLT(msg.From) {
+		return types.ErrRevealedOrderDoesNotMatchEscrow(types.DefaultCodespace, msg.From, commit.Escrow).Result()
+	}
+
+	// Check that from and to use reserve token names
+	fromAndTo := sdk.NewCoins(msg.From, sdk.NewCoin(msg.ToToken, sdk.OneInt()))
+	fromAndToDenoms := msg.From.Denom + "," + msg.ToToken
+	if !bond.ReserveDenomsEqualTo(fromAndTo) {
+		return types.ErrReserveDenomsMismatch(types.DefaultCodespace, fromAndToDenoms, bond.ReserveTokens).Result()
+	}
+
+	// Check if order quantity limit exceeded
+	if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.From}) {
+		return types.ErrOrderQuantityLimitExceeded(types.DefaultCodespace).Result()
+	}
+
+	// Get recipient address (if specified)
+	recipientAddr, err := getRecipientAddress(ctx, keeper, msg.RecipientDid, msg.RecipientAddress)
+	if err != nil {
+		return err.Result()
+	}
+
+	// Return any escrow not used by the revealed order to the swapper
+	excess := commit.Escrow.Sub(msg.From)
+	if excess.IsPositive() {
+		err = keeper.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, swapperAddr, sdk.Coins{excess})
+		if err != nil {
+			return err.Result()
+		}
+	}
+
+	// Create order and mark order commit as revealed. The order is added to
+	// the batch once the reveal window closes, in the order of the commits.
+	order := types.NewSwapOrder(msg.SwapperDid, msg.From, msg.ToToken, recipientAddr, nil)
+	commit.Escrow = msg.From
+	commit.Revealed = true
+	commit.Order = order
+	keeper.SetOrderCommit(ctx, bond.BondDid, commit)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRevealOrder,
+			sdk.NewAttribute(types.AttributeKeyBondDid, bond.BondDid),
+			sdk.NewAttribute(types.AttributeKeyCommitHash, commitHash),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.From.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeySwapFromToken, msg.From.Denom),
+			sdk.NewAttribute(types.AttributeKeySwapToToken, msg.ToToken),
+			sdk.NewAttribute(types.AttributeKeyRecipient, order.GetRecipient(swapperAddr).String()),
+			sdk.NewAttribute(types.AttributeKeyReturnedToAddress, excess.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.SwapperDid),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
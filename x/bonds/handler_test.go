@@ -0,0 +1,318 @@
+package bonds
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/keeper"
+	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+)
+
+const testReserveToken2 = "rez"
+
+func newTestMsgCreateBond(bond types.Bond) types.MsgCreateBond {
+	return types.NewMsgCreateBond(bond.Token, bond.Name, bond.Description,
+		bond.CreatorDid, bond.FunctionType, bond.FunctionParameters,
+		bond.ReserveTokens, bond.TxFeePercentage, bond.ExitFeePercentage,
+		bond.FeeAddress, bond.MaxSupply, bond.OrderQuantityLimits,
+		bond.SanityRate, bond.SanityMarginPercentage, bond.AllowSells,
+		bond.BatchBlocks, bond.OutcomePayment, bond.BondDid, bond.Metadata,
+		bond.SettlementClaimBlocks, bond.UnclaimedReserveAddress,
+		bond.RevealBlocks, bond.UnrevealedPenaltyPercentage,
+		bond.ReserveWeights, bond.InstantExecution,
+		bond.ImbalanceFeePercentage, bond.ImbalanceFeeExponent,
+		bond.AccessPolicy)
+}
+
+// setUpTestSwapperBond turns the test bond into a swapper function bond with
+// two reserve tokens.
+func setUpTestSwapperBond(bond *types.Bond) {
+	bond.FunctionType = types.SwapperFunction
+	bond.FunctionParameters = nil
+	bond.ReserveTokens = []string{keeper.TestReserveToken, testReserveToken2}
+}
+
+func TestHandlerCreateAndEditBondMetadata(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	creatorDid, _ := keeper.AddTestDid(ctx, k, keeper.TestCreatorSeed, nil)
+	handler := NewHandler(k)
+
+	bond := keeper.NewTestBond(creatorDid)
+	bond.Metadata = types.NewBondTokenMetadata("mabc", 3, "ABC", "", "")
+	res := handler(ctx, newTestMsgCreateBond(bond))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, bond.Metadata, k.MustGetBond(ctx, bond.BondDid).Metadata)
+
+	// Edit only the symbol and icon URI
+	dnm := types.DoNotModifyField
+	msg := types.NewMsgEditBond(bond.Token, dnm, dnm, dnm, dnm, dnm, dnm, dnm,
+		"ABCD", "https://abc.com/icon.png", dnm, creatorDid, bond.BondDid)
+	res = handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	expected := types.NewBondTokenMetadata(
+		"mabc", 3, "ABCD", "https://abc.com/icon.png", "")
+	require.Equal(t, expected, k.MustGetBond(ctx, bond.BondDid).Metadata)
+
+	// Clearing the display denom while an exponent is set is rejected
+	msg = types.NewMsgEditBond(bond.Token, dnm, dnm, dnm, dnm, dnm, "", dnm,
+		dnm, dnm, dnm, creatorDid, bond.BondDid)
+	res = handler(ctx, msg)
+	require.False(t, res.IsOK())
+	require.Equal(t, expected, k.MustGetBond(ctx, bond.BondDid).Metadata)
+
+	// Only the creator can edit the bond
+	otherDid, _ := keeper.AddTestDid(ctx, k, keeper.TestAccountSeed, nil)
+	msg = types.NewMsgEditBond(bond.Token, dnm, dnm, dnm, dnm, dnm, dnm, dnm,
+		"XYZ", dnm, dnm, otherDid, bond.BondDid)
+	require.False(t, handler(ctx, msg).IsOK())
+}
+
+func TestHandlerBuyToRecipientDid(t *testing.T) {
+	ctx, k, bond := keeper.CreateTestInputWithBond(nil)
+	buyerDid, _ := keeper.AddTestDid(ctx, k, keeper.TestAccountSeed, sdk.NewCoins(
+		sdk.NewInt64Coin(keeper.TestReserveToken, 100000)))
+	recipientDid, recipientAddr := keeper.AddTestDid(ctx, k, keeper.TestRecipientSeed, nil)
+	handler := NewHandler(k)
+
+	amount := sdk.NewInt64Coin(keeper.TestToken, 10)
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(keeper.TestReserveToken, 100000))
+	msg := types.NewMsgBuy(buyerDid, amount, maxPrices, bond.BondDid, recipientDid, nil)
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	// Order is added to the batch with the recipient DID's address
+	batch := k.MustGetBatch(ctx, bond.BondDid)
+	require.Len(t, batch.Buys, 1)
+	require.Equal(t, recipientAddr, batch.Buys[0].Recipient)
+
+	// Bond tokens go to the recipient when the batch is performed
+	EndBlocker(ctx, k)
+	require.Equal(t, amount.Amount,
+		k.BankKeeper.GetCoins(ctx, recipientAddr).AmountOf(keeper.TestToken))
+
+	// A deactivated recipient DID is rejected
+	require.Nil(t, k.DidKeeper.DeactivateDid(ctx, recipientDid))
+	res = handler(ctx, msg)
+	require.False(t, res.IsOK())
+	require.Equal(t, sdk.CodeUnauthorized, res.Code)
+}
+
+func TestHandlerSettlement(t *testing.T) {
+	reserveToken := keeper.TestReserveToken
+	outcomePayment := sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 1000))
+	ctx, k, bond := keeper.CreateTestInputWithBond(func(bond *types.Bond) {
+		bond.OutcomePayment = outcomePayment
+		bond.SettlementClaimBlocks = sdk.NewUint(5)
+	})
+	ctx = ctx.WithBlockHeight(100)
+	holderDid, holderAddr := keeper.AddTestDid(ctx, k, keeper.TestAccountSeed, nil)
+	handler := NewHandler(k)
+
+	creatorAddr := k.DidKeeper.MustGetDidDoc(ctx, bond.CreatorDid).Address()
+	_, err := k.BankKeeper.AddCoins(ctx, creatorAddr, outcomePayment)
+	require.Nil(t, err)
+
+	// Holder owns the full supply of 10 bond tokens
+	bondTokens := sdk.NewCoins(sdk.NewInt64Coin(bond.Token, 10))
+	require.Nil(t, k.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, bondTokens))
+	require.Nil(t, k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+		types.BondsMintBurnAccount, holderAddr, bondTokens))
+	k.SetCurrentSupply(ctx, bond.BondDid, bondTokens[0])
+
+	// Outcome payment settles the bond and sets the claim deadline
+	res := handler(ctx, types.NewMsgMakeOutcomePayment(bond.CreatorDid, bond.BondDid))
+	require.True(t, res.IsOK(), res.Log)
+	bond = k.MustGetBond(ctx, bond.BondDid)
+	require.Equal(t, types.SettleState, bond.State)
+	require.Equal(t, int64(105), bond.SettlementDeadline)
+
+	// Withdraw a share of 3 out of 10 bond tokens
+	amount := sdk.NewInt64Coin(bond.Token, 3)
+	res = handler(ctx, types.NewMsgWithdrawShare(holderDid, bond.BondDid, amount, "", nil))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.NewCoins(
+		sdk.NewInt64Coin(bond.Token, 7), sdk.NewInt64Coin(reserveToken, 300)),
+		k.BankKeeper.GetCoins(ctx, holderAddr))
+
+	// Cannot withdraw more bond tokens than owned
+	amount = sdk.NewInt64Coin(bond.Token, 8)
+	res = handler(ctx, types.NewMsgWithdrawShare(holderDid, bond.BondDid, amount, "", nil))
+	require.False(t, res.IsOK())
+
+	// Unclaimed reserve is not swept before the deadline
+	EndBlocker(ctx.WithBlockHeight(104), k)
+	require.Equal(t, types.SettleState, k.MustGetBond(ctx, bond.BondDid).State)
+
+	// Unclaimed reserve is swept exactly at the deadline
+	EndBlocker(ctx.WithBlockHeight(105), k)
+	require.Equal(t, types.ClosedState, k.MustGetBond(ctx, bond.BondDid).State)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(reserveToken, 700)),
+		k.BankKeeper.GetCoins(ctx, keeper.TestUnclaimedReserveAddress))
+}
+
+func TestHandlerCommitRevealOrder(t *testing.T) {
+	ctx, k, bond := keeper.CreateTestInputWithBond(func(bond *types.Bond) {
+		setUpTestSwapperBond(bond)
+		bond.RevealBlocks = sdk.NewUint(2)
+	})
+	ctx = ctx.WithBlockHeight(10)
+	escrow := sdk.NewInt64Coin(keeper.TestReserveToken, 100)
+	swapperDid, swapperAddr := keeper.AddTestDid(ctx, k, keeper.TestAccountSeed, sdk.NewCoins(escrow))
+	otherDid, _ := keeper.AddTestDid(ctx, k, keeper.TestRecipientSeed, sdk.NewCoins(escrow))
+	handler := NewHandler(k)
+
+	from := sdk.NewInt64Coin(keeper.TestReserveToken, 60)
+	reveal := types.NewMsgRevealOrder(swapperDid, from, testReserveToken2,
+		bond.BondDid, "", nil, "salt")
+	hash := reveal.GetCommitHash()
+
+	// Another swapper committing the same hash first does not block the swapper
+	res := handler(ctx, types.NewMsgCommitOrder(otherDid, escrow, hash, bond.BondDid))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, types.NewMsgCommitOrder(swapperDid, escrow, hash, bond.BondDid))
+	require.True(t, res.IsOK(), res.Log)
+	require.True(t, k.BankKeeper.GetCoins(ctx, swapperAddr).Empty())
+
+	// Cannot reveal in the same block as the commit
+	require.False(t, handler(ctx, reveal).IsOK())
+
+	// Revealing returns the escrow not used by the order
+	ctx = ctx.WithBlockHeight(11)
+	res = handler(ctx, reveal)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.NewCoins(escrow.Sub(from)), k.BankKeeper.GetCoins(ctx, swapperAddr))
+
+	commit, found := k.GetOrderCommit(ctx, bond.BondDid, swapperDid, hash)
+	require.True(t, found)
+	require.True(t, commit.Revealed)
+	require.Equal(t, from, commit.Escrow)
+
+	// Cannot reveal the same order twice
+	require.False(t, handler(ctx, reveal).IsOK())
+}
+
+func TestHandlerInstantExecution(t *testing.T) {
+	ctx, k, bond := keeper.CreateTestInputWithBond(func(bond *types.Bond) {
+		setUpTestSwapperBond(bond)
+		bond.InstantExecution = true
+	})
+	buyerDid, buyerAddr := keeper.AddTestDid(ctx, k, keeper.TestAccountSeed, sdk.NewCoins(
+		sdk.NewInt64Coin(keeper.TestReserveToken, 10000),
+		sdk.NewInt64Coin(testReserveToken2, 10000)))
+	handler := NewHandler(k)
+
+	// First buy initialises the reserves at 1000res and 1000rez per token
+	amount := sdk.NewInt64Coin(bond.Token, 1)
+	maxPrices := sdk.NewCoins(
+		sdk.NewInt64Coin(keeper.TestReserveToken, 1000),
+		sdk.NewInt64Coin(testReserveToken2, 1000))
+	res := handler(ctx, types.NewMsgBuy(buyerDid, amount, maxPrices, bond.BondDid, "", nil))
+	require.True(t, res.IsOK(), res.Log)
+
+	// Second buy is performed instantly at 1000 each plus a 0.5% tx fee
+	maxPrices = sdk.NewCoins(
+		sdk.NewInt64Coin(keeper.TestReserveToken, 2000),
+		sdk.NewInt64Coin(testReserveToken2, 2000))
+	res = handler(ctx, types.NewMsgBuy(buyerDid, amount, maxPrices, bond.BondDid, "", nil))
+	require.True(t, res.IsOK(), res.Log)
+	require.Empty(t, k.MustGetBatch(ctx, bond.BondDid).Buys)
+	require.Equal(t, sdk.NewCoins(
+		sdk.NewInt64Coin(bond.Token, 2),
+		sdk.NewInt64Coin(keeper.TestReserveToken, 7995),
+		sdk.NewInt64Coin(testReserveToken2, 7995)),
+		k.BankKeeper.GetCoins(ctx, buyerAddr))
+
+	// Swap of 100res (99res after fee) returns 2000-2000*2000/2099 = 94.3rez
+	from := sdk.NewInt64Coin(keeper.TestReserveToken, 100)
+	tooHigh := sdk.NewCoins(sdk.NewInt64Coin(testReserveToken2, 95))
+	msg := types.NewMsgSwap(buyerDid, from, testReserveToken2, bond.BondDid, "", nil, tooHigh)
+	cacheCtx, _ := ctx.CacheContext()
+	res = handler(cacheCtx, msg)
+	require.False(t, res.IsOK())
+	require.Equal(t, types.CodeMinReturnsNotMet, res.Code)
+
+	minReturns := sdk.NewCoins(sdk.NewInt64Coin(testReserveToken2, 94))
+	msg = types.NewMsgSwap(buyerDid, from, testReserveToken2, bond.BondDid, "", nil, minReturns)
+	res = handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	require.Empty(t, k.MustGetBatch(ctx, bond.BondDid).Swaps)
+	require.Equal(t, sdk.NewCoins(
+		sdk.NewInt64Coin(bond.Token, 2),
+		sdk.NewInt64Coin(keeper.TestReserveToken, 7895),
+		sdk.NewInt64Coin(testReserveToken2, 8089)),
+		k.BankKeeper.GetCoins(ctx, buyerAddr))
+}
+
+func TestHandlerBuyAccessPolicy(t *testing.T) {
+	ctx, k, bond := keeper.CreateTestInputWithBond(func(bond *types.Bond) {
+		bond.AccessPolicy = did.NewAccessPolicy([]string{"ProofOfKYC"}, nil)
+	})
+	buyerDid, _ := keeper.AddTestDid(ctx, k, keeper.TestAccountSeed, sdk.NewCoins(
+		sdk.NewInt64Coin(keeper.TestReserveToken, 100000)))
+	handler := NewHandler(k)
+
+	amount := sdk.NewInt64Coin(keeper.TestToken, 10)
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(keeper.TestReserveToken, 100000))
+	msg := types.NewMsgBuy(buyerDid, amount, maxPrices, bond.BondDid, "", nil)
+
+	// Buy is rejected since the buyer does not hold the credential
+	res := handler(ctx, msg)
+	require.False(t, res.IsOK())
+	require.Equal(t, sdk.CodeType(did.CodeAccessDenied), res.Code)
+
+	// Buy is accepted once the buyer holds the credential
+	credential := did.NewMsgAddCredential(buyerDid, []string{"Credential", "ProofOfKYC"},
+		bond.CreatorDid, "2020-01-01", "").DidCredential
+	require.Nil(t, k.DidKeeper.AddCredentials(ctx, buyerDid, credential))
+	res = handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	require.Len(t, k.MustGetBatch(ctx, bond.BondDid).Buys, 1)
+
+	// Buy to a recipient DID without the credential is rejected
+	recipientDid, recipientAddr := keeper.AddTestDid(ctx, k, keeper.TestRecipientSeed, nil)
+	res = handler(ctx, types.NewMsgBuy(buyerDid, amount, maxPrices, bond.BondDid, recipientDid, nil))
+	require.False(t, res.IsOK())
+	require.Equal(t, sdk.CodeType(did.CodeAccessDenied), res.Code)
+
+	// Buy to a raw recipient address is rejected, since it cannot hold
+	// credentials
+	res = handler(ctx, types.NewMsgBuy(buyerDid, amount, maxPrices, bond.BondDid, "", recipientAddr))
+	require.False(t, res.IsOK())
+	require.Equal(t, sdk.CodeUnauthorized, res.Code)
+}
+
+func TestHandlerSwapAccessPolicy(t *testing.T) {
+	ctx, k, bond := keeper.CreateTestInputWithBond(func(bond *types.Bond) {
+		setUpTestSwapperBond(bond)
+		bond.AccessPolicy = did.NewAccessPolicy([]string{"ProofOfKYC"}, nil)
+	})
+	from := sdk.NewInt64Coin(keeper.TestReserveToken, 100)
+	swapperDid, _ := keeper.AddTestDid(ctx, k, keeper.TestAccountSeed, sdk.NewCoins(from))
+	handler := NewHandler(k)
+
+	// Swap is rejected since the swapper does not hold the credential
+	msg := types.NewMsgSwap(swapperDid, from, testReserveToken2, bond.BondDid, "", nil, nil)
+	res := handler(ctx, msg)
+	require.False(t, res.IsOK())
+	require.Equal(t, sdk.CodeType(did.CodeAccessDenied), res.Code)
+
+	// The same applies to sells and swap order commits
+	amount := sdk.NewInt64Coin(bond.Token, 1)
+	res = handler(ctx, types.NewMsgSell(swapperDid, amount, bond.BondDid, "", nil, nil))
+	require.Equal(t, sdk.CodeType(did.CodeAccessDenied), res.Code)
+	hash := "f4b3a8b3cc2b05cd5fd8fa1d0a2d6bd0f1d4ee5e0e1bb0e2cc1e6d3ba42a9b3e"
+	res = handler(ctx, types.NewMsgCommitOrder(swapperDid, from, hash, bond.BondDid))
+	require.Equal(t, sdk.CodeType(did.CodeAccessDenied), res.Code)
+
+	// Swap is accepted once the swapper holds the credential
+	credential := did.NewMsgAddCredential(swapperDid, []string{"Credential", "ProofOfKYC"},
+		bond.CreatorDid, "2020-01-01", "").DidCredential
+	require.Nil(t, k.DidKeeper.AddCredentials(ctx, swapperDid, credential))
+	res = handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	require.Len(t, k.MustGetBatch(ctx, bond.BondDid).Swaps, 1)
+}
@@ -171,7 +171,8 @@ func (k Keeper) GetUpdatedBatchPricesAfterBuy(ctx sdk.Context, bondDid did.Did,
 		return nil, nil, err
 	}
 
-	err = k.CheckIfBuyOrderFulfillableAtPrice(ctx, bondDid, bo, buyPrices)
+	err = k.CheckIfBuyOrderFulfillableAtPrice(ctx, bondDid, bo, buyPrices,
+		bond.GetBuyTxFeePercentage(batch))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -198,7 +199,7 @@ func (k Keeper) GetUpdatedBatchPricesAfterSell(ctx sdk.Context, bondDid did.Did,
 	return buyPrices, sellPrices, nil
 }
 
-func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, bondDid did.Did, bo types.BuyOrder, prices sdk.DecCoins) (err sdk.Error) {
+func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, bondDid did.Did, bo types.BuyOrder, prices sdk.DecCoins, txFeePercentage sdk.Dec) (err sdk.Error) {
 	bond := k.MustGetBond(ctx, bondDid)
 	var extraEventAttributes []sdk.Attribute
 
@@ -208,6 +209,7 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, bondDid did.Did, bo types.Buy
 		return err
 	}
 	buyerAddr := buyerDidDoc.Address()
+	recipientAddr := bo.GetRecipient(buyerAddr)
 
 	// Mint bond tokens
 	err = k.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount,
@@ -216,16 +218,16 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, bondDid did.Did, bo types.Buy
 		return err
 	}
 
-	// Send bond tokens bought to buyer
+	// Send bond tokens bought to recipient (buyer unless otherwise specified)
 	err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-		types.BondsMintBurnAccount, buyerAddr, sdk.Coins{bo.Amount})
+		types.BondsMintBurnAccount, recipientAddr, sdk.Coins{bo.Amount})
 	if err != nil {
 		return err
 	}
 
 	reservePrices := types.MultiplyDecCoinsByInt(prices, bo.Amount.Amount)
 	reservePricesRounded := types.RoundReservePrices(reservePrices)
-	txFees := bond.GetTxFees(reservePrices)
+	txFees := bond.GetFees(reservePrices, txFeePercentage)
 	totalPrices := reservePricesRounded.Add(txFees)
 
 	if totalPrices.IsAnyGT(bo.MaxPrices) {
@@ -239,16 +241,9 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, bondDid did.Did, bo types.Buy
 		args := bond.FunctionParameters.AsMap()
 		theta := args["theta"]
 
-		// Get current reserve
-		var currentReserve sdk.Int
-		if bond.CurrentReserve.Empty() {
-			currentReserve = sdk.ZeroInt()
-		} else {
-			// Reserve balances should all be equal given that we are always
-			// applying the same additions/subtractions to all reserve balances.
-			// Thus we can pick the first reserve balance as the global balance.
-			currentReserve = k.GetReserveBalances(ctx, bondDid)[0].Amount
-		}
+		// Get current reserve (without reserve weights)
+		currentReserve := bond.GetCommonReserveBalance(
+			k.GetReserveBalances(ctx, bondDid)).TruncateInt()
 
 		// Calculate expected new reserve (as fraction 1-theta of new total raise)
 		newSupply := bond.CurrentSupply.Add(bo.Amount).Amount
@@ -258,12 +253,12 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, bondDid did.Did, bo types.Buy
 
 		// Calculate amount that should go into initial reserve
 		toInitialReserve := newReserve.Sub(currentReserve)
-		if reservePricesRounded[0].Amount.LT(toInitialReserve) {
+		coinsToInitialReserve, _ := bond.GetNewReserveDecCoins(
+			toInitialReserve.ToDec()).TruncateDecimal()
+		if !reservePricesRounded.IsAllGTE(coinsToInitialReserve) {
 			// Reserve supplied by buyer is insufficient
 			return types.ErrInsufficientReserveToBuy(types.DefaultCodespace)
 		}
-		coinsToInitialReserve, _ := bond.GetNewReserveDecCoins(
-			toInitialReserve.ToDec()).TruncateDecimal()
 
 		// Calculate amount that should go into funding pool
 		coinsToFundingPool := reservePricesRounded.Sub(coinsToInitialReserve)
@@ -319,17 +314,19 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, bondDid did.Did, bo types.Buy
 	logger := k.Logger(ctx)
 	logger.Info(fmt.Sprintf("performed buy order for %s from %s", bo.Amount.String(), bo.AccountDid))
 
-	// Get new bond token balance
-	bondTokenBalance := k.BankKeeper.GetCoins(ctx, buyerAddr).AmountOf(bond.Token)
+	// Get recipient's new bond token balance
+	bondTokenBalance := k.BankKeeper.GetCoins(ctx, recipientAddr).AmountOf(bond.Token)
 
 	event := sdk.NewEvent(
 		types.EventTypeOrderFulfill,
 		sdk.NewAttribute(types.AttributeKeyBondDid, bond.BondDid),
 		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
 		sdk.NewAttribute(types.AttributeKeyAddress, bo.AccountDid),
+		sdk.NewAttribute(types.AttributeKeyRecipient, recipientAddr.String()),
 		sdk.NewAttribute(types.AttributeKeyTokensMinted, bo.Amount.Amount.String()),
 		sdk.NewAttribute(types.AttributeKeyChargedPrices, reservePricesRounded.String()),
 		sdk.NewAttribute(types.AttributeKeyChargedFees, txFees.String()),
+		sdk.NewAttribute(types.AttributeKeyTxFeePercentage, txFeePercentage.String()),
 		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, returnToBuyer.String()),
 		sdk.NewAttribute(types.AttributeKeyNewBondTokenBalance, bondTokenBalance.String()),
 	)
@@ -341,7 +338,7 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, bondDid did.Did, bo types.Buy
 	return nil
 }
 
-func (k Keeper) PerformSellAtPrice(ctx sdk.Context, bondDid did.Did, so types.SellOrder, prices sdk.DecCoins) (err sdk.Error) {
+func (k Keeper) PerformSellAtPrice(ctx sdk.Context, bondDid did.Did, so types.SellOrder, prices sdk.DecCoins, txFeePercentage sdk.Dec) (err sdk.Error) {
 	bond := k.MustGetBond(ctx, bondDid)
 
 	// Get seller address
@@ -350,18 +347,24 @@ func (k Keeper) PerformSellAtPrice(ctx sdk.Context, bondDid did.Did, so types.Se
 		return err
 	}
 	sellerAddr := sellerDidDoc.Address()
+	recipientAddr := so.GetRecipient(sellerAddr)
 
 	reserveReturns := types.MultiplyDecCoinsByInt(prices, so.Amount.Amount)
 	reserveReturnsRounded := types.RoundReserveReturns(reserveReturns)
-	txFees := bond.GetTxFees(reserveReturns)
+	txFees := bond.GetFees(reserveReturns, txFeePercentage)
 	exitFees := bond.GetExitFees(reserveReturns)
 
 	totalFees := types.AdjustFees(txFees.Add(exitFees), reserveReturnsRounded) // calculate actual total fees
 	totalReturns := reserveReturnsRounded.Sub(totalFees)                       // calculate actual reserveReturns
 
-	// Send total returns to seller (totalReturns should never be zero)
+	// Check that total returns meet the min returns (if specified)
+	if !so.MinReturns.Empty() && !totalReturns.IsAllGTE(so.MinReturns) {
+		return types.ErrMinReturnsNotMet(types.DefaultCodespace, totalReturns, so.MinReturns)
+	}
+
+	// Send total returns to recipient (seller unless otherwise specified)
 	// TODO: investigate possibility of zero totalReturns
-	err = k.WithdrawReserve(ctx, bond.BondDid, sellerAddr, totalReturns)
+	err = k.WithdrawReserve(ctx, bond.BondDid, recipientAddr, totalReturns)
 	if err != nil {
 		return err
 	}
@@ -388,8 +391,10 @@ func (k Keeper) PerformSellAtPrice(ctx sdk.Context, bondDid did.Did, so types.Se
 		sdk.NewAttribute(types.AttributeKeyBondDid, bond.BondDid),
 		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSellOrder),
 		sdk.NewAttribute(types.AttributeKeyAddress, so.AccountDid),
+		sdk.NewAttribute(types.AttributeKeyRecipient, recipientAddr.String()),
 		sdk.NewAttribute(types.AttributeKeyTokensBurned, so.Amount.Amount.String()),
 		sdk.NewAttribute(types.AttributeKeyChargedFees, txFees.String()),
+		sdk.NewAttribute(types.AttributeKeyTxFeePercentage, txFeePercentage.String()),
 		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, totalReturns.String()),
 		sdk.NewAttribute(types.AttributeKeyNewBondTokenBalance, bondTokenBalance.String()),
 	))
@@ -408,6 +413,7 @@ func (k Keeper) PerformSwap(ctx sdk.Context, bondDid did.Did, so types.SwapOrder
 		return err, true
 	}
 	swapperAddr := swapperDidDoc.Address()
+	recipientAddr := so.GetRecipient(swapperAddr)
 
 	// Get return for swap
 	reserveBalances := k.GetReserveBalances(ctx, bondDid)
@@ -417,14 +423,20 @@ func (k Keeper) PerformSwap(ctx sdk.Context, bondDid did.Did, so types.SwapOrder
 	}
 	adjustedInput := so.Amount.Sub(txFee) // same as during GetReturnsForSwap
 
+	// Check that returns meet the min returns (if specified)
+	if !so.MinReturns.Empty() && !reserveReturns.IsAllGTE(so.MinReturns) {
+		return types.ErrMinReturnsNotMet(types.DefaultCodespace, reserveReturns, so.MinReturns), true
+	}
+
 	// Check if new rates violate sanity rate
 	newReserveBalances := reserveBalances.Add(sdk.Coins{adjustedInput}).Sub(reserveReturns)
 	if bond.ReservesViolateSanityRate(newReserveBalances) {
 		return types.ErrValuesViolateSanityRate(types.DefaultCodespace), true
 	}
 
-	// Give resultant tokens to swapper (reserveReturns should never be zero)
-	err = k.WithdrawReserve(ctx, bond.BondDid, swapperAddr, reserveReturns)
+	// Give resultant tokens to recipient (swapper unless otherwise specified)
+	// (reserveReturns should never be zero)
+	err = k.WithdrawReserve(ctx, bond.BondDid, recipientAddr, reserveReturns)
 	if err != nil {
 		return err, false
 	}
@@ -454,6 +466,7 @@ func (k Keeper) PerformSwap(ctx sdk.Context, bondDid did.Did, so types.SwapOrder
 		sdk.NewAttribute(types.AttributeKeyBondDid, bond.BondDid),
 		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSwapOrder),
 		sdk.NewAttribute(types.AttributeKeyAddress, so.AccountDid),
+		sdk.NewAttribute(types.AttributeKeyRecipient, recipientAddr.String()),
 		sdk.NewAttribute(types.AttributeKeyTokensSwapped, adjustedInput.String()),
 		sdk.NewAttribute(types.AttributeKeyChargedFees, txFee.String()),
 		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, reserveReturns.String()),
@@ -463,12 +476,14 @@ func (k Keeper) PerformSwap(ctx sdk.Context, bondDid did.Did, so types.SwapOrder
 }
 
 func (k Keeper) PerformBuyOrders(ctx sdk.Context, bondDid did.Did) {
+	bond := k.MustGetBond(ctx, bondDid)
 	batch := k.MustGetBatch(ctx, bondDid)
+	txFeePercentage := bond.GetBuyTxFeePercentage(batch)
 
 	// Perform buys or return to buyer
 	for _, bo := range batch.Buys {
 		if !bo.IsCancelled() {
-			err := k.PerformBuyAtPrice(ctx, bondDid, bo, batch.BuyPrices)
+			err := k.PerformBuyAtPrice(ctx, bondDid, bo, batch.BuyPrices, txFeePercentage)
 			if err != nil {
 				// Panic here since all calculations should have been done
 				// correctly to prevent any errors during the buy
@@ -482,12 +497,14 @@ func (k Keeper) PerformBuyOrders(ctx sdk.Context, bondDid did.Did) {
 }
 
 func (k Keeper) PerformSellOrders(ctx sdk.Context, bondDid did.Did) {
+	bond := k.MustGetBond(ctx, bondDid)
 	batch := k.MustGetBatch(ctx, bondDid)
+	txFeePercentage := bond.GetSellTxFeePercentage(batch)
 
 	// Perform sells or return to seller
 	for _, so := range batch.Sells {
 		if !so.IsCancelled() {
-			err := k.PerformSellAtPrice(ctx, bondDid, so, batch.SellPrices)
+			err := k.PerformSellAtPrice(ctx, bondDid, so, batch.SellPrices, txFeePercentage)
 			if err != nil {
 				// Panic here since all calculations should have been done
 				// correctly to prevent any errors during the sell
@@ -543,12 +560,12 @@ func (k Keeper) PerformOrders(ctx sdk.Context, bondDid did.Did) {
 	k.PerformSwapOrders(ctx, bondDid)
 }
 
-func (k Keeper) CheckIfBuyOrderFulfillableAtPrice(ctx sdk.Context, bondDid did.Did, bo types.BuyOrder, prices sdk.DecCoins) sdk.Error {
+func (k Keeper) CheckIfBuyOrderFulfillableAtPrice(ctx sdk.Context, bondDid did.Did, bo types.BuyOrder, prices sdk.DecCoins, txFeePercentage sdk.Dec) sdk.Error {
 	bond := k.MustGetBond(ctx, bondDid)
 
 	reservePrices := types.MultiplyDecCoinsByInt(prices, bo.Amount.Amount)
 	reserveRounded := types.RoundReservePrices(reservePrices)
-	txFees := bond.GetTxFees(reservePrices)
+	txFees := bond.GetFees(reservePrices, txFeePercentage)
 	totalPrices := reserveRounded.Add(txFees)
 
 	// Check that max prices not exceeded
@@ -561,12 +578,14 @@ func (k Keeper) CheckIfBuyOrderFulfillableAtPrice(ctx sdk.Context, bondDid did.D
 
 func (k Keeper) CancelUnfulfillableBuys(ctx sdk.Context, bondDid did.Did) (cancelledOrders int) {
 	logger := k.Logger(ctx)
+	bond := k.MustGetBond(ctx, bondDid)
 	batch := k.MustGetBatch(ctx, bondDid)
 
 	// Cancel unfulfillable buys
 	for i, bo := range batch.Buys {
 		if !bo.IsCancelled() {
-			err := k.CheckIfBuyOrderFulfillableAtPrice(ctx, bondDid, bo, batch.BuyPrices)
+			err := k.CheckIfBuyOrderFulfillableAtPrice(ctx, bondDid, bo, batch.BuyPrices,
+				bond.GetBuyTxFeePercentage(batch))
 			if err != nil {
 				// Cancel (important to use batch.Buys[i] and not bo!)
 				batch.Buys[i].Cancelled = true
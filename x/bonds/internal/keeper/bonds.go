@@ -19,6 +19,7 @@ func (k Keeper) GetBond(ctx sdk.Context, bondDid did.Did) (bond types.Bond, foun
 	}
 	bz := store.Get(types.GetBondKey(bondDid))
 	k.cdc.MustUnmarshalBinaryBare(bz, &bond)
+	bond.ApplyDefaults()
 	return bond, true
 }
 
@@ -49,6 +50,7 @@ func (k Keeper) MustGetBondByKey(ctx sdk.Context, key []byte) types.Bond {
 	bz := store.Get(key)
 	var bond types.Bond
 	k.cdc.MustUnmarshalBinaryBare(bz, &bond)
+	bond.ApplyDefaults()
 
 	return bond
 }
@@ -66,6 +68,19 @@ func (k Keeper) BondDidExists(ctx sdk.Context, bondToken string) bool {
 func (k Keeper) SetBond(ctx sdk.Context, bondDid did.Did, bond types.Bond) {
 	store := ctx.KVStore(k.storeKey)
 	store.Set(types.GetBondKey(bondDid), k.cdc.MustMarshalBinaryBare(bond))
+	store.Set(types.GetCreatorBondDidKey(bond.CreatorDid, bondDid), []byte(bondDid))
+}
+
+// GetCreatorBondDids returns the DIDs of the bonds created by the creator DID
+func (k Keeper) GetCreatorBondDids(ctx sdk.Context, creatorDid did.Did) (bondDids []did.Did) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetCreatorBondDidsPrefixKey(creatorDid))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		bondDids = append(bondDids, string(iterator.Value()))
+	}
+	return bondDids
 }
 
 func (k Keeper) SetBondDid(ctx sdk.Context, bondToken string, bondDid did.Did) {
@@ -171,6 +186,34 @@ func (k Keeper) SetBondState(ctx sdk.Context, bondDid did.Did, newState string)
 	))
 }
 
+func (k Keeper) SweepUnclaimedReserve(ctx sdk.Context, bondDid did.Did) sdk.Error {
+	bond := k.MustGetBond(ctx, bondDid)
+
+	// Send any remaining reserve to the unclaimed reserve address
+	remainingReserve := k.GetReserveBalances(ctx, bondDid)
+	if !remainingReserve.IsZero() {
+		err := k.WithdrawReserve(ctx, bondDid, bond.UnclaimedReserveAddress, remainingReserve)
+		if err != nil {
+			return err
+		}
+	}
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("swept unclaimed reserve %s of %s to %s",
+		remainingReserve, bond.Token, bond.UnclaimedReserveAddress))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSweepReserve,
+		sdk.NewAttribute(types.AttributeKeyBondDid, bond.BondDid),
+		sdk.NewAttribute(types.AttributeKeyAddress, bond.UnclaimedReserveAddress.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, remainingReserve.String()),
+	))
+
+	// Set bond state to CLOSED
+	k.SetBondState(ctx, bondDid, types.ClosedState)
+	return nil
+}
+
 func (k Keeper) ReservedBondToken(ctx sdk.Context, bondToken string) bool {
 	reservedBondTokens := k.GetParams(ctx).ReservedBondTokens
 	for _, rbt := range reservedBondTokens {
@@ -0,0 +1,175 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+)
+
+// GetOrderCommits returns the order commits of the bond, in the order in which
+// they were committed
+func (k Keeper) GetOrderCommits(ctx sdk.Context, bondDid did.Did) (commits []types.OrderCommit) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetOrderCommitQueuePrefixKey(bondDid))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var oc types.OrderCommit
+		k.cdc.MustUnmarshalBinaryBare(store.Get(iterator.Value()), &oc)
+		commits = append(commits, oc)
+	}
+	return commits
+}
+
+func (k Keeper) GetOrderCommit(ctx sdk.Context, bondDid, swapperDid did.Did, commitHash string) (types.OrderCommit, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetOrderCommitKey(bondDid, swapperDid, commitHash))
+	if bz == nil {
+		return types.OrderCommit{}, false
+	}
+
+	var oc types.OrderCommit
+	k.cdc.MustUnmarshalBinaryBare(bz, &oc)
+	return oc, true
+}
+
+func (k Keeper) OrderCommitExists(ctx sdk.Context, bondDid, swapperDid did.Did, commitHash string) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetOrderCommitKey(bondDid, swapperDid, commitHash))
+}
+
+// AddOrderCommit stores the order commit and adds it to the end of the bond's
+// order commit queue
+func (k Keeper) AddOrderCommit(ctx sdk.Context, bondDid did.Did, oc types.OrderCommit) {
+	store := ctx.KVStore(k.storeKey)
+	commitKey := types.GetOrderCommitKey(bondDid, oc.AccountDid, oc.CommitHash)
+	sequence := k.getLastOrderCommitSequence(ctx, bondDid) + 1
+
+	store.Set(commitKey, k.cdc.MustMarshalBinaryBare(oc))
+	store.Set(types.GetOrderCommitQueueKey(bondDid, sequence), commitKey)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added order commit %s with escrow %s from %s", oc.CommitHash, oc.Escrow.String(), oc.AccountDid))
+}
+
+func (k Keeper) SetOrderCommit(ctx sdk.Context, bondDid did.Did, oc types.OrderCommit) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetOrderCommitKey(bondDid, oc.AccountDid, oc.CommitHash),
+		k.cdc.MustMarshalBinaryBare(oc))
+}
+
+func (k Keeper) getLastOrderCommitSequence(ctx sdk.Context, bondDid did.Did) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, types.GetOrderCommitQueuePrefixKey(bondDid))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return 0
+	}
+
+	key := iterator.Key()
+	return binary.BigEndian.Uint64(key[len(key)-8:])
+}
+
+// ProcessOrderCommits goes through the order commits of the bond for which the
+// reveal window has closed. Revealed orders are added to the current batch in
+// the order in which they were committed. Unrevealed commits are refunded to
+// the committer, minus the unrevealed penalty which is sent to the fee address.
+// Since the queue is ordered by commit height, this stops at the first order
+// commit whose reveal window has not closed yet. An unrevealed commit that
+// cannot be refunded is dropped without affecting the other commits.
+func (k Keeper) ProcessOrderCommits(ctx sdk.Context, bondDid did.Did) {
+	bond := k.MustGetBond(ctx, bondDid)
+	logger := k.Logger(ctx)
+	store := ctx.KVStore(k.storeKey)
+
+	var closed []types.OrderCommit
+	var processedKeys [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, types.GetOrderCommitQueuePrefixKey(bondDid))
+	for ; iterator.Valid(); iterator.Next() {
+		var oc types.OrderCommit
+		k.cdc.MustUnmarshalBinaryBare(store.Get(iterator.Value()), &oc)
+		if !oc.RevealWindowClosed(ctx.BlockHeight(), bond.RevealBlocks) {
+			break
+		}
+		closed = append(closed, oc)
+		processedKeys = append(processedKeys, iterator.Key(), iterator.Value())
+	}
+	iterator.Close()
+
+	// The store is not written to while it is being iterated
+	for _, key := range processedKeys {
+		store.Delete(key)
+	}
+
+	for _, oc := range closed {
+		// Revealed orders go to the batch (escrow already holds the amount)
+		if oc.Revealed {
+			k.AddSwapOrder(ctx, bondDid, oc.Order)
+			continue
+		}
+
+		// Split escrow into penalty and refund
+		penalty := bond.GetUnrevealedPenalty(oc.Escrow)
+		refund := oc.Escrow.Sub(penalty)
+		committerAddr, err := k.refundOrderCommit(ctx, bond, oc, penalty, refund)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to refund unrevealed order commit %s from %s: %s",
+				oc.CommitHash, oc.AccountDid, err.Error()))
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeOrderCommitExpireFailed,
+				sdk.NewAttribute(types.AttributeKeyBondDid, bondDid),
+				sdk.NewAttribute(types.AttributeKeyCommitHash, oc.CommitHash),
+				sdk.NewAttribute(types.AttributeKeyEscrow, oc.Escrow.String()),
+				sdk.NewAttribute(types.AttributeKeyReason, err.Error()),
+			))
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("expired unrevealed order commit %s from %s", oc.CommitHash, oc.AccountDid))
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeOrderCommitExpire,
+			sdk.NewAttribute(types.AttributeKeyBondDid, bondDid),
+			sdk.NewAttribute(types.AttributeKeyCommitHash, oc.CommitHash),
+			sdk.NewAttribute(types.AttributeKeyAddress, committerAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyEscrow, oc.Escrow.String()),
+			sdk.NewAttribute(types.AttributeKeyPenalty, penalty.String()),
+			sdk.NewAttribute(types.AttributeKeyReturnedToAddress, refund.String()),
+		))
+	}
+}
+
+// refundOrderCommit sends the penalty to the bond's fee address and the refund
+// to the committer's address, only committing the changes if both succeed. The
+// committer's address is returned.
+func (k Keeper) refundOrderCommit(ctx sdk.Context, bond types.Bond,
+	oc types.OrderCommit, penalty, refund sdk.Coin) (sdk.AccAddress, sdk.Error) {
+	cacheCtx, write := ctx.CacheContext()
+
+	didDoc, err := k.DidKeeper.GetDidDoc(cacheCtx, oc.AccountDid)
+	if err != nil {
+		return nil, err
+	}
+	committerAddr := didDoc.Address()
+
+	if penalty.IsPositive() {
+		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(cacheCtx,
+			types.BatchesIntermediaryAccount, bond.FeeAddress, sdk.Coins{penalty})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if refund.IsPositive() {
+		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(cacheCtx,
+			types.BatchesIntermediaryAccount, committerAddr, sdk.Coins{refund})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	write()
+	return committerAddr, nil
+}
@@ -0,0 +1,29 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+)
+
+// Wrapper struct
+type Hooks struct {
+	k Keeper
+}
+
+var _ did.DidHooks = Hooks{}
+
+// Create new bonds hooks
+func (k Keeper) Hooks() Hooks { return Hooks{k} }
+
+// AfterDidDeactivated emits a warning event for each bond created by the DID,
+// since such bonds can no longer be edited by their creator
+func (h Hooks) AfterDidDeactivated(ctx sdk.Context, creatorDid did.Did) {
+	for _, bondDid := range h.k.GetCreatorBondDids(ctx, creatorDid) {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeDeactivatedDidWarning,
+			sdk.NewAttribute(types.AttributeKeyBondDid, bondDid),
+			sdk.NewAttribute(types.AttributeKeyCreatorDid, creatorDid),
+		))
+	}
+}
@@ -0,0 +1,44 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+)
+
+// Instant execution is only available for swapper function bonds, for which
+// orders are never added to a batch. The prices are thus calculated using an
+// empty batch, meaning that the order is performed at the current prices.
+// Since there is no batch imbalance, only the bond's tx fee is charged.
+
+// PerformBuyInstantly performs a buy order without adding it to the batch.
+// The max prices are expected to already be in the batches intermediary
+// account, from which the prices are paid and the remainder is returned.
+func (k Keeper) PerformBuyInstantly(ctx sdk.Context, bondDid did.Did, bo types.BuyOrder) sdk.Error {
+	buyPrices, _, err := k.GetUpdatedBatchPricesAfterBuy(ctx, bondDid, bo)
+	if err != nil {
+		return err
+	}
+	bond := k.MustGetBond(ctx, bondDid)
+	return k.PerformBuyAtPrice(ctx, bondDid, bo, buyPrices, bond.TxFeePercentage)
+}
+
+// PerformSellInstantly performs a sell order without adding it to the batch.
+// The bond tokens being sold are expected to have already been burned.
+func (k Keeper) PerformSellInstantly(ctx sdk.Context, bondDid did.Did, so types.SellOrder) sdk.Error {
+	_, sellPrices, err := k.GetUpdatedBatchPricesAfterSell(ctx, bondDid, so)
+	if err != nil {
+		return err
+	}
+	bond := k.MustGetBond(ctx, bondDid)
+	return k.PerformSellAtPrice(ctx, bondDid, so, sellPrices, bond.TxFeePercentage)
+}
+
+// PerformSwapInstantly performs a swap order without adding it to the batch.
+// The from amount is expected to already be in the batches intermediary
+// account. Any error means that the swap was not performed, in which case
+// the message fails and all of its changes are reverted.
+func (k Keeper) PerformSwapInstantly(ctx sdk.Context, bondDid did.Did, so types.SwapOrder) sdk.Error {
+	err, _ := k.PerformSwap(ctx, bondDid, so)
+	return err
+}
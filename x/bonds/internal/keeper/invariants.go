@@ -95,16 +95,17 @@ func ReserveInvariant(k Keeper) sdk.Invariant {
 			}
 
 			expectedReserve := bond.ReserveAtSupply(bond.CurrentSupply.Amount)
-			expectedRounded := expectedReserve.Ceil().TruncateInt()
 			actualReserve := k.GetReserveBalances(ctx, did)
 
 			for _, r := range actualReserve {
+				expectedWeighted := expectedReserve.Mul(bond.GetReserveWeight(r.Denom))
+				expectedRounded := expectedWeighted.Ceil().TruncateInt()
 				if r.Amount.LT(expectedRounded) {
 					count++
 					msg += fmt.Sprintf("%s reserve invariance:\n"+
 						"\texpected(ceil-rounded) %s reserve: %s\n"+
 						"\tactual %s reserve: %s\n",
-						did, denom, expectedReserve.String(),
+						did, denom, expectedWeighted.String(),
 						denom, r.String())
 				}
 			}
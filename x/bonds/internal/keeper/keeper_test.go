@@ -0,0 +1,412 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+)
+
+func TestKeeperBondMetadata(t *testing.T) {
+	metadata := types.NewBondTokenMetadata(
+		"mabc", 3, "ABC", "https://abc.com/icon.png", "https://abc.com")
+	require.Nil(t, metadata.Validate())
+	ctx, k, _ := CreateTestInputWithBond(func(bond *types.Bond) {
+		bond.Metadata = metadata
+	})
+
+	// Metadata is stored as part of the bond and found via the bond token
+	bondDid, found := k.GetBondDid(ctx, TestToken)
+	require.True(t, found)
+	require.Equal(t, metadata, k.MustGetBond(ctx, bondDid).Metadata)
+
+	// Exponent cannot be set without a display denom or exceed the precision
+	require.NotNil(t, types.NewBondTokenMetadata("", 3, "", "", "").Validate())
+	require.NotNil(t, types.NewBondTokenMetadata(
+		"mabc", types.MaxMetadataExponent+1, "", "", "").Validate())
+}
+
+func TestKeeperGetCreatorBondDids(t *testing.T) {
+	ctx, k, _ := CreateTestInput()
+
+	// The creator DID of the second bond has that of the first bond as a prefix
+	bond1 := NewTestBond("did:ixo:4XJLBfGtWSGKSz4BeRxdun")
+	bond2 := NewTestBond(bond1.CreatorDid + "/path")
+	bond2.BondDid = TestBondDid + "/path"
+	k.SetBond(ctx, bond1.BondDid, bond1)
+	k.SetBond(ctx, bond2.BondDid, bond2)
+
+	require.Equal(t, []did.Did{bond1.BondDid}, k.GetCreatorBondDids(ctx, bond1.CreatorDid))
+	require.Equal(t, []did.Did{bond2.BondDid}, k.GetCreatorBondDids(ctx, bond2.CreatorDid))
+}
+
+// sendTestMaxPrices sends the max prices of an order from the address to the
+// batches intermediary account, as done when the order is submitted.
+func sendTestMaxPrices(t *testing.T, ctx sdk.Context, k Keeper,
+	addr sdk.AccAddress, maxPrices sdk.Coins) {
+	err := k.SupplyKeeper.SendCoinsFromAccountToModule(ctx, addr,
+		types.BatchesIntermediaryAccount, maxPrices)
+	require.Nil(t, err)
+}
+
+func TestKeeperPerformBuyAtPriceToRecipient(t *testing.T) {
+	ctx, k, bond := CreateTestInputWithBond(nil)
+	buyerDid, buyerAddr := AddTestDid(ctx, k, TestAccountSeed, sdk.NewCoins(
+		sdk.NewInt64Coin(TestReserveToken, 1000)))
+	_, recipientAddr := AddTestDid(ctx, k, TestRecipientSeed, nil)
+
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 200))
+	sendTestMaxPrices(t, ctx, k, buyerAddr, maxPrices)
+
+	// Buy 10abc at 10res each (100res) plus 0.5% tx fee (0.5res, rounded up)
+	amount := sdk.NewInt64Coin(TestToken, 10)
+	prices := sdk.NewDecCoins(sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 10)))
+	bo := types.NewBuyOrder(buyerDid, amount, maxPrices, recipientAddr)
+	require.Nil(t, k.PerformBuyAtPrice(ctx, bond.BondDid, bo, prices, bond.TxFeePercentage))
+
+	// Bond tokens go to the recipient, and the change goes back to the buyer
+	require.Equal(t, sdk.NewCoins(amount), k.BankKeeper.GetCoins(ctx, recipientAddr))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 899)),
+		k.BankKeeper.GetCoins(ctx, buyerAddr))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 1)),
+		k.BankKeeper.GetCoins(ctx, TestFeeAddress))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 100)),
+		k.GetReserveBalances(ctx, bond.BondDid))
+	require.Equal(t, amount, k.MustGetBond(ctx, bond.BondDid).CurrentSupply)
+}
+
+func TestKeeperSweepUnclaimedReserve(t *testing.T) {
+	ctx, k, bond := CreateTestInputWithBond(func(bond *types.Bond) {
+		bond.State = types.SettleState
+	})
+	reserve := sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 300))
+	_, accountAddr := AddTestDid(ctx, k, TestAccountSeed, reserve)
+	require.Nil(t, k.DepositReserve(ctx, bond.BondDid, accountAddr, reserve))
+
+	// Remaining reserve goes to the unclaimed reserve address and bond closes
+	require.Nil(t, k.SweepUnclaimedReserve(ctx, bond.BondDid))
+	require.True(t, k.GetReserveBalances(ctx, bond.BondDid).IsZero())
+	require.Equal(t, reserve, k.BankKeeper.GetCoins(ctx, TestUnclaimedReserveAddress))
+	require.Equal(t, types.ClosedState, k.MustGetBond(ctx, bond.BondDid).State)
+
+	// Sweeping an empty reserve only closes the bond
+	bond.Token = "xyz"
+	bond.BondDid = "did:ixo:Gs8bR5ad5jF7JeNBMYW5cv"
+	bond.CurrentSupply = sdk.NewInt64Coin(bond.Token, 0)
+	SetTestBond(ctx, k, bond)
+	require.Nil(t, k.SweepUnclaimedReserve(ctx, bond.BondDid))
+	require.Equal(t, reserve, k.BankKeeper.GetCoins(ctx, TestUnclaimedReserveAddress))
+	require.Equal(t, types.ClosedState, k.MustGetBond(ctx, bond.BondDid).State)
+}
+
+func TestKeeperSettlementClaimDeadlineReached(t *testing.T) {
+	bond := NewTestBond(TestBondDid)
+	bond.SettlementDeadline = 10
+
+	// Only reached in the SETTLE state, from the deadline onwards
+	require.False(t, bond.SettlementClaimDeadlineReached(10))
+	bond.State = types.SettleState
+	require.False(t, bond.SettlementClaimDeadlineReached(9))
+	require.True(t, bond.SettlementClaimDeadlineReached(10))
+	require.True(t, bond.SettlementClaimDeadlineReached(11))
+
+	// Never reached if there is no deadline
+	bond.SettlementDeadline = 0
+	require.False(t, bond.SettlementClaimDeadlineReached(10))
+}
+
+func TestKeeperGetBondStoredByOlderVersion(t *testing.T) {
+	ctx, k, _ := CreateTestInput()
+	bond := NewTestBond(TestBondDid)
+
+	// Bond as stored before the settlement claim, commit-reveal, reserve
+	// weight and dynamic fee fields were introduced
+	type legacyBond struct {
+		Token                  string
+		Name                   string
+		Description            string
+		CreatorDid             did.Did
+		FunctionType           string
+		FunctionParameters     types.FunctionParams
+		ReserveTokens          []string
+		TxFeePercentage        sdk.Dec
+		ExitFeePercentage      sdk.Dec
+		FeeAddress             sdk.AccAddress
+		MaxSupply              sdk.Coin
+		OrderQuantityLimits    sdk.Coins
+		SanityRate             sdk.Dec
+		SanityMarginPercentage sdk.Dec
+		CurrentSupply          sdk.Coin
+		CurrentReserve         sdk.Coins
+		AllowSells             bool
+		BatchBlocks            sdk.Uint
+		OutcomePayment         sdk.Coins
+		State                  string
+		BondDid                did.Did
+	}
+	legacyCdc := codec.New()
+	legacyCdc.RegisterConcrete(legacyBond{}, "bonds/Bond", nil)
+	bz := legacyCdc.MustMarshalBinaryBare(legacyBond{
+		bond.Token, bond.Name, bond.Description, bond.CreatorDid,
+		bond.FunctionType, bond.FunctionParameters, bond.ReserveTokens,
+		bond.TxFeePercentage, bond.ExitFeePercentage, bond.FeeAddress,
+		bond.MaxSupply, bond.OrderQuantityLimits, bond.SanityRate,
+		bond.SanityMarginPercentage, bond.CurrentSupply, bond.CurrentReserve,
+		bond.AllowSells, bond.BatchBlocks, bond.OutcomePayment, bond.State,
+		bond.BondDid})
+	ctx.KVStore(k.storeKey).Set(types.GetBondKey(bond.BondDid), bz)
+
+	// Unset fields default to zero, disabling the corresponding features
+	for _, stored := range []types.Bond{k.MustGetBond(ctx, bond.BondDid),
+		k.MustGetBondByKey(ctx, types.GetBondKey(bond.BondDid))} {
+		require.Equal(t, bond.Token, stored.Token)
+		require.Equal(t, sdk.ZeroUint(), stored.SettlementClaimBlocks)
+		require.Equal(t, sdk.ZeroUint(), stored.RevealBlocks)
+		require.Equal(t, sdk.ZeroDec(), stored.UnrevealedPenaltyPercentage)
+		require.Equal(t, sdk.ZeroDec(), stored.ImbalanceFeePercentage)
+		require.Equal(t, sdk.ZeroUint(), stored.ImbalanceFeeExponent)
+		require.False(t, stored.CommitRevealEnabled())
+		require.False(t, stored.DynamicFeesEnabled())
+	}
+}
+
+func TestKeeperOrderCommits(t *testing.T) {
+	ctx, k, bond := CreateTestInputWithBond(func(bond *types.Bond) {
+		bond.RevealBlocks = sdk.NewUint(2)
+		bond.UnrevealedPenaltyPercentage = sdk.NewDec(10)
+	})
+	escrow := sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 15))
+	swapper1Did, swapper1Addr := AddTestDid(ctx, k, TestAccountSeed, escrow)
+	swapper2Did, swapper2Addr := AddTestDid(ctx, k, TestRecipientSeed, escrow)
+
+	// Both swappers commit the same hash (at heights 1 and 3 respectively)
+	hash := "f4b3a8b3cc2b05cd5fd8fa1d0a2d6bd0f1d4ee5e0e1bb0e2cc1e6d3ba42a9b3e"
+	sendTestMaxPrices(t, ctx, k, swapper1Addr, escrow)
+	k.AddOrderCommit(ctx, bond.BondDid, types.NewOrderCommit(
+		bond.BondDid, swapper1Did, hash, escrow[0], 1))
+	sendTestMaxPrices(t, ctx, k, swapper2Addr, escrow)
+	k.AddOrderCommit(ctx, bond.BondDid, types.NewOrderCommit(
+		bond.BondDid, swapper2Did, hash, escrow[0], 3))
+
+	// Commits are stored per swapper, so neither commit overwrites the other
+	require.True(t, k.OrderCommitExists(ctx, bond.BondDid, swapper1Did, hash))
+	require.True(t, k.OrderCommitExists(ctx, bond.BondDid, swapper2Did, hash))
+	require.Len(t, k.GetOrderCommits(ctx, bond.BondDid), 2)
+
+	// Only the first commit's reveal window has closed at height 4
+	k.ProcessOrderCommits(ctx.WithBlockHeight(4), bond.BondDid)
+	require.False(t, k.OrderCommitExists(ctx, bond.BondDid, swapper1Did, hash))
+	require.True(t, k.OrderCommitExists(ctx, bond.BondDid, swapper2Did, hash))
+
+	// Unrevealed penalty of 10% of 15res (1.5res) is truncated to 1res
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 14)),
+		k.BankKeeper.GetCoins(ctx, swapper1Addr))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 1)),
+		k.BankKeeper.GetCoins(ctx, TestFeeAddress))
+
+	// Second commit's reveal window has closed at height 6
+	k.ProcessOrderCommits(ctx.WithBlockHeight(6), bond.BondDid)
+	require.Len(t, k.GetOrderCommits(ctx, bond.BondDid), 0)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 14)),
+		k.BankKeeper.GetCoins(ctx, swapper2Addr))
+}
+
+func TestKeeperOrderCommitsOfBondDidWithPath(t *testing.T) {
+	ctx, k, _ := CreateTestInput()
+	escrow := sdk.NewInt64Coin(TestReserveToken, 15)
+	hash := "f4b3a8b3cc2b05cd5fd8fa1d0a2d6bd0f1d4ee5e0e1bb0e2cc1e6d3ba42a9b3e"
+
+	// The DID of the second bond has the DID of the first bond as a prefix
+	bondDid1 := TestBondDid
+	bondDid2 := TestBondDid + "/bond2"
+	k.AddOrderCommit(ctx, bondDid1, types.NewOrderCommit(
+		bondDid1, "did:ixo:4XJLBfGtWSGKSz4BeRxdun/a", hash, escrow, 1))
+	k.AddOrderCommit(ctx, bondDid2, types.NewOrderCommit(
+		bondDid2, "did:ixo:4XJLBfGtWSGKSz4BeRxdun", hash, escrow, 1))
+
+	// Each bond only has its own order commit
+	require.Len(t, k.GetOrderCommits(ctx, bondDid1), 1)
+	require.Len(t, k.GetOrderCommits(ctx, bondDid2), 1)
+	require.False(t, k.OrderCommitExists(ctx, bondDid1, "did:ixo:4XJLBfGtWSGKSz4BeRxdun", hash))
+	require.False(t, k.OrderCommitExists(ctx, bondDid2, "did:ixo:4XJLBfGtWSGKSz4BeRxdun/a", hash))
+}
+
+func TestKeeperProcessOrderCommitsRefundFails(t *testing.T) {
+	ctx, k, bond := CreateTestInputWithBond(func(bond *types.Bond) {
+		bond.RevealBlocks = sdk.NewUint(2)
+		bond.UnrevealedPenaltyPercentage = sdk.NewDec(10)
+	})
+	escrow := sdk.NewInt64Coin(TestReserveToken, 15)
+	penalty := sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 1))
+	swapperDid, swapperAddr := AddTestDid(ctx, k, TestAccountSeed, penalty)
+	hash := "f4b3a8b3cc2b05cd5fd8fa1d0a2d6bd0f1d4ee5e0e1bb0e2cc1e6d3ba42a9b3e"
+
+	// Escrow only holds the penalty, so the refund cannot be sent, and the
+	// committer of the second order commit does not have a DID doc
+	sendTestMaxPrices(t, ctx, k, swapperAddr, penalty)
+	k.AddOrderCommit(ctx, bond.BondDid, types.NewOrderCommit(
+		bond.BondDid, swapperDid, hash, escrow, 1))
+	k.AddOrderCommit(ctx, bond.BondDid, types.NewOrderCommit(
+		bond.BondDid, "did:ixo:4XJLBfGtWSGKSz4BeRxdun", hash, escrow, 1))
+
+	// Failing order commits are dropped without sending any coins
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.NotPanics(t, func() {
+		k.ProcessOrderCommits(ctx.WithBlockHeight(4), bond.BondDid)
+	})
+	require.Len(t, k.GetOrderCommits(ctx, bond.BondDid), 0)
+	require.True(t, k.BankKeeper.GetCoins(ctx, TestFeeAddress).IsZero())
+
+	var failed int
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == types.EventTypeOrderCommitExpireFailed {
+			failed++
+		}
+	}
+	require.Equal(t, 2, failed)
+}
+
+func TestKeeperReserveWeights(t *testing.T) {
+	// A single reserve without weights has a weight of one
+	bond := NewTestBond(TestBondDid)
+	require.Equal(t, sdk.OneDec(), bond.GetReserveWeight(TestReserveToken))
+
+	// Minting 10 at zero supply costs 4*10^3 + 100*10 = 5000
+	prices, err := bond.GetPricesToMint(sdk.NewInt(10), nil)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDecCoins(sdk.NewCoins(
+		sdk.NewInt64Coin(TestReserveToken, 5000))), prices)
+
+	// Weighted reserves are each charged the price times their weight
+	ctx, k, bond := CreateTestInputWithBond(func(bond *types.Bond) {
+		bond.ReserveTokens = []string{TestReserveToken, "rez"}
+		bond.ReserveWeights = sdk.DecCoins{
+			sdk.NewDecCoinFromDec(TestReserveToken, sdk.OneDec()),
+			sdk.NewDecCoinFromDec("rez", sdk.MustNewDecFromStr("0.5")),
+		}
+	})
+	bond = k.MustGetBond(ctx, bond.BondDid)
+	require.Equal(t, sdk.OneDec(), bond.GetReserveWeight(TestReserveToken))
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), bond.GetReserveWeight("rez"))
+	require.Equal(t, sdk.ZeroDec(), bond.GetReserveWeight("xyz"))
+
+	prices, err = bond.GetPricesToMint(sdk.NewInt(10), nil)
+	require.Nil(t, err)
+	expected := sdk.NewDecCoins(sdk.NewCoins(
+		sdk.NewInt64Coin(TestReserveToken, 5000), sdk.NewInt64Coin("rez", 2500)))
+	require.Equal(t, expected, prices)
+
+	// Common reserve balance removes the weights and takes the smallest
+	reserves := sdk.NewCoins(
+		sdk.NewInt64Coin(TestReserveToken, 5001), sdk.NewInt64Coin("rez", 2500))
+	require.Equal(t, sdk.NewDec(5000), bond.GetCommonReserveBalance(reserves))
+
+	// Burning the full supply returns the weighted reserve
+	bond.CurrentSupply = sdk.NewInt64Coin(TestToken, 10)
+	require.Equal(t, expected, bond.GetReturnsForBurn(sdk.NewInt(10), reserves))
+}
+
+func TestKeeperImbalanceFeePercentage(t *testing.T) {
+	bond := NewTestBond(TestBondDid)
+	batch := types.NewBatch(bond.BondDid, bond.Token, bond.BatchBlocks)
+
+	// Dynamic fees disabled, so only the tx fee is charged
+	require.Equal(t, sdk.ZeroDec(), bond.GetImbalanceFeePercentage(batch))
+	bond.ImbalanceFeePercentage = sdk.NewDec(2)
+
+	// An empty batch (e.g. at zero supply) is balanced, so no imbalance fee
+	require.Equal(t, sdk.ZeroDec(), bond.GetImbalanceFeePercentage(batch))
+	require.Equal(t, bond.TxFeePercentage, bond.GetBuyTxFeePercentage(batch))
+	require.Equal(t, bond.TxFeePercentage, bond.GetSellTxFeePercentage(batch))
+
+	// Only buys, so the full imbalance fee is charged on buys
+	batch.TotalBuyAmount = sdk.NewInt64Coin(bond.Token, 10)
+	require.Equal(t, sdk.NewDec(2), bond.GetImbalanceFeePercentage(batch))
+	require.Equal(t, sdk.MustNewDecFromStr("2.5"), bond.GetBuyTxFeePercentage(batch))
+	require.Equal(t, bond.TxFeePercentage, bond.GetSellTxFeePercentage(batch))
+
+	// 30 buys and 10 sells is 2%*(20/40) = 1%, charged on 20/30 of each buy
+	batch.TotalBuyAmount = sdk.NewInt64Coin(bond.Token, 30)
+	batch.TotalSellAmount = sdk.NewInt64Coin(bond.Token, 10)
+	require.Equal(t, sdk.OneDec(), bond.GetImbalanceFeePercentage(batch))
+	require.Equal(t, sdk.MustNewDecFromStr("1.166666666666666667"),
+		bond.GetBuyTxFeePercentage(batch))
+	require.Equal(t, bond.TxFeePercentage, bond.GetSellTxFeePercentage(batch))
+
+	// With an exponent of 2, the imbalance fee is 2%*(20/40)^2 = 0.5%
+	bond.ImbalanceFeeExponent = sdk.NewUint(2)
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), bond.GetImbalanceFeePercentage(batch))
+
+	// More sells than buys, so the imbalance fee is charged on sells
+	batch.TotalBuyAmount, batch.TotalSellAmount = batch.TotalSellAmount, batch.TotalBuyAmount
+	require.Equal(t, bond.TxFeePercentage, bond.GetBuyTxFeePercentage(batch))
+	require.Equal(t, sdk.MustNewDecFromStr("0.833333333333333334"),
+		bond.GetSellTxFeePercentage(batch))
+}
+
+func TestKeeperPerformBuySellAtPriceWithFeePercentage(t *testing.T) {
+	ctx, k, bond := CreateTestInputWithBond(nil)
+	accountDid, accountAddr := AddTestDid(ctx, k, TestAccountSeed, sdk.NewCoins(
+		sdk.NewInt64Coin(TestReserveToken, 1000)))
+
+	maxPrices := sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 200))
+	sendTestMaxPrices(t, ctx, k, accountAddr, maxPrices)
+
+	// Buy 10abc at 10res each with a 2.5% fee (2.5res, rounded up) rather
+	// than the bond's 0.5% tx fee
+	amount := sdk.NewInt64Coin(TestToken, 10)
+	prices := sdk.NewDecCoins(sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 10)))
+	bo := types.NewBuyOrder(accountDid, amount, maxPrices, nil)
+	require.Nil(t, k.PerformBuyAtPrice(ctx, bond.BondDid, bo, prices, sdk.MustNewDecFromStr("2.5")))
+	require.Equal(t, sdk.NewCoins(amount, sdk.NewInt64Coin(TestReserveToken, 897)),
+		k.BankKeeper.GetCoins(ctx, accountAddr))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 3)),
+		k.BankKeeper.GetCoins(ctx, TestFeeAddress))
+
+	// Sell 10abc at 10res each with a 2.5% fee (3res) and the 0.1% exit fee
+	// (1res), so 96res are returned (the bond tokens are burned beforehand)
+	so := types.NewSellOrder(accountDid, amount, nil, nil)
+	require.Nil(t, k.PerformSellAtPrice(ctx, bond.BondDid, so, prices, sdk.MustNewDecFromStr("2.5")))
+	require.Equal(t, sdk.NewCoins(amount, sdk.NewInt64Coin(TestReserveToken, 993)),
+		k.BankKeeper.GetCoins(ctx, accountAddr))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 7)),
+		k.BankKeeper.GetCoins(ctx, TestFeeAddress))
+	require.True(t, k.GetReserveBalances(ctx, bond.BondDid).IsZero())
+	require.True(t, k.MustGetBond(ctx, bond.BondDid).CurrentSupply.IsZero())
+}
+
+func TestKeeperCancelUnfulfillableBuys(t *testing.T) {
+	ctx, k, bond := CreateTestInputWithBond(func(bond *types.Bond) {
+		bond.ImbalanceFeePercentage = sdk.NewDec(2)
+	})
+	buyerDid, buyerAddr := AddTestDid(ctx, k, TestAccountSeed, sdk.NewCoins(
+		sdk.NewInt64Coin(TestReserveToken, 1000)))
+
+	// Two buys of 10abc at 10res each, the first of which only covers the
+	// 0.5% tx fee (101res) and the second of which also covers the 2%
+	// imbalance fee charged on a batch with only buys (103res)
+	amount := sdk.NewInt64Coin(TestToken, 10)
+	prices := sdk.NewDecCoins(sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 10)))
+	for _, maxPrice := range []int64{101, 103} {
+		maxPrices := sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, maxPrice))
+		sendTestMaxPrices(t, ctx, k, buyerAddr, maxPrices)
+		bo := types.NewBuyOrder(buyerDid, amount, maxPrices, nil)
+		k.AddBuyOrder(ctx, bond.BondDid, bo, prices, prices)
+	}
+
+	// Only the first buy is cancelled and its max prices are returned
+	require.Equal(t, 1, k.CancelUnfulfillableBuys(ctx, bond.BondDid))
+	batch := k.MustGetBatch(ctx, bond.BondDid)
+	require.True(t, batch.Buys[0].IsCancelled())
+	require.False(t, batch.Buys[1].IsCancelled())
+	require.Equal(t, amount, batch.TotalBuyAmount)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(TestReserveToken, 897)),
+		k.BankKeeper.GetCoins(ctx, buyerAddr))
+
+	// The remaining buy is fulfillable, so nothing else is cancelled
+	require.Equal(t, 0, k.CancelUnfulfillableBuys(ctx, bond.BondDid))
+}
@@ -12,8 +12,10 @@ import (
 const (
 	QueryBonds          = "bonds"
 	QueryBond           = "bond"
+	QueryBondMetadata   = "bond_metadata"
 	QueryBatch          = "batch"
 	QueryLastBatch      = "last_batch"
+	QueryOrderCommits   = "order_commits"
 	QueryCurrentPrice   = "current_price"
 	QueryCurrentReserve = "current_reserve"
 	QueryCustomPrice    = "custom_price"
@@ -31,10 +33,14 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryBonds(ctx, keeper)
 		case QueryBond:
 			return queryBond(ctx, path[1:], keeper)
+		case QueryBondMetadata:
+			return queryBondMetadata(ctx, path[1:], keeper)
 		case QueryBatch:
 			return queryBatch(ctx, path[1:], keeper)
 		case QueryLastBatch:
 			return queryLastBatch(ctx, path[1:], keeper)
+		case QueryOrderCommits:
+			return queryOrderCommits(ctx, path[1:], keeper)
 		case QueryCurrentPrice:
 			return queryCurrentPrice(ctx, path[1:], keeper)
 		case QueryCurrentReserve:
@@ -57,9 +63,9 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 
 func zeroReserveTokensIfEmpty(reserveCoins sdk.Coins, bond types.Bond) sdk.Coins {
 	if reserveCoins.IsZero() {
-		zeroes, _ := bond.GetNewReserveDecCoins(sdk.OneDec()).TruncateDecimal()
-		for i := range zeroes {
-			zeroes[i].Amount = sdk.ZeroInt()
+		zeroes := make(sdk.Coins, len(bond.ReserveTokens))
+		for i, r := range bond.ReserveTokens {
+			zeroes[i] = sdk.NewCoin(r, sdk.ZeroInt())
 		}
 		reserveCoins = zeroes
 	}
@@ -68,9 +74,9 @@ func zeroReserveTokensIfEmpty(reserveCoins sdk.Coins, bond types.Bond) sdk.Coins
 
 func zeroReserveTokensIfEmptyDec(reserveCoins sdk.DecCoins, bond types.Bond) sdk.DecCoins {
 	if reserveCoins.IsZero() {
-		zeroes := bond.GetNewReserveDecCoins(sdk.OneDec())
-		for i := range zeroes {
-			zeroes[i].Amount = sdk.ZeroDec()
+		zeroes := make(sdk.DecCoins, len(bond.ReserveTokens))
+		for i, r := range bond.ReserveTokens {
+			zeroes[i] = sdk.NewDecCoinFromDec(r, sdk.ZeroDec())
 		}
 		reserveCoins = zeroes
 	}
@@ -110,6 +116,28 @@ func queryBond(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err s
 	return bz, nil
 }
 
+func queryBondMetadata(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	bondToken := path[0]
+
+	bondDid, found := keeper.GetBondDid(ctx, bondToken)
+	if !found {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("bond with token '%s' does not exist", bondToken))
+	}
+	bond := keeper.MustGetBond(ctx, bondDid)
+
+	var result types.QueryBondMetadata
+	result.Token = bond.Token
+	result.BondDid = bond.BondDid
+	result.Metadata = bond.Metadata
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
 func queryBatch(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
 	bondDid := path[0]
 
@@ -144,6 +172,26 @@ func queryLastBatch(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 	return bz, nil
 }
 
+func queryOrderCommits(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	bondDid := path[0]
+
+	if !keeper.BondExists(ctx, bondDid) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("bond '%s' does not exist", bondDid))
+	}
+
+	commits := keeper.GetOrderCommits(ctx, bondDid)
+	if commits == nil {
+		commits = []types.OrderCommit{}
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, commits)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
 func queryCurrentPrice(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
 	bondDid := path[0]
 
@@ -238,7 +286,11 @@ func queryBuyPrice(ctx sdk.Context, path []string, keeper Keeper) (res []byte, e
 		return nil, err
 	}
 	reservePricesRounded := types.RoundReservePrices(reservePrices)
-	txFee := bond.GetTxFees(reservePrices)
+
+	// Tx fee depends on the current batch's imbalance if the buy is added to it
+	batch := keeper.MustGetBatch(ctx, bondDid)
+	batch.TotalBuyAmount = batch.TotalBuyAmount.Add(bondCoin)
+	txFee := bond.GetFees(reservePrices, bond.GetBuyTxFeePercentage(batch))
 
 	var result types.QueryBuyPrice
 	result.AdjustedSupply = adjustedSupply
@@ -283,7 +335,10 @@ func querySellReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 	reserveReturns := bond.GetReturnsForBurn(bondCoin.Amount, reserveBalances)
 	reserveReturnsRounded := types.RoundReserveReturns(reserveReturns)
 
-	txFees := bond.GetTxFees(reserveReturns)
+	// Tx fee depends on the current batch's imbalance if the sell is added to it
+	batch := keeper.MustGetBatch(ctx, bondDid)
+	batch.TotalSellAmount = batch.TotalSellAmount.Add(bondCoin)
+	txFees := bond.GetFees(reserveReturns, bond.GetSellTxFeePercentage(batch))
 	exitFees := bond.GetExitFees(reserveReturns)
 	totalFees := types.AdjustFees(txFees.Add(exitFees), reserveReturnsRounded)
 
@@ -0,0 +1,156 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/ixofoundation/ixo-blockchain/x/bonds/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	TestToken        = "abc"
+	TestReserveToken = "res"
+	TestBondDid      = "did:ixo:U7GK8p8rVhJMKhBVRCJJ8c"
+)
+
+var (
+	TestFeeAddress              = sdk.AccAddress(crypto.AddressHash([]byte("feeAddress")))
+	TestUnclaimedReserveAddress = sdk.AccAddress(crypto.AddressHash([]byte("unclaimedReserveAddress")))
+
+	// Seeds for the DIDs used in tests (creator, buyer/seller, recipient)
+	TestCreatorSeed   = [32]byte{1}
+	TestAccountSeed   = [32]byte{2}
+	TestRecipientSeed = [32]byte{3}
+)
+
+func CreateTestInput() (sdk.Context, Keeper, *codec.Codec) {
+	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+	actStoreKey := sdk.NewKVStoreKey(auth.StoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyStaking := sdk.NewKVStoreKey(staking.StoreKey)
+	tkeyStaking := sdk.NewTransientStoreKey(staking.TStoreKey)
+	keyDid := sdk.NewKVStoreKey(did.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(storeKey, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(actStoreKey, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyStaking, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(tkeyStaking, sdk.StoreTypeTransient, nil)
+	ms.MountStoreWithDB(keyDid, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, nil)
+
+	_ = ms.LoadLatestVersion()
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	did.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+
+	maccPerms := map[string][]string{
+		types.BondsMintBurnAccount:       {supply.Minter, supply.Burner},
+		types.BatchesIntermediaryAccount: nil,
+		types.BondsReserveAccount:        nil,
+		staking.NotBondedPoolName:        {supply.Burner, supply.Staking},
+		staking.BondedPoolName:           {supply.Burner, supply.Staking},
+	}
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams, params.DefaultCodespace)
+
+	accountKeeper := auth.NewAccountKeeper(cdc, actStoreKey, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := bank.NewBaseKeeper(accountKeeper, pk.Subspace(bank.DefaultParamspace), bank.DefaultCodespace, nil)
+	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, bankKeeper, maccPerms)
+	supplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.NewCoins()))
+	stakingKeeper := staking.NewKeeper(cdc, keyStaking, tkeyStaking, supplyKeeper, pk.Subspace(staking.DefaultParamspace), staking.DefaultCodespace)
+	didKeeper := did.NewKeeper(cdc, keyDid, pk.Subspace(did.DefaultParamspace))
+	didKeeper.SetParams(ctx, did.DefaultParams())
+
+	keeper := NewKeeper(bankKeeper, supplyKeeper, accountKeeper, stakingKeeper,
+		didKeeper, storeKey, pk.Subspace(types.DefaultParamspace), cdc)
+	keeper.SetParams(ctx, types.DefaultParams())
+
+	return ctx, keeper, cdc
+}
+
+// AddTestDid creates the DID generated from the seed and funds its address
+// with the coins. The DID and its address are returned.
+func AddTestDid(ctx sdk.Context, k Keeper, seed [32]byte, coins sdk.Coins) (did.Did, sdk.AccAddress) {
+	ixoDid, err := exported.FromSeed(seed)
+	if err != nil {
+		panic(err)
+	}
+
+	handler := did.NewHandler(k.DidKeeper, k.BankKeeper, k.accountKeeper)
+	res := handler(ctx, did.MsgAddDid{Did: ixoDid.Did, PubKey: ixoDid.VerifyKey})
+	if !res.IsOK() {
+		panic(res.Log)
+	}
+
+	addr := k.DidKeeper.MustGetDidDoc(ctx, ixoDid.Did).Address()
+	if _, err := k.BankKeeper.AddCoins(ctx, addr, coins); err != nil {
+		panic(err)
+	}
+	return ixoDid.Did, addr
+}
+
+// NewTestBond returns an open power function bond (m=12, n=2, c=100) with a
+// single reserve token, 0.5% tx fee, 0.1% exit fee and a one-block batch.
+func NewTestBond(creatorDid did.Did) types.Bond {
+	functionParams := types.FunctionParams{
+		types.NewFunctionParam("m", sdk.NewDec(12)),
+		types.NewFunctionParam("n", sdk.NewDec(2)),
+		types.NewFunctionParam("c", sdk.NewDec(100)),
+	}
+
+	return types.NewBond(TestToken, "Test bond", "Test bond description",
+		creatorDid, types.PowerFunction, functionParams,
+		[]string{TestReserveToken}, sdk.MustNewDecFromStr("0.5"),
+		sdk.MustNewDecFromStr("0.1"), TestFeeAddress,
+		sdk.NewInt64Coin(TestToken, 1000000), sdk.NewCoins(), sdk.ZeroDec(),
+		sdk.ZeroDec(), true, sdk.OneUint(), sdk.NewCoins(), types.OpenState,
+		TestBondDid, types.BondTokenMetadata{}, sdk.ZeroUint(),
+		TestUnclaimedReserveAddress, sdk.ZeroUint(), sdk.ZeroDec(),
+		sdk.DecCoins{}, false, sdk.ZeroDec(), sdk.OneUint(), did.AccessPolicy{})
+}
+
+// SetTestBond stores the bond together with its token-to-DID mapping and an
+// empty current batch, as done by the create bond handler.
+func SetTestBond(ctx sdk.Context, k Keeper, bond types.Bond) {
+	k.SetBond(ctx, bond.BondDid, bond)
+	k.SetBondDid(ctx, bond.Token, bond.BondDid)
+	k.SetBatch(ctx, bond.BondDid, types.NewBatch(bond.BondDid, bond.Token, bond.BatchBlocks))
+}
+
+// CreateTestInputWithBond creates the test input together with the creator
+// DID and the test bond, which is stored after being modified by setUp (if
+// not nil).
+func CreateTestInputWithBond(setUp func(bond *types.Bond)) (sdk.Context, Keeper, types.Bond) {
+	ctx, k, _ := CreateTestInput()
+	creatorDid, _ := AddTestDid(ctx, k, TestCreatorSeed, nil)
+
+	bond := NewTestBond(creatorDid)
+	if setUp != nil {
+		setUp(&bond)
+	}
+	SetTestBond(ctx, k, bond)
+	return ctx, k, bond
+}
@@ -31,18 +31,20 @@ func NewBatch(bondDid did.Did, token string, blocks sdk.Uint) Batch {
 }
 
 type BaseOrder struct {
-	AccountDid   did.Did  `json:"sender_did" yaml:"sender_did"`
-	Amount       sdk.Coin `json:"amount" yaml:"amount"`
-	Cancelled    bool     `json:"cancelled" yaml:"cancelled"`
-	CancelReason string   `json:"cancel_reason" yaml:"cancel_reason"`
+	AccountDid   did.Did        `json:"sender_did" yaml:"sender_did"`
+	Amount       sdk.Coin       `json:"amount" yaml:"amount"`
+	Cancelled    bool           `json:"cancelled" yaml:"cancelled"`
+	CancelReason string         `json:"cancel_reason" yaml:"cancel_reason"`
+	Recipient    sdk.AccAddress `json:"recipient" yaml:"recipient"`
 }
 
-func NewBaseOrder(accountDid did.Did, amount sdk.Coin) BaseOrder {
+func NewBaseOrder(accountDid did.Did, amount sdk.Coin, recipient sdk.AccAddress) BaseOrder {
 	return BaseOrder{
 		AccountDid:   accountDid,
 		Amount:       amount,
 		Cancelled:    false,
 		CancelReason: "",
+		Recipient:    recipient,
 	}
 }
 
@@ -50,36 +52,52 @@ func (bo BaseOrder) IsCancelled() bool {
 	return bo.Cancelled == true
 }
 
+// GetRecipient returns the address that should receive the order's returns,
+// i.e. the recipient (if specified) or otherwise the order's own address.
+func (bo BaseOrder) GetRecipient(accountAddr sdk.AccAddress) sdk.AccAddress {
+	if bo.Recipient.Empty() {
+		return accountAddr
+	}
+	return bo.Recipient
+}
+
 type BuyOrder struct {
 	BaseOrder
 	MaxPrices sdk.Coins `json:"max_prices" yaml:"max_prices"`
 }
 
-func NewBuyOrder(buyerDid did.Did, amount sdk.Coin, maxPrices sdk.Coins) BuyOrder {
+func NewBuyOrder(buyerDid did.Did, amount sdk.Coin, maxPrices sdk.Coins,
+	recipient sdk.AccAddress) BuyOrder {
 	return BuyOrder{
-		BaseOrder: NewBaseOrder(buyerDid, amount),
+		BaseOrder: NewBaseOrder(buyerDid, amount, recipient),
 		MaxPrices: maxPrices,
 	}
 }
 
 type SellOrder struct {
 	BaseOrder
+	MinReturns sdk.Coins `json:"min_returns" yaml:"min_returns"`
 }
 
-func NewSellOrder(sellerDid did.Did, amount sdk.Coin) SellOrder {
+func NewSellOrder(sellerDid did.Did, amount sdk.Coin, recipient sdk.AccAddress,
+	minReturns sdk.Coins) SellOrder {
 	return SellOrder{
-		BaseOrder: NewBaseOrder(sellerDid, amount),
+		BaseOrder:  NewBaseOrder(sellerDid, amount, recipient),
+		MinReturns: minReturns,
 	}
 }
 
 type SwapOrder struct {
 	BaseOrder
-	ToToken string `json:"to_token" yaml:"to_token"`
+	ToToken    string    `json:"to_token" yaml:"to_token"`
+	MinReturns sdk.Coins `json:"min_returns" yaml:"min_returns"`
 }
 
-func NewSwapOrder(swapperDid did.Did, from sdk.Coin, toToken string) SwapOrder {
+func NewSwapOrder(swapperDid did.Did, from sdk.Coin, toToken string,
+	recipient sdk.AccAddress, minReturns sdk.Coins) SwapOrder {
 	return SwapOrder{
-		BaseOrder: NewBaseOrder(swapperDid, from),
-		ToToken:   toToken,
+		BaseOrder:  NewBaseOrder(swapperDid, from, recipient),
+		ToToken:    toToken,
+		MinReturns: minReturns,
 	}
 }
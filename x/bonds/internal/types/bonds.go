@@ -17,10 +17,13 @@ const (
 	HatchState  = "HATCH"
 	OpenState   = "OPEN"
 	SettleState = "SETTLE"
+	ClosedState = "CLOSED"
 
 	DoNotModifyField = "[do-not-modify]"
 
 	AnyNumberOfReserveTokens = -1
+
+	MaxImbalanceFeeExponent = 10
 )
 
 type FunctionParamRestrictions func(paramsMap map[string]sdk.Dec) sdk.Error
@@ -181,27 +184,38 @@ func augmentedParameterRestrictions(paramsMap map[string]sdk.Dec) sdk.Error {
 }
 
 type Bond struct {
-	Token                  string         `json:"token" yaml:"token"`
-	Name                   string         `json:"name" yaml:"name"`
-	Description            string         `json:"description" yaml:"description"`
-	CreatorDid             did.Did        `json:"creator_did" yaml:"creator_did"`
-	FunctionType           string         `json:"function_type" yaml:"function_type"`
-	FunctionParameters     FunctionParams `json:"function_parameters" yaml:"function_parameters"`
-	ReserveTokens          []string       `json:"reserve_tokens" yaml:"reserve_tokens"`
-	TxFeePercentage        sdk.Dec        `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
-	ExitFeePercentage      sdk.Dec        `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
-	FeeAddress             sdk.AccAddress `json:"fee_address" yaml:"fee_address"`
-	MaxSupply              sdk.Coin       `json:"max_supply" yaml:"max_supply"`
-	OrderQuantityLimits    sdk.Coins      `json:"order_quantity_limits" yaml:"order_quantity_limits"`
-	SanityRate             sdk.Dec        `json:"sanity_rate" yaml:"sanity_rate"`
-	SanityMarginPercentage sdk.Dec        `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
-	CurrentSupply          sdk.Coin       `json:"current_supply" yaml:"current_supply"`
-	CurrentReserve         sdk.Coins      `json:"current_reserve" yaml:"current_reserve"`
-	AllowSells             bool           `json:"allow_sells" yaml:"allow_sells"`
-	BatchBlocks            sdk.Uint       `json:"batch_blocks" yaml:"batch_blocks"`
-	OutcomePayment         sdk.Coins      `json:"outcome_payment" yaml:"outcome_payment"`
-	State                  string         `json:"state" yaml:"state"`
-	BondDid                did.Did        `json:"bond_did" yaml:"bond_did"`
+	Token                       string            `json:"token" yaml:"token"`
+	Name                        string            `json:"name" yaml:"name"`
+	Description                 string            `json:"description" yaml:"description"`
+	CreatorDid                  did.Did           `json:"creator_did" yaml:"creator_did"`
+	FunctionType                string            `json:"function_type" yaml:"function_type"`
+	FunctionParameters          FunctionParams    `json:"function_parameters" yaml:"function_parameters"`
+	ReserveTokens               []string          `json:"reserve_tokens" yaml:"reserve_tokens"`
+	TxFeePercentage             sdk.Dec           `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	ExitFeePercentage           sdk.Dec           `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
+	FeeAddress                  sdk.AccAddress    `json:"fee_address" yaml:"fee_address"`
+	MaxSupply                   sdk.Coin          `json:"max_supply" yaml:"max_supply"`
+	OrderQuantityLimits         sdk.Coins         `json:"order_quantity_limits" yaml:"order_quantity_limits"`
+	SanityRate                  sdk.Dec           `json:"sanity_rate" yaml:"sanity_rate"`
+	SanityMarginPercentage      sdk.Dec           `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	CurrentSupply               sdk.Coin          `json:"current_supply" yaml:"current_supply"`
+	CurrentReserve              sdk.Coins         `json:"current_reserve" yaml:"current_reserve"`
+	AllowSells                  bool              `json:"allow_sells" yaml:"allow_sells"`
+	BatchBlocks                 sdk.Uint          `json:"batch_blocks" yaml:"batch_blocks"`
+	OutcomePayment              sdk.Coins         `json:"outcome_payment" yaml:"outcome_payment"`
+	State                       string            `json:"state" yaml:"state"`
+	BondDid                     did.Did           `json:"bond_did" yaml:"bond_did"`
+	Metadata                    BondTokenMetadata `json:"metadata" yaml:"metadata"`
+	SettlementClaimBlocks       sdk.Uint          `json:"settlement_claim_blocks" yaml:"settlement_claim_blocks"`
+	UnclaimedReserveAddress     sdk.AccAddress    `json:"unclaimed_reserve_address" yaml:"unclaimed_reserve_address"`
+	SettlementDeadline          int64             `json:"settlement_deadline" yaml:"settlement_deadline"`
+	RevealBlocks                sdk.Uint          `json:"reveal_blocks" yaml:"reveal_blocks"`
+	UnrevealedPenaltyPercentage sdk.Dec           `json:"unrevealed_penalty_percentage" yaml:"unrevealed_penalty_percentage"`
+	ReserveWeights              sdk.DecCoins      `json:"reserve_weights" yaml:"reserve_weights"`
+	InstantExecution            bool              `json:"instant_execution" yaml:"instant_execution"`
+	ImbalanceFeePercentage      sdk.Dec           `json:"imbalance_fee_percentage" yaml:"imbalance_fee_percentage"`
+	ImbalanceFeeExponent        sdk.Uint          `json:"imbalance_fee_exponent" yaml:"imbalance_fee_exponent"`
+	AccessPolicy                did.AccessPolicy  `json:"access_policy" yaml:"access_policy"`
 }
 
 func NewBond(token, name, description string, creatorDid did.Did,
@@ -209,45 +223,140 @@ func NewBond(token, name, description string, creatorDid did.Did,
 	txFeePercentage, exitFeePercentage sdk.Dec, feeAddress sdk.AccAddress,
 	maxSupply sdk.Coin, orderQuantityLimits sdk.Coins, sanityRate,
 	sanityMarginPercentage sdk.Dec, allowSells bool, batchBlocks sdk.Uint,
-	outcomePayment sdk.Coins, state string, bondDid did.Did) Bond {
+	outcomePayment sdk.Coins, state string, bondDid did.Did,
+	metadata BondTokenMetadata, settlementClaimBlocks sdk.Uint,
+	unclaimedReserveAddress sdk.AccAddress, revealBlocks sdk.Uint,
+	unrevealedPenaltyPercentage sdk.Dec, reserveWeights sdk.DecCoins,
+	instantExecution bool, imbalanceFeePercentage sdk.Dec,
+	imbalanceFeeExponent sdk.Uint, accessPolicy did.AccessPolicy) Bond {
 
 	// Ensure tokens and coins are sorted
 	sort.Strings(reserveTokens)
 	orderQuantityLimits = orderQuantityLimits.Sort()
+	reserveWeights = reserveWeights.Sort()
 
 	return Bond{
-		Token:                  token,
-		Name:                   name,
-		Description:            description,
-		CreatorDid:             creatorDid,
-		FunctionType:           functionType,
-		FunctionParameters:     functionParameters,
-		ReserveTokens:          reserveTokens,
-		TxFeePercentage:        txFeePercentage,
-		ExitFeePercentage:      exitFeePercentage,
-		FeeAddress:             feeAddress,
-		MaxSupply:              maxSupply,
-		OrderQuantityLimits:    orderQuantityLimits,
-		SanityRate:             sanityRate,
-		SanityMarginPercentage: sanityMarginPercentage,
-		CurrentSupply:          sdk.NewCoin(token, sdk.ZeroInt()),
-		CurrentReserve:         nil,
-		AllowSells:             allowSells,
-		BatchBlocks:            batchBlocks,
-		OutcomePayment:         outcomePayment,
-		State:                  state,
-		BondDid:                bondDid,
+		Token:                       token,
+		Name:                        name,
+		Description:                 description,
+		CreatorDid:                  creatorDid,
+		FunctionType:                functionType,
+		FunctionParameters:          functionParameters,
+		ReserveTokens:               reserveTokens,
+		TxFeePercentage:             txFeePercentage,
+		ExitFeePercentage:           exitFeePercentage,
+		FeeAddress:                  feeAddress,
+		MaxSupply:                   maxSupply,
+		OrderQuantityLimits:         orderQuantityLimits,
+		SanityRate:                  sanityRate,
+		SanityMarginPercentage:      sanityMarginPercentage,
+		CurrentSupply:               sdk.NewCoin(token, sdk.ZeroInt()),
+		CurrentReserve:              nil,
+		AllowSells:                  allowSells,
+		BatchBlocks:                 batchBlocks,
+		OutcomePayment:              outcomePayment,
+		State:                       state,
+		BondDid:                     bondDid,
+		Metadata:                    metadata,
+		SettlementClaimBlocks:       settlementClaimBlocks,
+		UnclaimedReserveAddress:     unclaimedReserveAddress,
+		SettlementDeadline:          0,
+		RevealBlocks:                revealBlocks,
+		UnrevealedPenaltyPercentage: unrevealedPenaltyPercentage,
+		ReserveWeights:              reserveWeights,
+		InstantExecution:            instantExecution,
+		ImbalanceFeePercentage:      imbalanceFeePercentage,
+		ImbalanceFeeExponent:        imbalanceFeeExponent,
+		AccessPolicy:                accessPolicy,
+	}
+}
+
+// ApplyDefaults sets the fields that are unset in bonds created before those
+// fields were introduced (e.g. bonds stored or exported by an older version) to
+// their zero value, which disables the corresponding feature.
+func (bond *Bond) ApplyDefaults() {
+	if bond.SettlementClaimBlocks == (sdk.Uint{}) {
+		bond.SettlementClaimBlocks = sdk.ZeroUint()
+	}
+	if bond.RevealBlocks == (sdk.Uint{}) {
+		bond.RevealBlocks = sdk.ZeroUint()
+	}
+	if bond.UnrevealedPenaltyPercentage.IsNil() {
+		bond.UnrevealedPenaltyPercentage = sdk.ZeroDec()
+	}
+	if bond.ImbalanceFeePercentage.IsNil() {
+		bond.ImbalanceFeePercentage = sdk.ZeroDec()
+	}
+	if bond.ImbalanceFeeExponent == (sdk.Uint{}) {
+		bond.ImbalanceFeeExponent = sdk.ZeroUint()
+	}
+}
+
+// CommitRevealEnabled returns true if orders to the bond have to be submitted
+// by first committing to the order and then revealing it (MsgCommitOrder and
+// MsgRevealOrder) rather than by submitting the order directly.
+func (bond Bond) CommitRevealEnabled() bool {
+	return !bond.RevealBlocks.IsZero()
+}
+
+func (bond Bond) GetUnrevealedPenalty(escrow sdk.Coin) sdk.Coin {
+	penalty := bond.UnrevealedPenaltyPercentage.Quo(sdk.NewDec(100)).MulInt(escrow.Amount)
+	return sdk.NewCoin(escrow.Denom, penalty.TruncateInt())
+}
+
+// HasSettlementClaimDeadline returns true if the bond's unclaimed reserve is
+// to be swept to the unclaimed reserve address some blocks after settlement.
+func (bond Bond) HasSettlementClaimDeadline() bool {
+	return !bond.UnclaimedReserveAddress.Empty()
+}
+
+// SettlementClaimDeadlineReached returns true if the bond is in the SETTLE
+// state and the settlement claim deadline has been reached at the height.
+func (bond Bond) SettlementClaimDeadlineReached(height int64) bool {
+	return bond.State == SettleState && bond.SettlementDeadline > 0 &&
+		height >= bond.SettlementDeadline
+}
+
+// GetReserveWeight returns the weight of the reserve token, i.e. the number
+// of reserve tokens charged or returned per unit of the bond's price. If no
+// reserve weights were specified, all reserve tokens have a weight of one.
+func (bond Bond) GetReserveWeight(reserveToken string) sdk.Dec {
+	if bond.ReserveWeights.Empty() {
+		return sdk.OneDec()
 	}
+	return bond.ReserveWeights.AmountOf(reserveToken)
 }
 
 //noinspection GoNilness
 func (bond Bond) GetNewReserveDecCoins(amount sdk.Dec) (coins sdk.DecCoins) {
 	for _, r := range bond.ReserveTokens {
-		coins = coins.Add(sdk.DecCoins{sdk.NewDecCoinFromDec(r, amount)})
+		weighted := amount.Mul(bond.GetReserveWeight(r))
+		coins = coins.Add(sdk.DecCoins{sdk.NewDecCoinFromDec(r, weighted)})
 	}
 	return coins
 }
 
+// GetCommonReserveBalance returns the reserve balance in terms of the bond's
+// price (i.e. without reserve weights). Reserve balances should all be equal
+// after removing the weights, given that we are always applying the same
+// weighted additions/subtractions to all reserve balances. Since rounding can
+// cause minor differences, the smallest of the balances is picked so that the
+// reserve is never overestimated.
+func (bond Bond) GetCommonReserveBalance(reserveBalances sdk.Coins) sdk.Dec {
+	if reserveBalances.Empty() {
+		return sdk.ZeroDec()
+	}
+
+	var common sdk.Dec
+	for i, r := range bond.ReserveTokens {
+		balance := reserveBalances.AmountOf(r).ToDec().Quo(bond.GetReserveWeight(r))
+		if i == 0 || balance.LT(common) {
+			common = balance
+		}
+	}
+	return common
+}
+
 func (bond Bond) GetPricesAtSupply(supply sdk.Int) (result sdk.DecCoins, err sdk.Error) {
 	if supply.IsNegative() {
 		panic(fmt.Sprintf("negative supply for bond %s", bond.Token))
@@ -434,17 +543,9 @@ func (bond Bond) GetPricesToMint(mint sdk.Int, reserveBalances sdk.Coins) (sdk.D
 	case SigmoidFunction:
 		fallthrough
 	case AugmentedFunction:
-		var priceToMint sdk.Dec
 		result := bond.ReserveAtSupply(bond.CurrentSupply.Amount.Add(mint))
-		if reserveBalances.Empty() {
-			priceToMint = result
-		} else {
-			// Reserve balances should all be equal given that we are always
-			// applying the same additions/subtractions to all reserve balances.
-			// Thus we can pick the first reserve balance as the global balance.
-			commonReserveBalance := reserveBalances[0].Amount.ToDec()
-			priceToMint = result.Sub(commonReserveBalance)
-		}
+		commonReserveBalance := bond.GetCommonReserveBalance(reserveBalances)
+		priceToMint := result.Sub(commonReserveBalance)
 		if priceToMint.IsNegative() {
 			// Negative priceToMint means that the previous buyer overpaid
 			// to the point that the price for this buyer is covered. However,
@@ -477,16 +578,7 @@ func (bond Bond) GetReturnsForBurn(burn sdk.Int, reserveBalances sdk.Coins) sdk.
 		fallthrough
 	case AugmentedFunction:
 		result := bond.ReserveAtSupply(bond.CurrentSupply.Amount.Sub(burn))
-
-		var reserveBalance sdk.Dec
-		if reserveBalances.Empty() {
-			reserveBalance = sdk.ZeroDec()
-		} else {
-			// Reserve balances should all be equal given that we are always
-			// applying the same additions/subtractions to all reserve balances.
-			// Thus we can pick the first reserve balance as the global balance.
-			reserveBalance = reserveBalances[0].Amount.ToDec()
-		}
+		reserveBalance := bond.GetCommonReserveBalance(reserveBalances)
 
 		if result.GT(reserveBalance) {
 			panic("not enough reserve available for burn")
@@ -581,6 +673,59 @@ func (bond Bond) GetTxFees(reserveAmounts sdk.DecCoins) (fees sdk.Coins) {
 	return bond.GetFees(reserveAmounts, bond.TxFeePercentage)
 }
 
+// DynamicFeesEnabled returns true if the orders on the side of a batch that
+// moves the price (i.e. the excess buys or excess sells) pay an imbalance fee
+// in addition to the tx fee.
+func (bond Bond) DynamicFeesEnabled() bool {
+	return bond.ImbalanceFeePercentage.IsPositive()
+}
+
+// GetImbalanceFeePercentage returns the fee percentage charged on the unmatched
+// amount of the batch. This follows the curve f*(|B-S|/(B+S))^e for total buys
+// B and total sells S, where f is the imbalance fee percentage and e is the
+// imbalance fee exponent, such that the fee is zero for a balanced batch and
+// reaches f for a batch with only buys or only sells.
+func (bond Bond) GetImbalanceFeePercentage(batch Batch) sdk.Dec {
+	if !bond.DynamicFeesEnabled() || batch.EqualBuysAndSells() {
+		return sdk.ZeroDec()
+	}
+
+	buys := batch.TotalBuyAmount.Amount.ToDec()
+	sells := batch.TotalSellAmount.Amount.ToDec()
+	imbalance := buys.Sub(sells).Abs().Quo(buys.Add(sells))
+
+	return bond.ImbalanceFeePercentage.Mul(
+		Power(imbalance, bond.ImbalanceFeeExponent.Uint64()))
+}
+
+// GetBuyTxFeePercentage returns the tx fee percentage charged on buys in the
+// batch. If there are more buys than sells, the unmatched fraction of each buy
+// is charged the imbalance fee on top of the tx fee. Otherwise, buys are fully
+// matched and are only charged the tx fee.
+func (bond Bond) GetBuyTxFeePercentage(batch Batch) sdk.Dec {
+	if !bond.DynamicFeesEnabled() || !batch.MoreBuysThanSells() {
+		return bond.TxFeePercentage
+	}
+
+	excessBuys := batch.TotalBuyAmount.Sub(batch.TotalSellAmount)
+	unmatched := excessBuys.Amount.ToDec().Quo(batch.TotalBuyAmount.Amount.ToDec())
+	return bond.TxFeePercentage.Add(bond.GetImbalanceFeePercentage(batch).Mul(unmatched))
+}
+
+// GetSellTxFeePercentage returns the tx fee percentage charged on sells in the
+// batch. If there are more sells than buys, the unmatched fraction of each sell
+// is charged the imbalance fee on top of the tx fee. Otherwise, sells are fully
+// matched and are only charged the tx fee.
+func (bond Bond) GetSellTxFeePercentage(batch Batch) sdk.Dec {
+	if !bond.DynamicFeesEnabled() || !batch.MoreSellsThanBuys() {
+		return bond.TxFeePercentage
+	}
+
+	excessSells := batch.TotalSellAmount.Sub(batch.TotalBuyAmount)
+	unmatched := excessSells.Amount.ToDec().Quo(batch.TotalSellAmount.Amount.ToDec())
+	return bond.TxFeePercentage.Add(bond.GetImbalanceFeePercentage(batch).Mul(unmatched))
+}
+
 //noinspection GoNilness
 func (bond Bond) GetExitFees(reserveAmounts sdk.DecCoins) (fees sdk.Coins) {
 	return bond.GetFees(reserveAmounts, bond.ExitFeePercentage)
@@ -12,6 +12,7 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(&BuyOrder{}, "bonds/BuyOrder", nil)
 	cdc.RegisterConcrete(&SellOrder{}, "bonds/SellOrder", nil)
 	cdc.RegisterConcrete(&SwapOrder{}, "bonds/SwapOrder", nil)
+	cdc.RegisterConcrete(&OrderCommit{}, "bonds/OrderCommit", nil)
 	cdc.RegisterConcrete(MsgCreateBond{}, "bonds/MsgCreateBond", nil)
 	cdc.RegisterConcrete(MsgEditBond{}, "bonds/MsgEditBond", nil)
 	cdc.RegisterConcrete(MsgBuy{}, "bonds/MsgBuy", nil)
@@ -19,6 +20,8 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSwap{}, "bonds/MsgSwap", nil)
 	cdc.RegisterConcrete(MsgMakeOutcomePayment{}, "bonds/MsgMakeOutcomePayment", nil)
 	cdc.RegisterConcrete(MsgWithdrawShare{}, "bonds/MsgWithdrawShare", nil)
+	cdc.RegisterConcrete(MsgCommitOrder{}, "bonds/MsgCommitOrder", nil)
+	cdc.RegisterConcrete(MsgRevealOrder{}, "bonds/MsgRevealOrder", nil)
 }
 
 // ModuleCdc is the codec for the module
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+)
+
+type OrderCommit struct {
+	BondDid      did.Did   `json:"bond_did" yaml:"bond_did"`
+	AccountDid   did.Did   `json:"sender_did" yaml:"sender_did"`
+	CommitHash   string    `json:"commit_hash" yaml:"commit_hash"`
+	Escrow       sdk.Coin  `json:"escrow" yaml:"escrow"`
+	CommitHeight int64     `json:"commit_height" yaml:"commit_height"`
+	Revealed     bool      `json:"revealed" yaml:"revealed"`
+	Order        SwapOrder `json:"order" yaml:"order"`
+}
+
+func NewOrderCommit(bondDid, accountDid did.Did, commitHash string,
+	escrow sdk.Coin, commitHeight int64) OrderCommit {
+	return OrderCommit{
+		BondDid:      bondDid,
+		AccountDid:   accountDid,
+		CommitHash:   commitHash,
+		Escrow:       escrow,
+		CommitHeight: commitHeight,
+		Revealed:     false,
+	}
+}
+
+// RevealWindowOpen returns true if the commit can be revealed at the height,
+// i.e. if the height is after the commit height and within the reveal blocks.
+func (oc OrderCommit) RevealWindowOpen(height int64, revealBlocks sdk.Uint) bool {
+	return height > oc.CommitHeight && !oc.RevealWindowClosed(height, revealBlocks)
+}
+
+// RevealWindowClosed returns true if the reveal blocks have passed since the
+// commit height, meaning that the commit can no longer be revealed.
+func (oc OrderCommit) RevealWindowClosed(height int64, revealBlocks sdk.Uint) bool {
+	return height > oc.CommitHeight+int64(revealBlocks.Uint64())
+}
+
+type swapOrderCommitment struct {
+	SwapperDid       did.Did        `json:"swapper_did"`
+	BondDid          did.Did        `json:"bond_did"`
+	From             sdk.Coin       `json:"from"`
+	ToToken          string         `json:"to_token"`
+	RecipientDid     did.Did        `json:"recipient_did"`
+	RecipientAddress sdk.AccAddress `json:"recipient_address"`
+	Salt             string         `json:"salt"`
+}
+
+// GetSwapOrderCommitHash returns the hex-encoded SHA-256 hash of the sorted
+// JSON representation of the swap order details and the salt. The swapper
+// DID and bond DID are included so that a commitment cannot be replayed.
+func GetSwapOrderCommitHash(swapperDid, bondDid did.Did, from sdk.Coin,
+	toToken string, recipientDid did.Did, recipientAddress sdk.AccAddress,
+	salt string) string {
+	commitment := swapOrderCommitment{
+		SwapperDid:       swapperDid,
+		BondDid:          bondDid,
+		From:             from,
+		ToToken:          toToken,
+		RecipientDid:     recipientDid,
+		RecipientAddress: recipientAddress,
+		Salt:             salt,
+	}
+	hash := sha256.Sum256(sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(commitment)))
+	return hex.EncodeToString(hash[:])
+}
@@ -27,6 +27,7 @@ const (
 	CodeInvalidSwapper          CodeType = 309
 	CodeInvalidBond             CodeType = 310
 	CodeInvalidState            CodeType = 311
+	CodeInvalidMetadata         CodeType = 329
 
 	// Function types and function parameters
 	CodeUnrecognizedFunctionType             CodeType = 312
@@ -50,6 +51,15 @@ const (
 	CodeFeeTooLarge                CodeType = 326
 	CodeNoBondTokensOwned          CodeType = 327
 	CodeInsufficientReserveToBuy   CodeType = 328
+
+	// Commit-reveal
+	CodeCommitRevealNotEnabled CodeType = 330
+	CodeCommitRevealRequired   CodeType = 331
+	CodeOrderCommitInvalid     CodeType = 332
+	CodeRevealWindowNotOpen    CodeType = 333
+
+	// Instant execution
+	CodeMinReturnsNotMet CodeType = 334
 )
 
 func ErrArgumentCannotBeEmpty(codespace sdk.CodespaceType, argument string) sdk.Error {
@@ -77,6 +87,11 @@ func ErrArgumentMustBeBetween(codespace sdk.CodespaceType, arg string, a, b stri
 	return sdk.NewError(codespace, CodeArgumentInvalid, errMsg)
 }
 
+func ErrArgumentsCannotBothBeSpecified(codespace sdk.CodespaceType, arg1, arg2 string) sdk.Error {
+	errMsg := fmt.Sprintf("%s and %s arguments cannot both be specified", arg1, arg2)
+	return sdk.NewError(codespace, CodeArgumentInvalid, errMsg)
+}
+
 func ErrFunctionParameterMissingOrNonFloat(codespace sdk.CodespaceType, param string) sdk.Error {
 	errMsg := fmt.Sprintf("%s parameter is missing or is not a float", param)
 	return sdk.NewError(codespace, CodeArgumentMissingOrIncorrectType, errMsg)
@@ -147,6 +162,11 @@ func ErrReservedBondToken(codespace sdk.CodespaceType, bondToken string) sdk.Err
 	return sdk.NewError(codespace, CodeInvalidBond, errMsg)
 }
 
+func ErrInvalidBondTokenMetadata(codespace sdk.CodespaceType, reason string) sdk.Error {
+	errMsg := fmt.Sprintf("Invalid bond token metadata: %s", reason)
+	return sdk.NewError(codespace, CodeInvalidMetadata, errMsg)
+}
+
 func ErrInvalidStateForAction(codespace sdk.CodespaceType) sdk.Error {
 	errMsg := "Cannot perform that action at the current state"
 	return sdk.NewError(codespace, CodeInvalidState, errMsg)
@@ -261,3 +281,53 @@ func ErrInsufficientReserveToBuy(codespace sdk.CodespaceType) sdk.Error {
 	errMsg := "Insufficient reserve was supplied to perform buy order"
 	return sdk.NewError(codespace, CodeInsufficientReserveToBuy, errMsg)
 }
+
+func ErrCommitRevealNotEnabled(codespace sdk.CodespaceType) sdk.Error {
+	errMsg := "Commit-reveal order submission is not enabled for this bond"
+	return sdk.NewError(codespace, CodeCommitRevealNotEnabled, errMsg)
+}
+
+func ErrCommitRevealRequired(codespace sdk.CodespaceType) sdk.Error {
+	errMsg := "Orders to this bond must be submitted using commit-reveal"
+	return sdk.NewError(codespace, CodeCommitRevealRequired, errMsg)
+}
+
+func ErrOrderCommitInvalid(codespace sdk.CodespaceType, reason string) sdk.Error {
+	errMsg := fmt.Sprintf("Order commit is invalid: %s", reason)
+	return sdk.NewError(codespace, CodeOrderCommitInvalid, errMsg)
+}
+
+func ErrOrderCommitAlreadyExists(codespace sdk.CodespaceType, commitHash string) sdk.Error {
+	errMsg := fmt.Sprintf("Order commit '%s' already exists", commitHash)
+	return sdk.NewError(codespace, CodeOrderCommitInvalid, errMsg)
+}
+
+func ErrOrderCommitDoesNotExist(codespace sdk.CodespaceType, commitHash string) sdk.Error {
+	errMsg := fmt.Sprintf("Order commit '%s' does not exist", commitHash)
+	return sdk.NewError(codespace, CodeOrderCommitInvalid, errMsg)
+}
+
+func ErrOrderCommitAlreadyRevealed(codespace sdk.CodespaceType, commitHash string) sdk.Error {
+	errMsg := fmt.Sprintf("Order commit '%s' was already revealed", commitHash)
+	return sdk.NewError(codespace, CodeOrderCommitInvalid, errMsg)
+}
+
+func ErrRevealedOrderDoesNotMatchEscrow(codespace sdk.CodespaceType, from, escrow sdk.Coin) sdk.Error {
+	errMsg := fmt.Sprintf("Revealed order amount %s does not fit in escrow %s", from, escrow)
+	return sdk.NewError(codespace, CodeOrderCommitInvalid, errMsg)
+}
+
+func ErrRevealWindowNotOpen(codespace sdk.CodespaceType) sdk.Error {
+	errMsg := "Order commit can only be revealed after the commit block and within the reveal blocks"
+	return sdk.NewError(codespace, CodeRevealWindowNotOpen, errMsg)
+}
+
+func ErrMinReturnsRequireInstantExecution(codespace sdk.CodespaceType) sdk.Error {
+	errMsg := "Min returns can only be specified for bonds with instant execution"
+	return sdk.NewError(codespace, CodeArgumentInvalid, errMsg)
+}
+
+func ErrMinReturnsNotMet(codespace sdk.CodespaceType, returns, minReturns sdk.Coins) sdk.Error {
+	errMsg := fmt.Sprintf("Actual returns %s are less than min returns %s", returns.String(), minReturns.String())
+	return sdk.NewError(codespace, CodeMinReturnsNotMet, errMsg)
+}
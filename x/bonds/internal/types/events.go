@@ -1,53 +1,83 @@
 package types
 
 const (
-	EventTypeCreateBond         = "create_bond"
-	EventTypeEditBond           = "edit_bond"
-	EventTypeInitSwapper        = "init_swapper"
-	EventTypeBuy                = "buy"
-	EventTypeSell               = "sell"
-	EventTypeSwap               = "swap"
-	EventTypeMakeOutcomePayment = "make_outcome_payment"
-	EventTypeWithdrawShare      = "withdraw_share"
-	EventTypeOrderCancel        = "order_cancel"
-	EventTypeOrderFulfill       = "order_fulfill"
-	EventTypeStateChange        = "state_change"
+	EventTypeCreateBond              = "create_bond"
+	EventTypeEditBond                = "edit_bond"
+	EventTypeInitSwapper             = "init_swapper"
+	EventTypeBuy                     = "buy"
+	EventTypeSell                    = "sell"
+	EventTypeSwap                    = "swap"
+	EventTypeMakeOutcomePayment      = "make_outcome_payment"
+	EventTypeWithdrawShare           = "withdraw_share"
+	EventTypeOrderCancel             = "order_cancel"
+	EventTypeOrderFulfill            = "order_fulfill"
+	EventTypeStateChange             = "state_change"
+	EventTypeSweepReserve            = "sweep_reserve"
+	EventTypeCommitOrder             = "commit_order"
+	EventTypeRevealOrder             = "reveal_order"
+	EventTypeOrderCommitExpire       = "order_commit_expire"
+	EventTypeOrderCommitExpireFailed = "order_commit_expire_failed"
+	EventTypeDeactivatedDidWarning   = "deactivated_did_warning"
 
-	AttributeKeyBondDid                = "bond_did"
-	AttributeKeyToken                  = "token"
-	AttributeKeyName                   = "name"
-	AttributeKeyDescription            = "description"
-	AttributeKeyFunctionType           = "function_type"
-	AttributeKeyFunctionParameters     = "function_parameters"
-	AttributeKeyReserveTokens          = "reserve_tokens"
-	AttributeKeyTxFeePercentage        = "tx_fee_percentage"
-	AttributeKeyExitFeePercentage      = "exit_fee_percentage"
-	AttributeKeyFeeAddress             = "fee_address"
-	AttributeKeyMaxSupply              = "max_supply"
-	AttributeKeyOrderQuantityLimits    = "order_quantity_limits"
-	AttributeKeySanityRate             = "sanity_rate"
-	AttributeKeySanityMarginPercentage = "sanity_margin_percentage"
-	AttributeKeyAllowSells             = "allow_sells"
-	AttributeKeyBatchBlocks            = "batch_blocks"
-	AttributeKeyOutcomePayment         = "outcome_payment"
-	AttributeKeyState                  = "state"
-	AttributeKeyMaxPrices              = "max_prices"
-	AttributeKeySwapFromToken          = "from_token"
-	AttributeKeySwapToToken            = "to_token"
-	AttributeKeyOrderType              = "order_type"
-	AttributeKeyAddress                = "address"
-	AttributeKeyCancelReason           = "cancel_reason"
-	AttributeKeyTokensMinted           = "tokens_minted"
-	AttributeKeyTokensBurned           = "tokens_burned"
-	AttributeKeyTokensSwapped          = "tokens_swapped"
-	AttributeKeyChargedPrices          = "charged_prices"
-	AttributeKeyChargedPricesReserve   = "charged_prices_of_which_reserve"
-	AttributeKeyChargedPricesFunding   = "charged_prices_of_which_funding"
-	AttributeKeyChargedFees            = "charged_fees"
-	AttributeKeyReturnedToAddress      = "returned_to_address"
-	AttributeKeyNewBondTokenBalance    = "new_bond_token_balance"
-	AttributeKeyOldState               = "old_state"
-	AttributeKeyNewState               = "new_state"
+	AttributeKeyBondDid                     = "bond_did"
+	AttributeKeyCreatorDid                  = "creator_did"
+	AttributeKeyToken                       = "token"
+	AttributeKeyName                        = "name"
+	AttributeKeyDescription                 = "description"
+	AttributeKeyFunctionType                = "function_type"
+	AttributeKeyFunctionParameters          = "function_parameters"
+	AttributeKeyReserveTokens               = "reserve_tokens"
+	AttributeKeyReserveWeights              = "reserve_weights"
+	AttributeKeyTxFeePercentage             = "tx_fee_percentage"
+	AttributeKeyExitFeePercentage           = "exit_fee_percentage"
+	AttributeKeyImbalanceFeePercentage      = "imbalance_fee_percentage"
+	AttributeKeyImbalanceFeeExponent        = "imbalance_fee_exponent"
+	AttributeKeyFeeAddress                  = "fee_address"
+	AttributeKeyMaxSupply                   = "max_supply"
+	AttributeKeyOrderQuantityLimits         = "order_quantity_limits"
+	AttributeKeySanityRate                  = "sanity_rate"
+	AttributeKeySanityMarginPercentage      = "sanity_margin_percentage"
+	AttributeKeyAllowSells                  = "allow_sells"
+	AttributeKeyBatchBlocks                 = "batch_blocks"
+	AttributeKeyOutcomePayment              = "outcome_payment"
+	AttributeKeyState                       = "state"
+	AttributeKeySettlementClaimBlocks       = "settlement_claim_blocks"
+	AttributeKeyUnclaimedReserveAddress     = "unclaimed_reserve_address"
+	AttributeKeySettlementDeadline          = "settlement_deadline"
+	AttributeKeyRevealBlocks                = "reveal_blocks"
+	AttributeKeyUnrevealedPenaltyPercentage = "unrevealed_penalty_percentage"
+	AttributeKeyInstantExecution            = "instant_execution"
+	AttributeKeyMinReturns                  = "min_returns"
+	AttributeKeyRequiredCredentialTypes     = "required_credential_types"
+	AttributeKeyTrustedIssuers              = "trusted_issuers"
+	AttributeKeyDisplayDenom                = "display_denom"
+	AttributeKeyExponent                    = "exponent"
+	AttributeKeySymbol                      = "symbol"
+	AttributeKeyIconUri                     = "icon_uri"
+	AttributeKeyWebsite                     = "website"
+	AttributeKeyMaxPrices                   = "max_prices"
+	AttributeKeySwapFromToken               = "from_token"
+	AttributeKeySwapToToken                 = "to_token"
+	AttributeKeyOrderType                   = "order_type"
+	AttributeKeyAddress                     = "address"
+	AttributeKeyRecipient                   = "recipient"
+	AttributeKeyBondTokensWithdrawn         = "bond_tokens_withdrawn"
+	AttributeKeyCommitHash                  = "commit_hash"
+	AttributeKeyEscrow                      = "escrow"
+	AttributeKeyPenalty                     = "penalty"
+	AttributeKeyCancelReason                = "cancel_reason"
+	AttributeKeyReason                      = "reason"
+	AttributeKeyTokensMinted                = "tokens_minted"
+	AttributeKeyTokensBurned                = "tokens_burned"
+	AttributeKeyTokensSwapped               = "tokens_swapped"
+	AttributeKeyChargedPrices               = "charged_prices"
+	AttributeKeyChargedPricesReserve        = "charged_prices_of_which_reserve"
+	AttributeKeyChargedPricesFunding        = "charged_prices_of_which_funding"
+	AttributeKeyChargedFees                 = "charged_fees"
+	AttributeKeyReturnedToAddress           = "returned_to_address"
+	AttributeKeyNewBondTokenBalance         = "new_bond_token_balance"
+	AttributeKeyOldState                    = "old_state"
+	AttributeKeyNewState                    = "new_state"
 
 	AttributeValueBuyOrder  = "buy"
 	AttributeValueSellOrder = "sell"
@@ -1,16 +1,19 @@
 package types
 
 type GenesisState struct {
-	Bonds   []Bond  `json:"bonds" yaml:"bonds"`
-	Batches []Batch `json:"batches" yaml:"batches"`
-	Params  Params  `json:"params" yaml:"params"`
+	Bonds        []Bond        `json:"bonds" yaml:"bonds"`
+	Batches      []Batch       `json:"batches" yaml:"batches"`
+	Params       Params        `json:"params" yaml:"params"`
+	OrderCommits []OrderCommit `json:"order_commits" yaml:"order_commits"`
 }
 
-func NewGenesisState(bonds []Bond, batches []Batch, params Params) GenesisState {
+func NewGenesisState(bonds []Bond, batches []Batch, params Params,
+	orderCommits []OrderCommit) GenesisState {
 	return GenesisState{
-		Bonds:   bonds,
-		Batches: batches,
-		Params:  params,
+		Bonds:        bonds,
+		Batches:      batches,
+		Params:       params,
+		OrderCommits: orderCommits,
 	}
 }
 
@@ -25,8 +28,9 @@ func ValidateGenesis(data GenesisState) error {
 
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		Bonds:   nil,
-		Batches: nil,
-		Params:  DefaultParams(),
+		Bonds:        nil,
+		Batches:      nil,
+		Params:       DefaultParams(),
+		OrderCommits: nil,
 	}
 }
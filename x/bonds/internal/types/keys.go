@@ -1,6 +1,11 @@
 package types
 
-import "github.com/ixofoundation/ixo-blockchain/x/did"
+import (
+	"encoding/binary"
+
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+	"github.com/ixofoundation/ixo-blockchain/x/ixo"
+)
 
 const (
 	// ModuleName is the name of this module
@@ -34,11 +39,17 @@ const (
 // - Batches: 0x01<bond_did_bytes>
 // - Last batches: 0x02<bond_did_bytes>
 // - Bond DIDs: 0x03<bond_token_bytes>
+// - Order commits: 0x04<len_bond_did><bond_did_bytes><len_swapper_did><swapper_did_bytes><commit_hash_bytes>
+// - Order commit queue: 0x05<len_bond_did><bond_did_bytes><big_endian_sequence_bytes>
+// - Creator bond DIDs: 0x06<len_creator_did><creator_did_bytes><bond_did_bytes>
 var (
-	BondsKeyPrefix       = []byte{0x00} // key for bonds
-	BatchesKeyPrefix     = []byte{0x01} // key for batches
-	LastBatchesKeyPrefix = []byte{0x02} // key for last batches
-	BondDidsKeyPrefix    = []byte{0x03} // key for bond DIDs
+	BondsKeyPrefix            = []byte{0x00} // key for bonds
+	BatchesKeyPrefix          = []byte{0x01} // key for batches
+	LastBatchesKeyPrefix      = []byte{0x02} // key for last batches
+	BondDidsKeyPrefix         = []byte{0x03} // key for bond DIDs
+	OrderCommitsKeyPrefix     = []byte{0x04} // key for order commits
+	OrderCommitQueueKeyPrefix = []byte{0x05} // key for order commit queue
+	CreatorBondDidsKeyPrefix  = []byte{0x06} // key for bond DIDs by creator
 )
 
 func GetBondKey(bondDid did.Did) []byte {
@@ -56,3 +67,31 @@ func GetLastBatchKey(bondDid did.Did) []byte {
 func GetBondDidsKey(token string) []byte {
 	return append(BondDidsKeyPrefix, []byte(token)...)
 }
+
+func GetOrderCommitKey(bondDid, swapperDid did.Did, commitHash string) []byte {
+	key := append(OrderCommitsKeyPrefix, ixo.LengthPrefix([]byte(bondDid))...)
+	key = append(key, ixo.LengthPrefix([]byte(swapperDid))...)
+	return append(key, []byte(commitHash)...)
+}
+
+func GetOrderCommitQueuePrefixKey(bondDid did.Did) []byte {
+	return append(OrderCommitQueueKeyPrefix, ixo.LengthPrefix([]byte(bondDid))...)
+}
+
+func GetCreatorBondDidsPrefixKey(creatorDid did.Did) []byte {
+	return append(CreatorBondDidsKeyPrefix, ixo.LengthPrefix([]byte(creatorDid))...)
+}
+
+func GetCreatorBondDidKey(creatorDid, bondDid did.Did) []byte {
+	return append(GetCreatorBondDidsPrefixKey(creatorDid), []byte(bondDid)...)
+}
+
+// GetOrderCommitQueueKey is the key of a bond's order commit in the order
+// commit queue, which orders the bond's order commits by the sequence in which
+// they were committed. Sequences are big-endian encoded so that they are
+// iterated in order.
+func GetOrderCommitQueueKey(bondDid did.Did, sequence uint64) []byte {
+	sequenceBz := make([]byte, 8)
+	binary.BigEndian.PutUint64(sequenceBz, sequence)
+	return append(GetOrderCommitQueuePrefixKey(bondDid), sequenceBz...)
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"net/url"
+)
+
+const (
+	MaxMetadataExponent     = sdk.Precision
+	MaxMetadataSymbolLength = 16
+	MaxMetadataUriLength    = 256
+)
+
+type BondTokenMetadata struct {
+	DisplayDenom string `json:"display_denom" yaml:"display_denom"`
+	Exponent     uint32 `json:"exponent" yaml:"exponent"`
+	Symbol       string `json:"symbol" yaml:"symbol"`
+	IconUri      string `json:"icon_uri" yaml:"icon_uri"`
+	Website      string `json:"website" yaml:"website"`
+}
+
+func NewBondTokenMetadata(displayDenom string, exponent uint32, symbol,
+	iconUri, website string) BondTokenMetadata {
+	return BondTokenMetadata{
+		DisplayDenom: displayDenom,
+		Exponent:     exponent,
+		Symbol:       symbol,
+		IconUri:      iconUri,
+		Website:      website,
+	}
+}
+
+func (m BondTokenMetadata) IsEmpty() bool {
+	return m == BondTokenMetadata{}
+}
+
+func (m BondTokenMetadata) Validate() sdk.Error {
+	// Metadata is optional, so an empty metadata is valid
+	if m.IsEmpty() {
+		return nil
+	}
+
+	// Check that display denom is a valid denomination (if specified), and
+	// that an exponent is only specified together with a display denom
+	if m.DisplayDenom != "" {
+		if err := CheckCoinDenom(m.DisplayDenom); err != nil {
+			return ErrInvalidBondTokenMetadata(DefaultCodespace,
+				fmt.Sprintf("display denom '%s' is invalid", m.DisplayDenom))
+		}
+	} else if m.Exponent != 0 {
+		return ErrInvalidBondTokenMetadata(DefaultCodespace,
+			"exponent cannot be set without a display denom")
+	}
+
+	// Check that exponent does not exceed the decimal precision
+	if m.Exponent > MaxMetadataExponent {
+		return ErrInvalidBondTokenMetadata(DefaultCodespace, fmt.Sprintf(
+			"exponent cannot be greater than %d", MaxMetadataExponent))
+	}
+
+	// Check symbol length
+	if len(m.Symbol) > MaxMetadataSymbolLength {
+		return ErrInvalidBondTokenMetadata(DefaultCodespace, fmt.Sprintf(
+			"symbol cannot be longer than %d characters", MaxMetadataSymbolLength))
+	}
+
+	// Check that icon URI and website are valid URIs (if specified)
+	if err := checkMetadataUri(m.IconUri, "icon uri"); err != nil {
+		return err
+	} else if err := checkMetadataUri(m.Website, "website"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m BondTokenMetadata) String() string {
+	output, err := json.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	return string(output)
+}
+
+func checkMetadataUri(uri, field string) sdk.Error {
+	if uri == "" {
+		return nil
+	} else if len(uri) > MaxMetadataUriLength {
+		return ErrInvalidBondTokenMetadata(DefaultCodespace, fmt.Sprintf(
+			"%s cannot be longer than %d characters", field, MaxMetadataUriLength))
+	}
+
+	parsed, err := url.ParseRequestURI(uri)
+	if err != nil || parsed.Scheme == "" {
+		return ErrInvalidBondTokenMetadata(DefaultCodespace,
+			fmt.Sprintf("%s '%s' is not a valid uri", field, uri))
+	}
+	return nil
+}
@@ -1,9 +1,12 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ixofoundation/ixo-blockchain/x/did"
 	"github.com/ixofoundation/ixo-blockchain/x/ixo"
+	"strconv"
 	"strings"
 )
 
@@ -15,6 +18,8 @@ const (
 	TypeMsgSwap               = "swap"
 	TypeMsgMakeOutcomePayment = "make_outcome_payment"
 	TypeMsgWithdrawShare      = "withdraw_share"
+	TypeMsgCommitOrder        = "commit_order"
+	TypeMsgRevealOrder        = "reveal_order"
 )
 
 var (
@@ -23,53 +28,80 @@ var (
 	_ ixo.IxoMsg = MsgBuy{}
 	_ ixo.IxoMsg = MsgSell{}
 	_ ixo.IxoMsg = MsgSwap{}
+	_ ixo.IxoMsg = MsgCommitOrder{}
+	_ ixo.IxoMsg = MsgRevealOrder{}
 )
 
 type MsgCreateBond struct {
-	BondDid                did.Did        `json:"bond_did" yaml:"bond_did"`
-	Token                  string         `json:"token" yaml:"token"`
-	Name                   string         `json:"name" yaml:"name"`
-	Description            string         `json:"description" yaml:"description"`
-	FunctionType           string         `json:"function_type" yaml:"function_type"`
-	FunctionParameters     FunctionParams `json:"function_parameters" yaml:"function_parameters"`
-	CreatorDid             did.Did        `json:"creator_did" yaml:"creator_did"`
-	ReserveTokens          []string       `json:"reserve_tokens" yaml:"reserve_tokens"`
-	TxFeePercentage        sdk.Dec        `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
-	ExitFeePercentage      sdk.Dec        `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
-	FeeAddress             sdk.AccAddress `json:"fee_address" yaml:"fee_address"`
-	MaxSupply              sdk.Coin       `json:"max_supply" yaml:"max_supply"`
-	OrderQuantityLimits    sdk.Coins      `json:"order_quantity_limits" yaml:"order_quantity_limits"`
-	SanityRate             sdk.Dec        `json:"sanity_rate" yaml:"sanity_rate"`
-	SanityMarginPercentage sdk.Dec        `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
-	AllowSells             bool           `json:"allow_sells" yaml:"allow_sells"`
-	BatchBlocks            sdk.Uint       `json:"batch_blocks" yaml:"batch_blocks"`
-	OutcomePayment         sdk.Coins      `json:"outcome_payment" yaml:"outcome_payment"`
+	BondDid                     did.Did           `json:"bond_did" yaml:"bond_did"`
+	Token                       string            `json:"token" yaml:"token"`
+	Name                        string            `json:"name" yaml:"name"`
+	Description                 string            `json:"description" yaml:"description"`
+	FunctionType                string            `json:"function_type" yaml:"function_type"`
+	FunctionParameters          FunctionParams    `json:"function_parameters" yaml:"function_parameters"`
+	CreatorDid                  did.Did           `json:"creator_did" yaml:"creator_did"`
+	ReserveTokens               []string          `json:"reserve_tokens" yaml:"reserve_tokens"`
+	TxFeePercentage             sdk.Dec           `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	ExitFeePercentage           sdk.Dec           `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
+	FeeAddress                  sdk.AccAddress    `json:"fee_address" yaml:"fee_address"`
+	MaxSupply                   sdk.Coin          `json:"max_supply" yaml:"max_supply"`
+	OrderQuantityLimits         sdk.Coins         `json:"order_quantity_limits" yaml:"order_quantity_limits"`
+	SanityRate                  sdk.Dec           `json:"sanity_rate" yaml:"sanity_rate"`
+	SanityMarginPercentage      sdk.Dec           `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	AllowSells                  bool              `json:"allow_sells" yaml:"allow_sells"`
+	BatchBlocks                 sdk.Uint          `json:"batch_blocks" yaml:"batch_blocks"`
+	OutcomePayment              sdk.Coins         `json:"outcome_payment" yaml:"outcome_payment"`
+	Metadata                    BondTokenMetadata `json:"metadata" yaml:"metadata"`
+	SettlementClaimBlocks       sdk.Uint          `json:"settlement_claim_blocks" yaml:"settlement_claim_blocks"`
+	UnclaimedReserveAddress     sdk.AccAddress    `json:"unclaimed_reserve_address" yaml:"unclaimed_reserve_address"`
+	RevealBlocks                sdk.Uint          `json:"reveal_blocks" yaml:"reveal_blocks"`
+	UnrevealedPenaltyPercentage sdk.Dec           `json:"unrevealed_penalty_percentage" yaml:"unrevealed_penalty_percentage"`
+	ReserveWeights              sdk.DecCoins      `json:"reserve_weights" yaml:"reserve_weights"`
+	InstantExecution            bool              `json:"instant_execution" yaml:"instant_execution"`
+	ImbalanceFeePercentage      sdk.Dec           `json:"imbalance_fee_percentage" yaml:"imbalance_fee_percentage"`
+	ImbalanceFeeExponent        sdk.Uint          `json:"imbalance_fee_exponent" yaml:"imbalance_fee_exponent"`
+	AccessPolicy                did.AccessPolicy  `json:"access_policy" yaml:"access_policy"`
 }
 
 func NewMsgCreateBond(token, name, description string, creatorDid did.Did,
 	functionType string, functionParameters FunctionParams, reserveTokens []string,
 	txFeePercentage, exitFeePercentage sdk.Dec, feeAddress sdk.AccAddress, maxSupply sdk.Coin,
 	orderQuantityLimits sdk.Coins, sanityRate, sanityMarginPercentage sdk.Dec,
-	allowSell bool, batchBlocks sdk.Uint, outcomePayment sdk.Coins, bondDid did.Did) MsgCreateBond {
+	allowSell bool, batchBlocks sdk.Uint, outcomePayment sdk.Coins, bondDid did.Did,
+	metadata BondTokenMetadata, settlementClaimBlocks sdk.Uint,
+	unclaimedReserveAddress sdk.AccAddress, revealBlocks sdk.Uint,
+	unrevealedPenaltyPercentage sdk.Dec, reserveWeights sdk.DecCoins,
+	instantExecution bool, imbalanceFeePercentage sdk.Dec,
+	imbalanceFeeExponent sdk.Uint, accessPolicy did.AccessPolicy) MsgCreateBond {
 	return MsgCreateBond{
-		BondDid:                bondDid,
-		Token:                  token,
-		Name:                   name,
-		Description:            description,
-		CreatorDid:             creatorDid,
-		FunctionType:           functionType,
-		FunctionParameters:     functionParameters,
-		ReserveTokens:          reserveTokens,
-		TxFeePercentage:        txFeePercentage,
-		ExitFeePercentage:      exitFeePercentage,
-		FeeAddress:             feeAddress,
-		MaxSupply:              maxSupply,
-		OrderQuantityLimits:    orderQuantityLimits,
-		SanityRate:             sanityRate,
-		SanityMarginPercentage: sanityMarginPercentage,
-		AllowSells:             allowSell,
-		BatchBlocks:            batchBlocks,
-		OutcomePayment:         outcomePayment,
+		BondDid:                     bondDid,
+		Token:                       token,
+		Name:                        name,
+		Description:                 description,
+		CreatorDid:                  creatorDid,
+		FunctionType:                functionType,
+		FunctionParameters:          functionParameters,
+		ReserveTokens:               reserveTokens,
+		TxFeePercentage:             txFeePercentage,
+		ExitFeePercentage:           exitFeePercentage,
+		FeeAddress:                  feeAddress,
+		MaxSupply:                   maxSupply,
+		OrderQuantityLimits:         orderQuantityLimits,
+		SanityRate:                  sanityRate,
+		SanityMarginPercentage:      sanityMarginPercentage,
+		AllowSells:                  allowSell,
+		BatchBlocks:                 batchBlocks,
+		OutcomePayment:              outcomePayment,
+		Metadata:                    metadata,
+		SettlementClaimBlocks:       settlementClaimBlocks,
+		UnclaimedReserveAddress:     unclaimedReserveAddress,
+		RevealBlocks:                revealBlocks,
+		UnrevealedPenaltyPercentage: unrevealedPenaltyPercentage,
+		ReserveWeights:              reserveWeights,
+		InstantExecution:            instantExecution,
+		ImbalanceFeePercentage:      imbalanceFeePercentage,
+		ImbalanceFeeExponent:        imbalanceFeeExponent,
+		AccessPolicy:                accessPolicy,
 	}
 }
 
@@ -110,6 +142,8 @@ func (msg MsgCreateBond) ValidateBasic() sdk.Error {
 		return err
 	} else if err = CheckNoOfReserveTokens(msg.ReserveTokens, msg.FunctionType); err != nil {
 		return err
+	} else if err = CheckReserveWeights(msg.ReserveWeights, msg.ReserveTokens, msg.FunctionType); err != nil {
+		return err
 	}
 
 	// Validate coins
@@ -138,10 +172,34 @@ func (msg MsgCreateBond) ValidateBasic() sdk.Error {
 		return ErrArgumentCannotBeNegative(DefaultCodespace, "TxFeePercentage")
 	} else if msg.ExitFeePercentage.IsNegative() {
 		return ErrArgumentCannotBeNegative(DefaultCodespace, "ExitFeePercentage")
-	} else if msg.TxFeePercentage.Add(msg.ExitFeePercentage).GTE(sdk.NewDec(100)) {
+	} else if msg.ImbalanceFeePercentage.IsNegative() {
+		return ErrArgumentCannotBeNegative(DefaultCodespace, "ImbalanceFeePercentage")
+	} else if msg.TxFeePercentage.Add(msg.ExitFeePercentage).Add(
+		msg.ImbalanceFeePercentage).GTE(sdk.NewDec(100)) {
 		return ErrFeesCannotBeOrExceed100Percent(DefaultCodespace)
 	}
 
+	// Check that the imbalance fee curve exponent is specified if and only if
+	// there is an imbalance fee, and that the imbalance fee is not combined
+	// with instant execution (since the imbalance is that of a batch)
+	if msg.ImbalanceFeePercentage.IsPositive() {
+		if msg.ImbalanceFeeExponent.IsZero() ||
+			msg.ImbalanceFeeExponent.GT(sdk.NewUint(MaxImbalanceFeeExponent)) {
+			return ErrArgumentMustBeBetween(DefaultCodespace, "ImbalanceFeeExponent",
+				"1", strconv.Itoa(MaxImbalanceFeeExponent))
+		} else if msg.InstantExecution {
+			return ErrArgumentsCannotBothBeSpecified(DefaultCodespace,
+				"ImbalanceFeePercentage", "InstantExecution")
+		}
+	} else if !msg.ImbalanceFeeExponent.IsZero() {
+		return ErrArgumentMustBePositive(DefaultCodespace, "ImbalanceFeePercentage")
+	}
+
+	// Validate access policy (optional)
+	if err := did.ValidateAccessPolicy(msg.AccessPolicy); err != nil {
+		return err
+	}
+
 	// Check that not zero
 	if msg.BatchBlocks.IsZero() {
 		return ErrArgumentMustBePositive(DefaultCodespace, "BatchBlocks")
@@ -151,6 +209,38 @@ func (msg MsgCreateBond) ValidateBasic() sdk.Error {
 
 	// Note: uniqueness of reserve tokens checked when parsing
 
+	// Validate bond token metadata (optional)
+	if err := msg.Metadata.Validate(); err != nil {
+		return err
+	}
+
+	// Check that settlement claim blocks and unclaimed reserve address are
+	// either both specified or both not specified (settlement claim deadline)
+	if !msg.SettlementClaimBlocks.IsZero() && msg.UnclaimedReserveAddress.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "UnclaimedReserveAddress")
+	} else if msg.SettlementClaimBlocks.IsZero() && !msg.UnclaimedReserveAddress.Empty() {
+		return ErrArgumentMustBePositive(DefaultCodespace, "SettlementClaimBlocks")
+	}
+
+	// Check that commit-reveal (reveal blocks) is only enabled for swapper
+	// function bonds, and that the unrevealed penalty is between 0 and 100
+	if !msg.RevealBlocks.IsZero() && msg.FunctionType != SwapperFunction {
+		return ErrFunctionNotAvailableForFunctionType(DefaultCodespace)
+	} else if msg.UnrevealedPenaltyPercentage.IsNegative() ||
+		msg.UnrevealedPenaltyPercentage.GT(sdk.NewDec(100)) {
+		return ErrArgumentMustBeBetween(DefaultCodespace,
+			"UnrevealedPenaltyPercentage", "0", "100")
+	}
+
+	// Check that instant execution is only enabled for swapper function bonds,
+	// and that it is not combined with commit-reveal (which requires batching)
+	if msg.InstantExecution && msg.FunctionType != SwapperFunction {
+		return ErrFunctionNotAvailableForFunctionType(DefaultCodespace)
+	} else if msg.InstantExecution && !msg.RevealBlocks.IsZero() {
+		return ErrArgumentsCannotBothBeSpecified(DefaultCodespace,
+			"InstantExecution", "RevealBlocks")
+	}
+
 	// Check that DIDs valid
 	if !did.IsValidDid(msg.BondDid) {
 		return did.ErrorInvalidDid(DefaultCodespace, "bond did is invalid")
@@ -182,11 +272,17 @@ type MsgEditBond struct {
 	OrderQuantityLimits    string  `json:"order_quantity_limits" yaml:"order_quantity_limits"`
 	SanityRate             string  `json:"sanity_rate" yaml:"sanity_rate"`
 	SanityMarginPercentage string  `json:"sanity_margin_percentage" yaml:"sanity_margin_percentage"`
+	DisplayDenom           string  `json:"display_denom" yaml:"display_denom"`
+	Exponent               string  `json:"exponent" yaml:"exponent"`
+	Symbol                 string  `json:"symbol" yaml:"symbol"`
+	IconUri                string  `json:"icon_uri" yaml:"icon_uri"`
+	Website                string  `json:"website" yaml:"website"`
 	EditorDid              did.Did `json:"editor_did" yaml:"editor_did"`
 }
 
 func NewMsgEditBond(token, name, description, orderQuantityLimits, sanityRate,
-	sanityMarginPercentage string, editorDid, bondDid did.Did) MsgEditBond {
+	sanityMarginPercentage, displayDenom, exponent, symbol, iconUri,
+	website string, editorDid, bondDid did.Did) MsgEditBond {
 	return MsgEditBond{
 		BondDid:                bondDid,
 		Token:                  token,
@@ -195,6 +291,11 @@ func NewMsgEditBond(token, name, description, orderQuantityLimits, sanityRate,
 		OrderQuantityLimits:    orderQuantityLimits,
 		SanityRate:             sanityRate,
 		SanityMarginPercentage: sanityMarginPercentage,
+		DisplayDenom:           displayDenom,
+		Exponent:               exponent,
+		Symbol:                 symbol,
+		IconUri:                iconUri,
+		Website:                website,
 		EditorDid:              editorDid,
 	}
 }
@@ -216,13 +317,14 @@ func (msg MsgEditBond) ValidateBasic() sdk.Error {
 	} else if strings.TrimSpace(msg.EditorDid) == "" {
 		return ErrArgumentCannotBeEmpty(DefaultCodespace, "EditorDid")
 	}
-	// Note: order quantity limits can be blank
+	// Note: order quantity limits and metadata fields can be blank
 
 	// Check that at least one editable was edited. Fields that will not
 	// be edited should be "DoNotModifyField", and not an empty string
 	inputList := []string{
 		msg.Name, msg.Description, msg.OrderQuantityLimits,
-		msg.SanityRate, msg.SanityMarginPercentage,
+		msg.SanityRate, msg.SanityMarginPercentage, msg.DisplayDenom,
+		msg.Exponent, msg.Symbol, msg.IconUri, msg.Website,
 	}
 	atLeaseOneEdit := false
 	for _, e := range inputList {
@@ -259,19 +361,23 @@ func (msg MsgEditBond) Route() string { return RouterKey }
 func (msg MsgEditBond) Type() string { return TypeMsgEditBond }
 
 type MsgBuy struct {
-	BuyerDid  did.Did   `json:"buyer_did" yaml:"buyer_did"`
-	Amount    sdk.Coin  `json:"amount" yaml:"amount"`
-	MaxPrices sdk.Coins `json:"max_prices" yaml:"max_prices"`
-	BondDid   did.Did   `json:"bond_did" yaml:"bond_did"`
+	BuyerDid         did.Did        `json:"buyer_did" yaml:"buyer_did"`
+	Amount           sdk.Coin       `json:"amount" yaml:"amount"`
+	MaxPrices        sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	BondDid          did.Did        `json:"bond_did" yaml:"bond_did"`
+	RecipientDid     did.Did        `json:"recipient_did" yaml:"recipient_did"`
+	RecipientAddress sdk.AccAddress `json:"recipient_address" yaml:"recipient_address"`
 }
 
 func NewMsgBuy(buyerDid did.Did, amount sdk.Coin, maxPrices sdk.Coins,
-	bondDid did.Did) MsgBuy {
+	bondDid, recipientDid did.Did, recipientAddress sdk.AccAddress) MsgBuy {
 	return MsgBuy{
-		BuyerDid:  buyerDid,
-		Amount:    amount,
-		MaxPrices: maxPrices,
-		BondDid:   bondDid,
+		BuyerDid:         buyerDid,
+		Amount:           amount,
+		MaxPrices:        maxPrices,
+		BondDid:          bondDid,
+		RecipientDid:     recipientDid,
+		RecipientAddress: recipientAddress,
 	}
 }
 
@@ -302,7 +408,8 @@ func (msg MsgBuy) ValidateBasic() sdk.Error {
 		return did.ErrorInvalidDid(DefaultCodespace, "buyer did is invalid")
 	}
 
-	return nil
+	// Check that recipient valid (if specified)
+	return checkRecipient(msg.RecipientDid, msg.RecipientAddress)
 }
 
 func (msg MsgBuy) GetSignBytes() []byte {
@@ -319,16 +426,23 @@ func (msg MsgBuy) Route() string { return RouterKey }
 func (msg MsgBuy) Type() string { return TypeMsgBuy }
 
 type MsgSell struct {
-	SellerDid did.Did  `json:"seller_did" yaml:"seller_did"`
-	Amount    sdk.Coin `json:"amount" yaml:"amount"`
-	BondDid   did.Did  `json:"bond_did" yaml:"bond_did"`
+	SellerDid        did.Did        `json:"seller_did" yaml:"seller_did"`
+	Amount           sdk.Coin       `json:"amount" yaml:"amount"`
+	BondDid          did.Did        `json:"bond_did" yaml:"bond_did"`
+	RecipientDid     did.Did        `json:"recipient_did" yaml:"recipient_did"`
+	RecipientAddress sdk.AccAddress `json:"recipient_address" yaml:"recipient_address"`
+	MinReturns       sdk.Coins      `json:"min_returns" yaml:"min_returns"`
 }
 
-func NewMsgSell(sellerDid did.Did, amount sdk.Coin, bondDid did.Did) MsgSell {
+func NewMsgSell(sellerDid did.Did, amount sdk.Coin, bondDid, recipientDid did.Did,
+	recipientAddress sdk.AccAddress, minReturns sdk.Coins) MsgSell {
 	return MsgSell{
-		SellerDid: sellerDid,
-		Amount:    amount,
-		BondDid:   bondDid,
+		SellerDid:        sellerDid,
+		Amount:           amount,
+		BondDid:          bondDid,
+		RecipientDid:     recipientDid,
+		RecipientAddress: recipientAddress,
+		MinReturns:       minReturns,
 	}
 }
 
@@ -347,6 +461,11 @@ func (msg MsgSell) ValidateBasic() sdk.Error {
 		return ErrArgumentMustBePositive(DefaultCodespace, "Amount")
 	}
 
+	// Check that min returns valid (if specified)
+	if !msg.MinReturns.Empty() && !msg.MinReturns.IsValid() {
+		return sdk.ErrInvalidCoins("min returns are invalid")
+	}
+
 	// Check that DIDs valid
 	if !did.IsValidDid(msg.BondDid) {
 		return did.ErrorInvalidDid(DefaultCodespace, "bond did is invalid")
@@ -354,7 +473,8 @@ func (msg MsgSell) ValidateBasic() sdk.Error {
 		return did.ErrorInvalidDid(DefaultCodespace, "seller did is invalid")
 	}
 
-	return nil
+	// Check that recipient valid (if specified)
+	return checkRecipient(msg.RecipientDid, msg.RecipientAddress)
 }
 
 func (msg MsgSell) GetSignBytes() []byte {
@@ -371,19 +491,26 @@ func (msg MsgSell) Route() string { return RouterKey }
 func (msg MsgSell) Type() string { return TypeMsgSell }
 
 type MsgSwap struct {
-	SwapperDid did.Did  `json:"swapper_did" yaml:"swapper_did"`
-	BondDid    did.Did  `json:"bond_did" yaml:"bond_did"`
-	From       sdk.Coin `json:"from" yaml:"from"`
-	ToToken    string   `json:"to_token" yaml:"to_token"`
+	SwapperDid       did.Did        `json:"swapper_did" yaml:"swapper_did"`
+	BondDid          did.Did        `json:"bond_did" yaml:"bond_did"`
+	From             sdk.Coin       `json:"from" yaml:"from"`
+	ToToken          string         `json:"to_token" yaml:"to_token"`
+	RecipientDid     did.Did        `json:"recipient_did" yaml:"recipient_did"`
+	RecipientAddress sdk.AccAddress `json:"recipient_address" yaml:"recipient_address"`
+	MinReturns       sdk.Coins      `json:"min_returns" yaml:"min_returns"`
 }
 
 func NewMsgSwap(swapperDid did.Did, from sdk.Coin, toToken string,
-	bondDid did.Did) MsgSwap {
+	bondDid, recipientDid did.Did, recipientAddress sdk.AccAddress,
+	minReturns sdk.Coins) MsgSwap {
 	return MsgSwap{
-		SwapperDid: swapperDid,
-		From:       from,
-		ToToken:    toToken,
-		BondDid:    bondDid,
+		SwapperDid:       swapperDid,
+		From:             from,
+		ToToken:          toToken,
+		BondDid:          bondDid,
+		RecipientDid:     recipientDid,
+		RecipientAddress: recipientAddress,
+		MinReturns:       minReturns,
 	}
 }
 
@@ -418,6 +545,16 @@ func (msg MsgSwap) ValidateBasic() sdk.Error {
 		return ErrArgumentMustBePositive(DefaultCodespace, "FromAmount")
 	}
 
+	// Check that min returns valid and only in to token (if specified)
+	if !msg.MinReturns.Empty() {
+		if !msg.MinReturns.IsValid() {
+			return sdk.ErrInvalidCoins("min returns are invalid")
+		} else if len(msg.MinReturns) != 1 || msg.MinReturns[0].Denom != msg.ToToken {
+			return ErrReserveDenomsMismatch(DefaultCodespace,
+				msg.MinReturns.String(), []string{msg.ToToken})
+		}
+	}
+
 	// Note: From denom and amount must be valid since sdk.Coin
 
 	// Check that DIDs valid
@@ -427,7 +564,8 @@ func (msg MsgSwap) ValidateBasic() sdk.Error {
 		return did.ErrorInvalidDid(DefaultCodespace, "swapper did is invalid")
 	}
 
-	return nil
+	// Check that recipient valid (if specified)
+	return checkRecipient(msg.RecipientDid, msg.RecipientAddress)
 }
 
 func (msg MsgSwap) GetSignBytes() []byte {
@@ -487,17 +625,30 @@ func (msg MsgMakeOutcomePayment) Route() string { return RouterKey }
 func (msg MsgMakeOutcomePayment) Type() string { return TypeMsgMakeOutcomePayment }
 
 type MsgWithdrawShare struct {
-	RecipientDid did.Did `json:"recipient_did" yaml:"recipient_did"`
-	BondDid      did.Did `json:"bond_did" yaml:"bond_did"`
+	RecipientDid  did.Did        `json:"recipient_did" yaml:"recipient_did"`
+	BondDid       did.Did        `json:"bond_did" yaml:"bond_did"`
+	Amount        sdk.Coin       `json:"amount" yaml:"amount"`
+	PayoutDid     did.Did        `json:"payout_did" yaml:"payout_did"`
+	PayoutAddress sdk.AccAddress `json:"payout_address" yaml:"payout_address"`
 }
 
-func NewMsgWithdrawShare(recipientDid, bondDid did.Did) MsgWithdrawShare {
+func NewMsgWithdrawShare(recipientDid, bondDid did.Did, amount sdk.Coin,
+	payoutDid did.Did, payoutAddress sdk.AccAddress) MsgWithdrawShare {
 	return MsgWithdrawShare{
-		RecipientDid: recipientDid,
-		BondDid:      bondDid,
+		RecipientDid:  recipientDid,
+		BondDid:       bondDid,
+		Amount:        amount,
+		PayoutDid:     payoutDid,
+		PayoutAddress: payoutAddress,
 	}
 }
 
+// WithdrawsAll returns true if no amount was specified, meaning that all of
+// the bond tokens owned by the recipient are to be withdrawn
+func (msg MsgWithdrawShare) WithdrawsAll() bool {
+	return msg.Amount.Denom == ""
+}
+
 func (msg MsgWithdrawShare) ValidateBasic() sdk.Error {
 	// Check if empty
 	if strings.TrimSpace(msg.RecipientDid) == "" {
@@ -513,6 +664,23 @@ func (msg MsgWithdrawShare) ValidateBasic() sdk.Error {
 		return did.ErrorInvalidDid(DefaultCodespace, "recipient did is invalid")
 	}
 
+	// Check that amount valid and non zero (if specified)
+	if !msg.WithdrawsAll() {
+		if !msg.Amount.IsValid() {
+			return sdk.ErrInvalidCoins("amount is invalid")
+		} else if msg.Amount.Amount.IsZero() {
+			return ErrArgumentMustBePositive(DefaultCodespace, "Amount")
+		}
+	}
+
+	// Check that payout recipient valid (if specified)
+	if strings.TrimSpace(msg.PayoutDid) != "" && !msg.PayoutAddress.Empty() {
+		return ErrArgumentsCannotBothBeSpecified(
+			DefaultCodespace, "PayoutDid", "PayoutAddress")
+	} else if strings.TrimSpace(msg.PayoutDid) != "" && !did.IsValidDid(msg.PayoutDid) {
+		return did.ErrorInvalidDid(DefaultCodespace, "payout did is invalid")
+	}
+
 	return nil
 }
 
@@ -528,3 +696,139 @@ func (msg MsgWithdrawShare) GetSigners() []sdk.AccAddress {
 func (msg MsgWithdrawShare) Route() string { return RouterKey }
 
 func (msg MsgWithdrawShare) Type() string { return TypeMsgWithdrawShare }
+
+type MsgCommitOrder struct {
+	SwapperDid did.Did  `json:"swapper_did" yaml:"swapper_did"`
+	BondDid    did.Did  `json:"bond_did" yaml:"bond_did"`
+	Escrow     sdk.Coin `json:"escrow" yaml:"escrow"`
+	CommitHash string   `json:"commit_hash" yaml:"commit_hash"`
+}
+
+func NewMsgCommitOrder(swapperDid did.Did, escrow sdk.Coin, commitHash string,
+	bondDid did.Did) MsgCommitOrder {
+	return MsgCommitOrder{
+		SwapperDid: swapperDid,
+		Escrow:     escrow,
+		CommitHash: commitHash,
+		BondDid:    bondDid,
+	}
+}
+
+func (msg MsgCommitOrder) ValidateBasic() sdk.Error {
+	// Check if empty
+	if strings.TrimSpace(msg.SwapperDid) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "SwapperDid")
+	} else if strings.TrimSpace(msg.BondDid) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "BondDid")
+	} else if strings.TrimSpace(msg.CommitHash) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "CommitHash")
+	}
+
+	// Validate escrow amount
+	if !msg.Escrow.IsValid() {
+		return sdk.ErrInvalidCoins("escrow amount is invalid")
+	}
+
+	// Check that non zero
+	if msg.Escrow.Amount.IsZero() {
+		return ErrArgumentMustBePositive(DefaultCodespace, "EscrowAmount")
+	}
+
+	// Check that commit hash is a hex-encoded SHA-256 hash
+	if hash, err := hex.DecodeString(msg.CommitHash); err != nil || len(hash) != sha256.Size {
+		return ErrOrderCommitInvalid(DefaultCodespace, "commit hash must be a hex-encoded SHA-256 hash")
+	}
+
+	// Check that DIDs valid
+	if !did.IsValidDid(msg.BondDid) {
+		return did.ErrorInvalidDid(DefaultCodespace, "bond did is invalid")
+	} else if !did.IsValidDid(msg.SwapperDid) {
+		return did.ErrorInvalidDid(DefaultCodespace, "swapper did is invalid")
+	}
+
+	return nil
+}
+
+func (msg MsgCommitOrder) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCommitOrder) GetSignerDid() did.Did { return msg.SwapperDid }
+func (msg MsgCommitOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgCommitOrder) Route() string { return RouterKey }
+
+func (msg MsgCommitOrder) Type() string { return TypeMsgCommitOrder }
+
+type MsgRevealOrder struct {
+	SwapperDid       did.Did        `json:"swapper_did" yaml:"swapper_did"`
+	BondDid          did.Did        `json:"bond_did" yaml:"bond_did"`
+	From             sdk.Coin       `json:"from" yaml:"from"`
+	ToToken          string         `json:"to_token" yaml:"to_token"`
+	RecipientDid     did.Did        `json:"recipient_did" yaml:"recipient_did"`
+	RecipientAddress sdk.AccAddress `json:"recipient_address" yaml:"recipient_address"`
+	Salt             string         `json:"salt" yaml:"salt"`
+}
+
+func NewMsgRevealOrder(swapperDid did.Did, from sdk.Coin, toToken string,
+	bondDid, recipientDid did.Did, recipientAddress sdk.AccAddress,
+	salt string) MsgRevealOrder {
+	return MsgRevealOrder{
+		SwapperDid:       swapperDid,
+		From:             from,
+		ToToken:          toToken,
+		BondDid:          bondDid,
+		RecipientDid:     recipientDid,
+		RecipientAddress: recipientAddress,
+		Salt:             salt,
+	}
+}
+
+func (msg MsgRevealOrder) ValidateBasic() sdk.Error {
+	// The revealed order has to be a valid swap order
+	swap := NewMsgSwap(msg.SwapperDid, msg.From, msg.ToToken, msg.BondDid,
+		msg.RecipientDid, msg.RecipientAddress, nil)
+	if err := swap.ValidateBasic(); err != nil {
+		return err
+	}
+
+	// Check that salt not empty
+	if strings.TrimSpace(msg.Salt) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Salt")
+	}
+
+	return nil
+}
+
+// GetCommitHash returns the hash that the revealed order is expected to have
+// been committed to using MsgCommitOrder
+func (msg MsgRevealOrder) GetCommitHash() string {
+	return GetSwapOrderCommitHash(msg.SwapperDid, msg.BondDid, msg.From,
+		msg.ToToken, msg.RecipientDid, msg.RecipientAddress, msg.Salt)
+}
+
+func (msg MsgRevealOrder) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRevealOrder) GetSignerDid() did.Did { return msg.SwapperDid }
+func (msg MsgRevealOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgRevealOrder) Route() string { return RouterKey }
+
+func (msg MsgRevealOrder) Type() string { return TypeMsgRevealOrder }
+
+func checkRecipient(recipientDid did.Did, recipientAddress sdk.AccAddress) sdk.Error {
+	// A recipient can be specified as a DID or as an address, but not both
+	if strings.TrimSpace(recipientDid) != "" && !recipientAddress.Empty() {
+		return ErrArgumentsCannotBothBeSpecified(
+			DefaultCodespace, "RecipientDid", "RecipientAddress")
+	} else if strings.TrimSpace(recipientDid) != "" && !did.IsValidDid(recipientDid) {
+		return did.ErrorInvalidDid(DefaultCodespace, "recipient did is invalid")
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
 	"strings"
 )
 
@@ -11,6 +12,12 @@ func (b QueryBonds) String() string {
 	return strings.Join(b[:], "\n")
 }
 
+type QueryBondMetadata struct {
+	Token    string            `json:"token" yaml:"token"`
+	BondDid  did.Did           `json:"bond_did" yaml:"bond_did"`
+	Metadata BondTokenMetadata `json:"metadata" yaml:"metadata"`
+}
+
 type QueryBuyPrice struct {
 	AdjustedSupply sdk.Coin  `json:"adjusted_supply" yaml:"asdjusted_supply"`
 	Prices         sdk.Coins `json:"prices" yaml:"prices"`
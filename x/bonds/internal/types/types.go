@@ -45,6 +45,33 @@ func CheckNoOfReserveTokens(resTokens []string, fnType string) sdk.Error {
 	return nil
 }
 
+func CheckReserveWeights(weights sdk.DecCoins, resTokens []string, fnType string) sdk.Error {
+	// Reserve weights are optional
+	if weights.Empty() {
+		return nil
+	}
+
+	// Swapper function reserves are not priced using the bond function
+	if fnType == SwapperFunction {
+		return ErrFunctionNotAvailableForFunctionType(DefaultCodespace)
+	}
+
+	// Check that weights are valid (sorted, positive) and that there is
+	// exactly one weight for each of the reserve tokens
+	if !weights.IsValid() {
+		return sdk.ErrInvalidCoins("reserve weights are invalid")
+	} else if len(weights) != len(resTokens) {
+		return ErrReserveDenomsMismatch(DefaultCodespace, weights.String(), resTokens)
+	}
+	for _, r := range resTokens {
+		if !weights.AmountOf(r).IsPositive() {
+			return ErrReserveDenomsMismatch(DefaultCodespace, weights.String(), resTokens)
+		}
+	}
+
+	return nil
+}
+
 func CheckCoinDenom(denom string) (err sdk.Error) {
 	coin, err2 := sdk.ParseCoin("0" + denom)
 	if err2 != nil {
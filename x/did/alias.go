@@ -12,20 +12,80 @@ const (
 	RouterKey    = types.RouterKey
 	StoreKey     = types.StoreKey
 
+	DefaultParamspace = types.DefaultParamspace
+
 	DefaultCodespace = types.DefaultCodespace
+	CodeAccessDenied = types.CodeAccessDenied
+
+	PrimaryKeyFragment = types.PrimaryKeyFragment
+	MaxMemoLength      = exported.MaxMemoLength
+
+	EventTypeEncryptedMemo   = types.EventTypeEncryptedMemo
+	AttributeKeySenderDid    = types.AttributeKeySenderDid
+	AttributeKeyRecipientDid = types.AttributeKeyRecipientDid
+
+	Ed25519VerificationKey2018   = exported.Ed25519VerificationKey2018
+	Secp256k1VerificationKey2019 = exported.Secp256k1VerificationKey2019
+	X25519KeyAgreementKey2019    = exported.X25519KeyAgreementKey2019
+
+	Ed25519KeyType   = exported.Ed25519KeyType
+	Secp256k1KeyType = exported.Secp256k1KeyType
+	Authentication   = exported.Authentication
+	AssertionMethod  = exported.AssertionMethod
+	KeyAgreement     = exported.KeyAgreement
+
+	FieldTypeString  = types.FieldTypeString
+	FieldTypeNumber  = types.FieldTypeNumber
+	FieldTypeBoolean = types.FieldTypeBoolean
+	FieldTypeObject  = types.FieldTypeObject
+	FieldTypeArray   = types.FieldTypeArray
 )
 
 type (
-	Keeper       = keeper.Keeper
-	GenesisState = types.GenesisState
+	Keeper           = keeper.Keeper
+	CredentialStatus = types.CredentialStatus
+	CredentialSchema = types.CredentialSchema
+	SchemaField      = types.SchemaField
+	GenesisState     = types.GenesisState
+	Params           = types.Params
+	TrustedIssuer    = types.TrustedIssuer
+	DidHooks         = types.DidHooks
+	DidDocVersion    = types.DidDocVersion
+	Recovery         = types.Recovery
 
 	Did           = exported.Did
 	DidCredential = exported.DidCredential
+	Claim         = exported.Claim
 	DidDoc        = exported.DidDoc
+	AccessPolicy  = exported.AccessPolicy
 	IxoDid        = exported.IxoDid
 
-	MsgAddDid        = types.MsgAddDid
-	MsgAddCredential = types.MsgAddCredential
+	VerificationMethod = exported.VerificationMethod
+	Service            = exported.Service
+	Delegate           = exported.Delegate
+	MultisigKey        = exported.MultisigKey
+	EncryptedMemo      = exported.EncryptedMemo
+	EncryptedMemoMsg   = exported.EncryptedMemoMsg
+
+	MsgAddDid              = types.MsgAddDid
+	MsgAddCredential       = types.MsgAddCredential
+	MsgRevokeCredential    = types.MsgRevokeCredential
+	MsgAddCredentialSchema = types.MsgAddCredentialSchema
+	MsgUpdateDidPubKey     = types.MsgUpdateDidPubKey
+	MsgDeactivateDid       = types.MsgDeactivateDid
+
+	MsgAddVerificationMethod    = types.MsgAddVerificationMethod
+	MsgRemoveVerificationMethod = types.MsgRemoveVerificationMethod
+	MsgAddService               = types.MsgAddService
+	MsgRemoveService            = types.MsgRemoveService
+	MsgGrantDelegation          = types.MsgGrantDelegation
+	MsgRevokeDelegation         = types.MsgRevokeDelegation
+	MsgSetMultisigKey           = types.MsgSetMultisigKey
+	MsgRemoveMultisigKey        = types.MsgRemoveMultisigKey
+	MsgSetGuardians             = types.MsgSetGuardians
+	MsgStartRecovery            = types.MsgStartRecovery
+	MsgApproveRecovery          = types.MsgApproveRecovery
+	MsgCancelRecovery           = types.MsgCancelRecovery
 )
 
 var (
@@ -34,15 +94,71 @@ var (
 	NewQuerier    = keeper.NewQuerier
 	RegisterCodec = types.RegisterCodec
 
+	NewMsgAddDid              = types.NewMsgAddDid
+	NewMsgAddCredential       = types.NewMsgAddCredential
+	NewMsgRevokeCredential    = types.NewMsgRevokeCredential
+	NewMsgAddSignedCredential = types.NewMsgAddSignedCredential
+	NewMsgAddCredentialSchema = types.NewMsgAddCredentialSchema
+	NewMsgUpdateDidPubKey     = types.NewMsgUpdateDidPubKey
+	NewMsgDeactivateDid       = types.NewMsgDeactivateDid
+	NewMultiDidHooks          = types.NewMultiDidHooks
+
+	NewMsgAddVerificationMethod    = types.NewMsgAddVerificationMethod
+	NewMsgRemoveVerificationMethod = types.NewMsgRemoveVerificationMethod
+	NewMsgAddService               = types.NewMsgAddService
+	NewMsgRemoveService            = types.NewMsgRemoveService
+	NewMsgGrantDelegation          = types.NewMsgGrantDelegation
+	NewMsgRevokeDelegation         = types.NewMsgRevokeDelegation
+	NewMsgSetMultisigKey           = types.NewMsgSetMultisigKey
+	NewMsgRemoveMultisigKey        = types.NewMsgRemoveMultisigKey
+	NewMsgSetGuardians             = types.NewMsgSetGuardians
+	NewMsgStartRecovery            = types.NewMsgStartRecovery
+	NewMsgApproveRecovery          = types.NewMsgApproveRecovery
+	NewMsgCancelRecovery           = types.NewMsgCancelRecovery
+
+	NewDidDocVersion    = types.NewDidDocVersion
 	NewGenesisState     = types.NewGenesisState
 	DefaultGenesisState = types.DefaultGenesisState
 	ValidateGenesis     = types.ValidateGenesis
 
-	VerifyKeyToAddr = exported.VerifyKeyToAddr
+	NewParams        = types.NewParams
+	DefaultParams    = types.DefaultParams
+	ValidateParams   = types.ValidateParams
+	NewTrustedIssuer = types.NewTrustedIssuer
+	ParamKeyTable    = types.ParamKeyTable
+
+	VerifyKeyToAddr  = exported.VerifyKeyToAddr
+	PubKeyToAddr     = exported.PubKeyToAddr
+	PubKeyFromBase58 = exported.PubKeyFromBase58
+
+	DidHDPath            = exported.DidHDPath
+	FromMnemonicAccount  = exported.FromMnemonicAccount
+	FromMnemonicWithPath = exported.FromMnemonicWithPath
+	NewAccessPolicy      = exported.NewAccessPolicy
 
-	IsValidDid      = types.IsValidDid
-	IsValidPubKey   = types.IsValidPubKey
-	UnmarshalIxoDid = types.UnmarshalIxoDid
+	NewVerificationMethod = exported.NewVerificationMethod
+	NewClaim              = exported.NewClaim
+	NewDidCredential      = exported.NewDidCredential
+	NewCredentialSchema   = types.NewCredentialSchema
+	NewSchemaField        = types.NewSchemaField
+	NewService            = exported.NewService
+	NewDelegate           = exported.NewDelegate
+	NewMultisigKey        = exported.NewMultisigKey
+	EncryptMemo           = exported.EncryptMemo
+	NewEncryptedMemoEvent = types.NewEncryptedMemoEvent
+
+	ValidateAccessPolicy       = types.ValidateAccessPolicy
+	ValidateVerificationMethod = types.ValidateVerificationMethod
+	ValidateService            = types.ValidateService
+	ValidateDelegate           = types.ValidateDelegate
+	ValidateMultisigKey        = types.ValidateMultisigKey
+	ValidateEncryptedMemo      = types.ValidateEncryptedMemo
+
+	IsValidDid          = types.IsValidDid
+	IsValidPubKey       = types.IsValidPubKey
+	IsValidKeyType      = types.IsValidKeyType
+	IsValidPubKeyOfType = types.IsValidPubKeyOfType
+	UnmarshalIxoDid     = types.UnmarshalIxoDid
 
 	// variable aliases
 	ModuleCdc = types.ModuleCdc
@@ -50,4 +166,13 @@ var (
 	ErrorInvalidDid        = types.ErrorInvalidDid
 	ErrorInvalidPubKey     = types.ErrorInvalidPubKey
 	ErrorDidPubKeyMismatch = types.ErrorDidPubKeyMismatch
+	ErrorAccessDenied      = types.ErrorAccessDenied
+	ErrorDidDeactivated    = types.ErrorDidDeactivated
+
+	ErrorInvalidVerificationMethod = types.ErrorInvalidVerificationMethod
+	ErrorInvalidService            = types.ErrorInvalidService
+	ErrorInvalidSchema             = types.ErrorInvalidSchema
+	ErrorInvalidDelegate           = types.ErrorInvalidDelegate
+	ErrorInvalidMultisigKey        = types.ErrorInvalidMultisigKey
+	ErrorInvalidEncryptedMemo      = types.ErrorInvalidEncryptedMemo
 )
@@ -1,29 +1,51 @@
 package did
 
 import (
-	"github.com/btcsuite/btcutil/base58"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
 	"github.com/ixofoundation/ixo-blockchain/x/ixo"
 	"github.com/tendermint/tendermint/crypto"
-	"github.com/tendermint/tendermint/crypto/ed25519"
 )
 
 func GetPubKeyGetter(keeper Keeper) ixo.PubKeyGetter {
 	return func(ctx sdk.Context, msg ixo.IxoMsg) (pubKey crypto.PubKey, res sdk.Result) {
 
 		// Get signer PubKey
-		var pubKeyEd25519 ed25519.PubKeyEd25519
 		switch msg := msg.(type) {
 		case MsgAddDid:
-			copy(pubKeyEd25519[:], base58.Decode(msg.PubKey))
+			return exported.PubKeyFromBase58(msg.KeyType, msg.PubKey), sdk.Result{}
+		case MsgUpdateDidPubKey, MsgDeactivateDid, MsgAddVerificationMethod, MsgRemoveVerificationMethod,
+			MsgGrantDelegation, MsgRevokeDelegation, MsgSetMultisigKey, MsgRemoveMultisigKey,
+			MsgSetGuardians, MsgCancelRecovery:
+			// Keys, delegations, multisig key sets and guardians can only be
+			// managed by the did itself (see NonDelegableMsgTypes), and only
+			// using its PubKey (or multisig key set), so that a compromised
+			// authentication key cannot be used to take over the did
+			didDoc, _ := keeper.GetDidDoc(ctx, msg.GetSignerDid())
+			if didDoc == nil {
+				return pubKey, sdk.ErrUnauthorized("Issuer did not found").Result()
+			} else if didDoc.IsDeactivated() {
+				return pubKey, sdk.ErrUnauthorized("Issuer did is deactivated").Result()
+			}
+			return ixo.NewKeyManagementDidPubKey(didDoc), sdk.Result{}
+		case MsgStartRecovery, MsgApproveRecovery:
+			// Recoveries are signed by the guardians themselves
+			didDoc, _ := keeper.GetDidDoc(ctx, msg.GetSignerDid())
+			if didDoc == nil {
+				return pubKey, sdk.ErrUnauthorized("Issuer did not found").Result()
+			} else if didDoc.IsDeactivated() {
+				return pubKey, sdk.ErrUnauthorized("Issuer did is deactivated").Result()
+			}
+			return ixo.NewDidPubKey(didDoc), sdk.Result{}
 		default:
 			// For the remaining messages, the did is the signer
 			didDoc, _ := keeper.GetDidDoc(ctx, msg.GetSignerDid())
 			if didDoc == nil {
 				return pubKey, sdk.ErrUnauthorized("Issuer did not found").Result()
+			} else if didDoc.IsDeactivated() {
+				return pubKey, sdk.ErrUnauthorized("Issuer did is deactivated").Result()
 			}
-			copy(pubKeyEd25519[:], base58.Decode(didDoc.GetPubKey()))
+			return ixo.NewDelegatedDidPubKey(ctx, keeper, didDoc, msg), sdk.Result{}
 		}
-		return pubKeyEd25519, sdk.Result{}
 	}
 }
@@ -0,0 +1,47 @@
+package did
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/keeper"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/ixo"
+)
+
+func TestPubKeyGetterKeyManagementMsgs(t *testing.T) {
+	ctx, k, cdc := keeper.CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := types.ValidDidDoc.GetDid()
+	getter := GetPubKeyGetter(k)
+
+	err := k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+	method := exported.NewVerificationMethod(did+"#key-2",
+		exported.Ed25519VerificationKey2018, "47mm6LCDAyJmqkbUbqGoZKZkBixjBgvDFRMwQRF9HWMU",
+		[]string{exported.Authentication})
+	err = k.AddVerificationMethod(ctx, did, method)
+	require.Nil(t, err)
+
+	// Ordinary messages can be signed using the authentication key
+	msg := types.NewMsgAddCredential("did:ixo:4XJLBfGtWSGKSz4BeRxdun",
+		[]string{"Credential"}, did, "2020-01-01", "")
+	pubKey, res := getter(ctx, msg)
+	require.True(t, res.IsOK())
+	require.Len(t, pubKey.(ixo.DidPubKey).AuthenticationKeys, 1)
+
+	// Key management messages can only be signed using the DID doc's PubKey
+	keyMsgs := []ixo.IxoMsg{
+		types.NewMsgUpdateDidPubKey(did, method.PublicKeyBase58, ""),
+		types.NewMsgDeactivateDid(did),
+	}
+	for _, keyMsg := range keyMsgs {
+		pubKey, res = getter(ctx, keyMsg)
+		require.True(t, res.IsOK())
+		require.Empty(t, pubKey.(ixo.DidPubKey).AuthenticationKeys)
+		require.Equal(t, types.ValidDidDoc.Address(), sdk.AccAddress(pubKey.Address()))
+	}
+}
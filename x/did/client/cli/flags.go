@@ -0,0 +1,26 @@
+package cli
+
+const (
+	FlagExpires  = "expires"
+	FlagSchemaId = "schema-id"
+	FlagClaim    = "claim"
+	FlagOffline  = "offline"
+	FlagKeyType  = "key-type"
+
+	FlagVersionId = "version-id"
+
+	FlagEncryptedMemo = "encrypted-memo"
+	FlagPage          = "page"
+	FlagLimit         = "limit"
+
+	FlagId        = "id"
+	FlagCredTypes = "types"
+	FlagChallenge = "challenge"
+	FlagDomain    = "domain"
+
+	FlagAccount    = "account"
+	FlagCount      = "count"
+	FlagHDPath     = "hd-path"
+	FlagLegacy     = "legacy"
+	FlagPassphrase = "passphrase"
+)
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/go-bip39"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/keeper"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// GetKeysCmd returns the commands that derive IxoDids from a BIP39 mnemonic.
+// These work offline, except for recover, which queries the chain.
+func GetKeysCmd(cdc *codec.Codec) *cobra.Command {
+	keysCmd := &cobra.Command{
+		Use:   "did-keys",
+		Short: "Derive, list and recover IxoDids from a BIP39 mnemonic",
+	}
+
+	keysCmd.AddCommand(
+		GetCmdDeriveDid(),
+		GetCmdListDerivedDids(),
+		GetCmdRecoverDids(cdc),
+	)
+
+	return keysCmd
+}
+
+// readMnemonic prompts for the mnemonic and, if requested, the BIP39
+// passphrase. Legacy mnemonics are not required to be valid BIP39 mnemonics.
+func readMnemonic(cmd *cobra.Command, legacy bool) (mnemonic, passphrase string, err error) {
+	buf := bufio.NewReader(cmd.InOrStdin())
+	mnemonic, err = input.GetString("Enter your bip39 mnemonic", buf)
+	if err != nil {
+		return "", "", err
+	} else if !legacy && !bip39.IsMnemonicValid(mnemonic) {
+		return "", "", fmt.Errorf("invalid mnemonic")
+	}
+
+	if viper.GetBool(FlagPassphrase) {
+		passphrase, err = input.GetString("Enter your bip39 passphrase", buf)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return mnemonic, passphrase, nil
+}
+
+func GetCmdDeriveDid() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "derive",
+		Short: "Derive an IxoDid from a BIP39 mnemonic",
+		Long: `Derive the IxoDid with the specified account index from a BIP39 mnemonic,
+using SLIP-0010 ed25519 derivation at the HD path m/44'/118'/<account>'/0'/0'.
+A different (fully hardened) HD path can be specified using --hd-path. With
+--legacy, the IxoDid is derived using the legacy derivation (i.e. the SHA-256
+hash of the mnemonic) instead. The mnemonic is read from STDIN.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			legacy := viper.GetBool(FlagLegacy)
+			mnemonic, passphrase, err := readMnemonic(cmd, legacy)
+			if err != nil {
+				return err
+			}
+
+			var ixoDid exported.IxoDid
+			if legacy {
+				ixoDid, err = exported.FromMnemonic(mnemonic)
+			} else if hdPath := viper.GetString(FlagHDPath); hdPath != "" {
+				ixoDid, err = exported.FromMnemonicWithPath(mnemonic, passphrase, hdPath)
+			} else {
+				ixoDid, err = exported.FromMnemonicAccount(mnemonic, passphrase, viper.GetUint32(FlagAccount))
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(ixoDid.String())
+			return nil
+		},
+	}
+	cmd.Flags().Uint32(FlagAccount, 0, "Account index of the IxoDid")
+	cmd.Flags().String(FlagHDPath, "", "HD path of the IxoDid, overriding the account index")
+	cmd.Flags().Bool(FlagLegacy, false, "Use the legacy derivation")
+	cmd.Flags().Bool(FlagPassphrase, false, "Prompt for a BIP39 passphrase")
+	return cmd
+}
+
+func GetCmdListDerivedDids() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the DIDs derived from a BIP39 mnemonic",
+		Long: `List the DIDs (without their keys) derived from a BIP39 mnemonic for the
+first --count account indices. The mnemonic is read from STDIN.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mnemonic, passphrase, err := readMnemonic(cmd, false)
+			if err != nil {
+				return err
+			}
+
+			for account := uint32(0); account < viper.GetUint32(FlagCount); account++ {
+				ixoDid, err := exported.FromMnemonicAccount(mnemonic, passphrase, account)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%d\t%s\t%s\n", account, exported.DidHDPath(account), ixoDid.Did)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Uint32(FlagCount, 5, "Number of account indices to list")
+	cmd.Flags().Bool(FlagPassphrase, false, "Prompt for a BIP39 passphrase")
+	return cmd
+}
+
+func GetCmdRecoverDids(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recover",
+		Short: "Recover the IxoDids derived from a BIP39 mnemonic that exist on-chain",
+		Long: `Recover the IxoDids derived from a BIP39 mnemonic that have been added to the
+chain, by checking the first --count account indices as well as the legacy
+derivation. The mnemonic is read from STDIN.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			mnemonic, passphrase, err := readMnemonic(cmd, true)
+			if err != nil {
+				return err
+			}
+
+			var candidates []exported.IxoDid
+			if bip39.IsMnemonicValid(mnemonic) {
+				for account := uint32(0); account < viper.GetUint32(FlagCount); account++ {
+					ixoDid, err := exported.FromMnemonicAccount(mnemonic, passphrase, account)
+					if err != nil {
+						return err
+					}
+					candidates = append(candidates, ixoDid)
+				}
+			}
+			legacyDid, err := exported.FromMnemonic(mnemonic)
+			if err != nil {
+				return err
+			}
+			candidates = append(candidates, legacyDid)
+
+			var recovered []exported.IxoDid
+			for _, ixoDid := range candidates {
+				res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s",
+					types.QuerierRoute, keeper.QueryDidDoc, ixoDid.Did), nil)
+				if err == nil && len(res) > 0 {
+					recovered = append(recovered, ixoDid)
+				}
+			}
+
+			if len(recovered) == 0 {
+				return fmt.Errorf("no dids derived from the mnemonic were found")
+			}
+
+			output, err := cdc.MarshalJSONIndent(recovered, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+	cmd.Flags().Uint32(FlagCount, 5, "Number of account indices to check")
+	cmd.Flags().Bool(FlagPassphrase, false, "Prompt for a BIP39 passphrase")
+	return cmd
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/keeper"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/ixo"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// EncryptMemoForDid encrypts the memo from the sender for the first
+// keyAgreement key published in the recipient DID's DID doc. It is used by
+// the commands of other modules whose messages carry an encrypted memo.
+func EncryptMemoForDid(cliCtx context.CLIContext, sender exported.IxoDid,
+	recipientDid exported.Did, memo string) (exported.EncryptedMemo, error) {
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s",
+		types.QuerierRoute, keeper.QueryDidDoc, recipientDid), nil)
+	if err != nil {
+		return exported.EncryptedMemo{}, err
+	}
+
+	var didDoc types.BaseDidDoc
+	if err := cliCtx.Codec.UnmarshalJSON(res, &didDoc); err != nil {
+		return exported.EncryptedMemo{}, err
+	}
+
+	pubKeys := didDoc.GetKeyAgreementPubKeys()
+	if len(pubKeys) == 0 {
+		return exported.EncryptedMemo{}, fmt.Errorf(
+			"did %s has not published an encryption key (keyAgreement method)", recipientDid)
+	}
+
+	return exported.EncryptMemo(sender, recipientDid, pubKeys[0], memo)
+}
+
+// DecryptedMemo is an encrypted memo received by a DID, as output by the
+// get-encrypted-memos command
+type DecryptedMemo struct {
+	TxHash    string       `json:"txhash" yaml:"txhash"`
+	Height    int64        `json:"height" yaml:"height"`
+	Timestamp string       `json:"timestamp" yaml:"timestamp"`
+	SenderDid exported.Did `json:"sender_did" yaml:"sender_did"`
+	Memo      string       `json:"memo" yaml:"memo"`
+}
+
+func GetCmdEncryptedMemos(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-encrypted-memos [ixo-did]",
+		Short: "Query and decrypt the encrypted memos received by an IxoDid",
+		Long: `Search for the transactions carrying encrypted memos addressed to the IxoDid
+and decrypt the memos using the IxoDid's encryption private key. Memos that
+cannot be decrypted (e.g. because they were encrypted for a different key) are
+skipped.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			ixoDid, err := types.UnmarshalIxoDid(args[0])
+			if err != nil {
+				return err
+			}
+
+			events := []string{fmt.Sprintf("%s.%s='%s'", types.EventTypeEncryptedMemo,
+				types.AttributeKeyRecipientDid, ixoDid.Did)}
+			searchResult, err := utils.QueryTxsByEvents(cliCtx, events,
+				viper.GetInt(FlagPage), viper.GetInt(FlagLimit))
+			if err != nil {
+				return err
+			}
+
+			memos := []DecryptedMemo{}
+			for _, txResponse := range searchResult.Txs {
+				if txResponse.Tx == nil {
+					continue
+				}
+				for _, msg := range txResponse.Tx.GetMsgs() {
+					memoMsg, ok := msg.(exported.EncryptedMemoMsg)
+					if !ok || memoMsg.GetEncryptedMemo().RecipientDid != ixoDid.Did {
+						continue
+					}
+					memo, err := memoMsg.GetEncryptedMemo().Decrypt(ixoDid)
+					if err != nil {
+						continue
+					}
+
+					var senderDid exported.Did
+					if ixoMsg, ok := msg.(ixo.IxoMsg); ok {
+						senderDid = ixoMsg.GetSignerDid()
+					}
+					memos = append(memos, DecryptedMemo{
+						TxHash:    txResponse.TxHash,
+						Height:    txResponse.Height,
+						Timestamp: txResponse.Timestamp,
+						SenderDid: senderDid,
+						Memo:      memo,
+					})
+				}
+			}
+
+			output, err := cdc.MarshalJSONIndent(memos, "", "  ")
+			if err != nil {
+				return errors.Wrap(err, "failed to marshal memos")
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+	cmd.Flags().Int(FlagPage, 1, "Query a specific page of paginated results")
+	cmd.Flags().Int(FlagLimit, 30, "Query number of transactions results per page returned")
+	return cmd
+}
@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/keeper"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/ixo"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+func GetCmdSetMultisigKey(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-multisig-key [threshold] [pub-keys] [ixo-did]",
+		Short: "Back an IxoDid by a threshold multisig key set",
+		Long: `Back an IxoDid by an M-of-N threshold multisig key set, given the threshold
+and the comma-separated base58-encoded Ed25519 pubKeys. Any coins held by the
+DID's address are migrated to the address of the multisig key set.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			threshold, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			pubKeys := strings.Split(args[1], ",")
+
+			ixoDid, err := types.UnmarshalIxoDid(args[2])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			multisigKey := exported.NewMultisigKey(uint(threshold), pubKeys)
+			msg := types.NewMsgSetMultisigKey(ixoDid.Did, multisigKey)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+}
+
+func GetCmdRemoveMultisigKey(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove-multisig-key [did]",
+		Short: "Generate a tx that removes the multisig key set of a DID",
+		Long: `Generate an unsigned tx that removes the multisig key set of a DID. The tx
+has to be signed using sign-multisig and multisign, and can then be broadcast.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			msg := types.NewMsgRemoveMultisigKey(args[0])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+// queryMultisigDidDoc returns the DID doc of the tx's signer DID
+func queryMultisigDidDoc(cliCtx context.CLIContext, stdTx auth.StdTx) (types.BaseDidDoc, error) {
+	if len(stdTx.GetMsgs()) != 1 {
+		return types.BaseDidDoc{}, errors.New("tx should have exactly one message")
+	}
+	msg, ok := stdTx.GetMsgs()[0].(ixo.IxoMsg)
+	if !ok {
+		return types.BaseDidDoc{}, errors.New("tx message should be an ixo message")
+	}
+
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+		keeper.QueryDidDoc, msg.GetSignerDid()), nil)
+	if err != nil {
+		return types.BaseDidDoc{}, err
+	}
+
+	var didDoc types.BaseDidDoc
+	if err := cliCtx.Codec.UnmarshalJSON(res, &didDoc); err != nil {
+		return types.BaseDidDoc{}, err
+	}
+
+	if !didDoc.IsMultisig() {
+		return types.BaseDidDoc{}, errors.New("signer did does not have a multisig key set")
+	}
+	return didDoc, nil
+}
+
+func GetCmdSignMultisig(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-multisig [tx-file] [ixo-did]",
+		Short: "Sign a tx as one of the keys of a multisig DID",
+		Long: `Sign a tx on behalf of a multisig DID using the IxoDid's sign key, which has
+to be one of the keys in the DID's multisig key set. The signature is written to
+STDOUT, to be merged with the other signatures using multisign.
+
+With --offline, the account number and sequence of the DID's account have to be
+specified using --account-number and --sequence, so that the tx can be signed
+without access to a node.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI()
+
+			stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			ixoDid, err := types.UnmarshalIxoDid(args[1])
+			if err != nil {
+				return err
+			}
+
+			if !viper.GetBool(FlagOffline) {
+				didDoc, err := queryMultisigDidDoc(cliCtx, stdTx)
+				if err != nil {
+					return err
+				}
+
+				accNum, seq, err := auth.NewAccountRetriever(cliCtx).
+					GetAccountNumberSequence(didDoc.Address())
+				if err != nil {
+					return err
+				}
+				txBldr = txBldr.WithAccountNumber(accNum).WithSequence(seq)
+			}
+
+			signMsg := auth.StdSignMsg{
+				ChainID:       txBldr.ChainID(),
+				AccountNumber: txBldr.AccountNumber(),
+				Sequence:      txBldr.Sequence(),
+				Fee:           stdTx.Fee,
+				Msgs:          stdTx.GetMsgs(),
+				Memo:          stdTx.GetMemo(),
+			}
+
+			sig, err := ixo.MakeDidSignature(signMsg, ixoDid)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(sig, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+	cmd.Flags().Bool(FlagOffline, false, "Sign without querying the DID's account number and sequence")
+	return cmd
+}
+
+func GetCmdMultisign(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "multisign [tx-file] [signature-files...]",
+		Short: "Merge the signatures of a multisig DID's keys into a signed tx",
+		Long: `Merge the signatures produced by sign-multisig into a multisignature of the
+tx's multisig signer DID. The signed tx is written to STDOUT and can be broadcast
+using the broadcast command.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			didDoc, err := queryMultisigDidDoc(cliCtx, stdTx)
+			if err != nil {
+				return err
+			}
+			multisigPubKey := didDoc.GetMultisigKey().PubKey()
+
+			multiSig := multisig.NewMultisig(len(multisigPubKey.PubKeys))
+			for _, sigFile := range args[1:] {
+				bz, err := ioutil.ReadFile(sigFile)
+				if err != nil {
+					return err
+				}
+
+				var sig auth.StdSignature
+				if err := cdc.UnmarshalJSON(bz, &sig); err != nil {
+					return err
+				}
+
+				err = multiSig.AddSignatureFromPubKey(sig.Signature, sig.PubKey, multisigPubKey.PubKeys)
+				if err != nil {
+					return err
+				}
+			}
+
+			if uint(multiSig.BitArray.NumTrueBitsBefore(multiSig.BitArray.Size())) < didDoc.GetMultisigKey().Threshold {
+				return errors.New("not enough signatures to reach the multisig threshold")
+			}
+
+			sig := auth.StdSignature{PubKey: multisigPubKey, Signature: multiSig.Marshal()}
+			signedTx := auth.NewStdTx(stdTx.GetMsgs(), stdTx.Fee, []auth.StdSignature{sig}, stdTx.GetMemo())
+
+			output, err := cdc.MarshalJSONIndent(signedTx, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
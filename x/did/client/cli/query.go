@@ -1,31 +1,40 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
 	"github.com/ixofoundation/ixo-blockchain/x/did/internal/keeper"
 	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"time"
 )
 
 func GetCmdAddressFromBase58Pubkey() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "get-address-from-pubkey [base-58-encoded-pubkey]",
-		Short: "Get the address for a base-58 encoded ed25519 public key",
+		Short: "Get the address for a base-58 encoded ed25519 (or secp256k1) public key",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !types.IsValidPubKey(args[0]) {
+			keyType := viper.GetString(FlagKeyType)
+			if !types.IsValidKeyType(keyType) {
+				return errors.New("input is not a valid key type")
+			} else if !types.IsValidPubKeyOfType(keyType, args[0]) {
 				return errors.New("input is not a valid base-58 encoded pubKey")
 			}
 
-			accAddress := exported.VerifyKeyToAddr(args[0])
+			accAddress := exported.PubKeyToAddr(keyType, args[0])
 			fmt.Println(accAddress.String())
 			return nil
 		},
 	}
+	cmd.Flags().String(FlagKeyType, "", "Key type of the pubKey (ed25519 or secp256k1), ed25519 by default")
+	return cmd
 }
 
 func GetCmdAddressFromDid(cdc *codec.Codec) *cobra.Command {
@@ -62,6 +71,36 @@ func GetCmdAddressFromDid(cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+func GetCmdDidFromAddress(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-did-from-address [address]",
+		Short: "Query the DID whose current address is the address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+				keeper.QueryAddress, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			if len(res) == 0 {
+				return errors.New("response bytes are empty")
+			}
+
+			var did exported.Did
+			err = json.Unmarshal(res, &did)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(did)
+			return nil
+		},
+	}
+}
+
 func GetCmdDidDoc(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get-did-doc [did]",
@@ -100,6 +139,41 @@ func GetCmdDidDoc(cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+func GetCmdDidDocHistory(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-did-doc-history [did]",
+		Short: "Query the history of versions of a DID's DidDoc",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+				keeper.QueryDidDocHistory, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			if len(res) == 0 {
+				return errors.New("response bytes are empty")
+			}
+
+			var history []types.DidDocVersion
+			err = cdc.UnmarshalJSON(res, &history)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(history, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
 func GetCmdAllDids(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get-all-dids",
@@ -159,3 +233,267 @@ func GetCmdAllDidDocs(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func GetCmdResolveDid(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resolve-did [did]",
+		Short: "Resolve a DID to a W3C DID Core document with resolution metadata",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			did := exported.Did(args[0])
+			if !types.IsValidDid(did) {
+				return errors.New("input is not a valid did")
+			}
+
+			// A past version of the DID doc is resolved from its history
+			var result types.DidResolutionResult
+			if versionId := viper.GetString(FlagVersionId); versionId != "" {
+				res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s", types.QuerierRoute,
+					keeper.QueryDidDocVersion, did, versionId), nil)
+				if err != nil {
+					return err
+				}
+
+				var version types.DidDocVersion
+				err = cdc.UnmarshalJSON(res, &version)
+				if err != nil {
+					return err
+				}
+				result = types.NewDidDocVersionResolutionResult(version)
+			} else {
+				res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+					keeper.QueryDidDoc, did), nil)
+				if err != nil {
+					return err
+				}
+
+				if len(res) == 0 {
+					return errors.New("response bytes are empty")
+				}
+
+				var didDoc types.BaseDidDoc
+				err = cdc.UnmarshalJSON(res, &didDoc)
+				if err != nil {
+					return err
+				}
+				result = types.NewDidResolutionResult(didDoc)
+			}
+
+			output, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+	cmd.Flags().String(FlagVersionId, "", "Resolve a past version of the DID doc")
+	return cmd
+}
+
+func GetCmdCredentialStatus(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get-credential-status [did] [credential-id] [issuer-did]",
+		Short: "Query whether a credential from an issuer on a DID is valid (at a height)",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// The status at a past height is evaluated using that block's time
+			var blockTime time.Time
+			height := viper.GetInt64(flags.FlagHeight)
+			if height != 0 {
+				node, err := cliCtx.GetNode()
+				if err != nil {
+					return err
+				}
+				block, err := node.Block(&height)
+				if err != nil {
+					return err
+				}
+				blockTime = block.Block.Time
+			}
+
+			params := types.NewQueryCredentialStatusParams(
+				args[0], args[1], args[2], height, blockTime)
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute,
+				keeper.QueryCredentialStatus), bz)
+			if err != nil {
+				return err
+			}
+
+			var status types.CredentialStatus
+			err = cdc.UnmarshalJSON(res, &status)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(status, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	// The --height flag (defaulting to the latest height) is added to all query
+	// commands by flags.GetCommands
+	return cmd
+}
+
+func GetCmdCredentials(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-credentials [did]",
+		Short: "Query all credentials (including revoked ones) of a DID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+				keeper.QueryCredentials, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var credentials []exported.DidCredential
+			err = cdc.UnmarshalJSON(res, &credentials)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(credentials, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func GetCmdCredentialSchema(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-credential-schema [schema-id]",
+		Short: "Query a credential schema",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+				keeper.QueryCredentialSchema, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var schema types.CredentialSchema
+			err = cdc.UnmarshalJSON(res, &schema)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(schema, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func GetCmdAllCredentialSchemas(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-all-credential-schemas",
+		Short: "Query all credential schemas",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute,
+				keeper.QueryAllCredentialSchemas), nil)
+			if err != nil {
+				return err
+			}
+
+			var schemas []types.CredentialSchema
+			err = cdc.UnmarshalJSON(res, &schemas)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(schemas, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func GetParamsRequestHandler(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Query params",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute,
+				keeper.QueryParams), nil)
+			if err != nil {
+				return err
+			}
+
+			var params types.Params
+			if err := cdc.UnmarshalJSON(bz, &params); err != nil {
+				return err
+			}
+
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+}
+
+func GetCmdTrustedIssuers(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-trusted-issuers [cred-type]",
+		Short: "Query the DIDs trusted to issue credentials of a type",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+				keeper.QueryTrustedIssuers, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var issuers []exported.Did
+			err = cdc.UnmarshalJSON(res, &issuers)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(issuers, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/keeper"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/ixo"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func GetCmdSetGuardians(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-guardians [threshold] [guardian-dids] [recovery-delay] [ixo-did]",
+		Short: "Set the guardians that can recover an IxoDid",
+		Long: `Set the guardians of an IxoDid, given the threshold of guardians required to
+recover the DID, the comma-separated guardian DIDs, and the recovery delay (e.g.
+72h) during which the DID can still cancel an approved recovery. A threshold of
+0 and an empty list of guardian DIDs ("") removes the DID's guardians.`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			threshold, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			var guardianDids []exported.Did
+			if args[1] != "" {
+				guardianDids = strings.Split(args[1], ",")
+			}
+
+			recoveryDelay, err := time.ParseDuration(args[2])
+			if err != nil {
+				return err
+			}
+
+			ixoDid, err := types.UnmarshalIxoDid(args[3])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			guardians := exported.NewGuardians(uint(threshold), guardianDids, recoveryDelay)
+			msg := types.NewMsgSetGuardians(ixoDid.Did, guardians)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+}
+
+func GetCmdStartRecovery(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "start-recovery [did] [new-pub-key] [guardian-ixo-did]",
+		Short: "Start the recovery of a DID as one of its guardians",
+		Long: `Start the recovery of a DID that will install the new PubKey, signed by one
+of the DID's guardians. Starting the recovery counts as the guardian's approval.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			guardianDid, err := types.UnmarshalIxoDid(args[2])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(guardianDid.Address())
+
+			keyType := viper.GetString(FlagKeyType)
+
+			msg := types.NewMsgStartRecovery(args[0], guardianDid.Did, args[1], keyType)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, guardianDid)
+		},
+	}
+	cmd.Flags().String(FlagKeyType, "", "Key type of the new PubKey (ed25519 or secp256k1), ed25519 by default")
+	return cmd
+}
+
+func GetCmdApproveRecovery(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "approve-recovery [did] [new-pub-key] [guardian-ixo-did]",
+		Short: "Approve the recovery of a DID as one of its guardians",
+		Long: `Approve the in-progress recovery of a DID as one of its guardians. The new
+PubKey has to match the PubKey that the recovery installs.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			guardianDid, err := types.UnmarshalIxoDid(args[2])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(guardianDid.Address())
+
+			msg := types.NewMsgApproveRecovery(args[0], guardianDid.Did, args[1])
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, guardianDid)
+		},
+	}
+}
+
+func GetCmdCancelRecovery(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel-recovery [ixo-did]",
+		Short: "Cancel the in-progress recovery of an IxoDid, signed using its current PubKey",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ixoDid, err := types.UnmarshalIxoDid(args[0])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			msg := types.NewMsgCancelRecovery(ixoDid.Did)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+}
+
+func GetCmdRecovery(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-recovery [did]",
+		Short: "Query the in-progress recovery of a DID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+				keeper.QueryRecovery, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			if len(res) == 0 {
+				return errors.New("response bytes are empty")
+			}
+
+			var recovery types.Recovery
+			err = cdc.UnmarshalJSON(res, &recovery)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(recovery, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
@@ -1,10 +1,15 @@
 package cli
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
 	"github.com/ixofoundation/ixo-blockchain/x/ixo"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -26,34 +31,326 @@ func GetCmdAddDidDoc(cdc *codec.Codec) *cobra.Command {
 			cliCtx := context.NewCLIContext().WithCodec(cdc).
 				WithFromAddress(ixoDid.Address())
 
-			msg := types.NewMsgAddDid(ixoDid.Did, ixoDid.VerifyKey)
+			msg := types.NewMsgAddDid(ixoDid.Did, ixoDid.VerifyKey, ixoDid.KeyType)
 			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
 		},
 	}
 }
 
 func GetCmdAddCredential(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-credential [did] [cred-types] [signer-did-doc]",
+		Short: "Add a new Credential (with comma-separated types) for a Did by the signer",
+		Long: `Add a new Credential for a Did by the signer. If a schema ID and a claim
+body are specified, the claim is typed and the credential is signed by the
+signer, otherwise the claim is a KYC validation claim.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			didAddr := args[0]
+			credTypes := strings.Split(args[1], ",")
+
+			ixoDid, err := types.UnmarshalIxoDid(args[2])
+			if err != nil {
+				return err
+			}
+
+			t := time.Now()
+			issued := t.Format(time.RFC3339)
+			expires := viper.GetString(FlagExpires)
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			schemaId := viper.GetString(FlagSchemaId)
+			if schemaId == "" {
+				msg := types.NewMsgAddCredential(didAddr, credTypes,
+					ixoDid.Did, issued, expires)
+				return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+			}
+
+			// Typed claims are signed by the issuer so that the credential
+			// can be verified off-chain
+			claim := exported.NewClaim(didAddr, schemaId, viper.GetString(FlagClaim))
+			credential := exported.NewDidCredential(
+				credTypes, ixoDid.Did, issued, expires, claim)
+			sig, err := ixoDid.SignMessage(credential.SignBytes())
+			if err != nil {
+				return err
+			}
+			credential.Signature = base64.StdEncoding.EncodeToString(sig)
+
+			msg := types.NewMsgAddSignedCredential(credential)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+
+	cmd.Flags().String(FlagExpires, "", "Expiry time of the credential (RFC3339), if any")
+	cmd.Flags().String(FlagSchemaId, "", "ID of the credential schema of a typed claim, if any")
+	cmd.Flags().String(FlagClaim, "", "JSON body of a typed claim, conforming to the schema")
+	return cmd
+}
+
+func GetCmdAddCredentialSchema(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "add-kyc-credential [did] [signer-did-doc]",
-		Short: "Add a new KYC Credential for a Did by the signer",
+		Use:   "add-credential-schema [schema-json] [creator-did-doc]",
+		Short: "Register a credential schema (with id, name, and fields) for typed claims",
 		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var schema types.CredentialSchema
+			err := json.Unmarshal([]byte(args[0]), &schema)
+			if err != nil {
+				return err
+			}
+
+			ixoDid, err := types.UnmarshalIxoDid(args[1])
+			if err != nil {
+				return err
+			}
+			schema.Creator = ixoDid.Did
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			msg := types.NewMsgAddCredentialSchema(schema)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+}
+
+func GetCmdRevokeCredential(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-credential [did] [credential-id] [issuer-did-doc]",
+		Short: "Revoke a credential for a Did, signed by the credential's issuer",
+		Args:  cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			didAddr := args[0]
+			credentialId := args[1]
+
+			ixoDid, err := types.UnmarshalIxoDid(args[2])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			msg := types.NewMsgRevokeCredential(didAddr, credentialId, ixoDid.Did)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+}
+
+func GetCmdUpdateDidPubKey(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-did-pub-key [new-pub-key] [ixo-did]",
+		Short: "Rotate the PubKey of an IxoDid, signed using the current PubKey",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			newPubKey := args[0]
 
 			ixoDid, err := types.UnmarshalIxoDid(args[1])
 			if err != nil {
 				return err
 			}
 
-			t := time.Now()
-			issued := t.Format(time.RFC3339)
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			keyType := viper.GetString(FlagKeyType)
+
+			msg := types.NewMsgUpdateDidPubKey(ixoDid.Did, newPubKey, keyType)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+	cmd.Flags().String(FlagKeyType, "", "Key type of the new PubKey (ed25519 or secp256k1), ed25519 by default")
+	return cmd
+}
+
+func GetCmdDeactivateDid(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deactivate-did [ixo-did]",
+		Short: "Permanently deactivate an IxoDid",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ixoDid, err := types.UnmarshalIxoDid(args[0])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			msg := types.NewMsgDeactivateDid(ixoDid.Did)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+}
+
+func GetCmdAddVerificationMethod(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add-verification-method [fragment] [type] [pub-key] [relationships] [ixo-did]",
+		Short: "Add a verification method (with comma-separated relationships) to an IxoDid",
+		Args:  cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fragment := args[0]
+			methodType := args[1]
+			pubKey := args[2]
+			relationships := strings.Split(args[3], ",")
+
+			ixoDid, err := types.UnmarshalIxoDid(args[4])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
 
-			credTypes := []string{"Credential", "ProofOfKYC"}
+			method := exported.NewVerificationMethod(
+				ixoDid.Did+"#"+fragment, methodType, pubKey, relationships)
+			msg := types.NewMsgAddVerificationMethod(ixoDid.Did, method)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+}
+
+func GetCmdAddKeyAgreementMethod(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add-key-agreement-method [fragment] [ixo-did]",
+		Short: "Add an IxoDid's encryption public key as a key agreement verification method",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fragment := args[0]
+
+			ixoDid, err := types.UnmarshalIxoDid(args[1])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			method := exported.NewVerificationMethod(
+				ixoDid.Did+"#"+fragment, exported.X25519KeyAgreementKey2019,
+				ixoDid.EncryptionPublicKey, []string{exported.KeyAgreement})
+			msg := types.NewMsgAddVerificationMethod(ixoDid.Did, method)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+}
+
+func GetCmdRemoveVerificationMethod(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove-verification-method [fragment] [ixo-did]",
+		Short: "Remove a verification method from an IxoDid",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fragment := args[0]
+
+			ixoDid, err := types.UnmarshalIxoDid(args[1])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			msg := types.NewMsgRemoveVerificationMethod(ixoDid.Did, ixoDid.Did+"#"+fragment)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+}
+
+func GetCmdAddService(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add-service [fragment] [type] [service-endpoint] [ixo-did]",
+		Short: "Add a service endpoint to an IxoDid",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fragment := args[0]
+			serviceType := args[1]
+			serviceEndpoint := args[2]
+
+			ixoDid, err := types.UnmarshalIxoDid(args[3])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			service := exported.NewService(
+				ixoDid.Did+"#"+fragment, serviceType, serviceEndpoint)
+			msg := types.NewMsgAddService(ixoDid.Did, service)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+}
+
+func GetCmdRemoveService(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove-service [fragment] [ixo-did]",
+		Short: "Remove a service endpoint from an IxoDid",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fragment := args[0]
+
+			ixoDid, err := types.UnmarshalIxoDid(args[1])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			msg := types.NewMsgRemoveService(ixoDid.Did, ixoDid.Did+"#"+fragment)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+}
+
+func GetCmdGrantDelegation(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "grant-delegation [delegate-did] [scopes] [ixo-did]",
+		Short: "Allow a delegate DID to sign messages on behalf of an IxoDid",
+		Long: `Allow a delegate DID to sign messages on behalf of an IxoDid. Scopes are
+comma-separated modules (e.g. bonds) or message types (e.g. bonds/buy).`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			delegateDid := args[0]
+			scopes := strings.Split(args[1], ",")
+
+			ixoDid, err := types.UnmarshalIxoDid(args[2])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).
+				WithFromAddress(ixoDid.Address())
+
+			delegate := exported.NewDelegate(delegateDid, scopes)
+			msg := types.NewMsgGrantDelegation(ixoDid.Did, delegate)
+			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
+		},
+	}
+}
+
+func GetCmdRevokeDelegation(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-delegation [delegate-did] [ixo-did]",
+		Short: "Revoke a delegate DID's delegation from an IxoDid",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			delegateDid := args[0]
+
+			ixoDid, err := types.UnmarshalIxoDid(args[1])
+			if err != nil {
+				return err
+			}
 
 			cliCtx := context.NewCLIContext().WithCodec(cdc).
 				WithFromAddress(ixoDid.Address())
 
-			msg := types.NewMsgAddCredential(didAddr, credTypes, ixoDid.Did, issued)
+			msg := types.NewMsgRevokeDelegation(ixoDid.Did, delegateDid)
 			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
 		},
 	}
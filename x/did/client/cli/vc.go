@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did/vc"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"strings"
+	"time"
+)
+
+// GetVcCmd returns the commands that sign W3C verifiable credentials and
+// presentations off-chain and verify them against chain state
+func GetVcCmd(cdc *codec.Codec) *cobra.Command {
+	vcCmd := &cobra.Command{
+		Use:   "did-vc",
+		Short: "Sign and verify W3C verifiable credentials and presentations using DIDs",
+	}
+
+	vcCmd.AddCommand(
+		GetCmdIssueVerifiableCredential(),
+		GetCmdCreateVerifiablePresentation(),
+		GetCmdVerifyVerifiableCredential(cdc),
+		GetCmdVerifyVerifiablePresentation(cdc),
+	)
+
+	return vcCmd
+}
+
+func printJSON(v interface{}) error {
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(output))
+	return nil
+}
+
+func GetCmdIssueVerifiableCredential() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issue-credential [subject-did] [claims-json] [issuer-ixo-did]",
+		Short: "Issue a verifiable credential about a DID, signed by the issuer IxoDid",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			subjectDid := args[0]
+			if !types.IsValidDid(subjectDid) {
+				return errors.New("input is not a valid did")
+			}
+
+			var claims map[string]interface{}
+			if err := json.Unmarshal([]byte(args[1]), &claims); err != nil {
+				return errors.Wrap(err, "claims should be a JSON object")
+			}
+
+			ixoDid, err := types.UnmarshalIxoDid(args[2])
+			if err != nil {
+				return err
+			}
+
+			var credTypes []string
+			if credTypesStr := viper.GetString(FlagCredTypes); credTypesStr != "" {
+				credTypes = strings.Split(credTypesStr, ",")
+			}
+
+			var expires time.Time
+			if expiresStr := viper.GetString(FlagExpires); expiresStr != "" {
+				expires, err = time.Parse(time.RFC3339, expiresStr)
+				if err != nil {
+					return errors.Wrap(err, "expiry time should be in RFC3339 format")
+				}
+			}
+
+			now := time.Now()
+			credential := vc.NewVerifiableCredential(viper.GetString(FlagId), credTypes,
+				ixoDid.Did, subjectDid, claims, now, expires)
+			credential, err = vc.SignCredential(credential, ixoDid, now)
+			if err != nil {
+				return err
+			}
+
+			return printJSON(credential)
+		},
+	}
+	cmd.Flags().String(FlagId, "", "ID (URI) of the credential, if any")
+	cmd.Flags().String(FlagCredTypes, "", "Comma-separated types of the credential")
+	cmd.Flags().String(FlagExpires, "", "Expiry time of the credential (RFC3339), if any")
+	return cmd
+}
+
+func GetCmdCreateVerifiablePresentation() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-presentation [credentials-json] [holder-ixo-did]",
+		Short: "Create a verifiable presentation of a JSON list of credentials, signed by the holder IxoDid",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var credentials []vc.VerifiableCredential
+			if err := json.Unmarshal([]byte(args[0]), &credentials); err != nil {
+				return errors.Wrap(err, "credentials should be a JSON list of credentials")
+			}
+
+			ixoDid, err := types.UnmarshalIxoDid(args[1])
+			if err != nil {
+				return err
+			}
+
+			presentation := vc.NewVerifiablePresentation(viper.GetString(FlagId), ixoDid.Did, credentials)
+			presentation, err = vc.SignPresentation(presentation, ixoDid,
+				viper.GetString(FlagChallenge), viper.GetString(FlagDomain), time.Now())
+			if err != nil {
+				return err
+			}
+
+			return printJSON(presentation)
+		},
+	}
+	cmd.Flags().String(FlagId, "", "ID (URI) of the presentation, if any")
+	cmd.Flags().String(FlagChallenge, "", "Challenge set by the verifier, if any")
+	cmd.Flags().String(FlagDomain, "", "Domain set by the verifier, if any")
+	return cmd
+}
+
+func GetCmdVerifyVerifiableCredential(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify-credential [credential-json]",
+		Short: "Verify a verifiable credential against the issuer's keys on-chain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var credential vc.VerifiableCredential
+			if err := json.Unmarshal([]byte(args[0]), &credential); err != nil {
+				return err
+			}
+
+			err := vc.VerifyCredential(credential, vc.NewQuerierResolver(cliCtx), time.Now())
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("credential is valid")
+			return nil
+		},
+	}
+}
+
+func GetCmdVerifyVerifiablePresentation(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-presentation [presentation-json]",
+		Short: "Verify a verifiable presentation (and its credentials) against the DIDs' keys on-chain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var presentation vc.VerifiablePresentation
+			if err := json.Unmarshal([]byte(args[0]), &presentation); err != nil {
+				return err
+			}
+
+			err := vc.VerifyPresentation(presentation, vc.NewQuerierResolver(cliCtx),
+				viper.GetString(FlagChallenge), viper.GetString(FlagDomain), time.Now())
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("presentation is valid")
+			return nil
+		},
+	}
+	cmd.Flags().String(FlagChallenge, "", "Expected challenge, if any")
+	cmd.Flags().String(FlagDomain, "", "Expected domain, if any")
+	return cmd
+}
@@ -1,10 +1,14 @@
 package rest
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/gorilla/mux"
@@ -18,8 +22,20 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/didToAddr/{did:.*}", queryAddressFromDidRequestHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/pubKeyToAddr/{pubKey}", queryAddressFromBase58EncodedPubkeyRequestHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/did/{did}", queryDidDocRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/did/address/{address}", queryDidFromAddressRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/did/{did}/history", queryDidDocHistoryRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/did/{did}/recovery", queryRecoveryRequestHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/did", queryAllDidsRequestHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/allDidDocs", queryAllDidDocsRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/credentialStatus/{did}/{credentialId}", queryCredentialStatusRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/credentials/{did}", queryCredentialsRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/credentialSchema/{schemaId}", queryCredentialSchemaRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/credentialSchemas", queryAllCredentialSchemasRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/trustedIssuers/{credType}", queryTrustedIssuersRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/didParams", queryParamsRequestHandler(cliCtx)).Methods("GET")
+
+	// DID resolution endpoint, as expected by universal resolver drivers
+	r.HandleFunc("/1.0/identifiers/{did:.*}", resolveDidRequestHandler(cliCtx)).Methods("GET")
 }
 
 func queryAddressFromBase58EncodedPubkeyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -28,13 +44,14 @@ func queryAddressFromBase58EncodedPubkeyRequestHandler(cliCtx context.CLIContext
 		w.Header().Set("Content-Type", "application/json")
 		vars := mux.Vars(r)
 
-		if !types.IsValidPubKey(vars["pubKey"]) {
+		keyType := r.URL.Query().Get("keyType")
+		if !types.IsValidKeyType(keyType) || !types.IsValidPubKeyOfType(keyType, vars["pubKey"]) {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte("input is not a valid base-58 encoded pubKey"))
 			return
 		}
 
-		accAddress := exported.VerifyKeyToAddr(vars["pubKey"])
+		accAddress := exported.PubKeyToAddr(keyType, vars["pubKey"])
 
 		rest.PostProcessResponse(w, cliCtx, accAddress)
 	}
@@ -68,6 +85,34 @@ func queryAddressFromDidRequestHandler(cliCtx context.CLIContext) http.HandlerFu
 	}
 }
 
+func queryDidFromAddressRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+			keeper.QueryAddress, vars["address"]), nil)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(fmt.Sprintf("Could't query did. Error: %s", err.Error())))
+			return
+		}
+		if len(res) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			_, _ = w.Write([]byte("No did for respected address."))
+			return
+		}
+
+		var did exported.Did
+		if err := json.Unmarshal(res, &did); err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, did)
+	}
+}
+
 func queryDidDocRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
@@ -143,3 +188,259 @@ func queryAllDidDocsRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		rest.PostProcessResponse(w, cliCtx, didDocs)
 	}
 }
+
+func queryDidDocHistoryRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+			keeper.QueryDidDocHistory, vars["did"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		var history []types.DidDocVersion
+		cliCtx.Codec.MustUnmarshalJSON(res, &history)
+
+		rest.PostProcessResponse(w, cliCtx, history)
+	}
+}
+
+func queryRecoveryRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+		vars := mux.Vars(r)
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+			keeper.QueryRecovery, vars["did"]), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		var recovery types.Recovery
+		cliCtx.Codec.MustUnmarshalJSON(res, &recovery)
+
+		rest.PostProcessResponse(w, cliCtx, recovery)
+	}
+}
+
+func resolveDidRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		vars := mux.Vars(r)
+		did := exported.Did(vars["did"])
+
+		// The DID document alone is returned if requested, otherwise the full
+		// resolution result (including resolution and document metadata)
+		documentOnly := strings.Contains(r.Header.Get("Accept"), types.DidLdJsonContentType)
+
+		var result types.DidResolutionResult
+		status := http.StatusOK
+		if !types.IsValidDid(did) {
+			result = types.NewDidResolutionError(types.ResolutionErrorInvalidDid)
+			status = http.StatusBadRequest
+		} else if versionId := r.URL.Query().Get("versionId"); versionId != "" {
+			// A past version of the DID doc, from the DID doc's history
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s", types.QuerierRoute,
+				keeper.QueryDidDocVersion, did, versionId), nil)
+			if err != nil || len(res) == 0 {
+				result = types.NewDidResolutionError(types.ResolutionErrorNotFound)
+				status = http.StatusNotFound
+			} else {
+				var version types.DidDocVersion
+				cliCtx.Codec.MustUnmarshalJSON(res, &version)
+				result = types.NewDidDocVersionResolutionResult(version)
+				if version.DidDoc.IsDeactivated() {
+					status = http.StatusGone
+				}
+			}
+		} else {
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+				keeper.QueryDidDoc, did), nil)
+			if err != nil || len(res) == 0 {
+				result = types.NewDidResolutionError(types.ResolutionErrorNotFound)
+				status = http.StatusNotFound
+			} else {
+				var didDoc types.BaseDidDoc
+				cliCtx.Codec.MustUnmarshalJSON(res, &didDoc)
+				result = types.NewDidResolutionResult(didDoc)
+				if didDoc.IsDeactivated() {
+					status = http.StatusGone
+				}
+			}
+		}
+
+		var output []byte
+		var err error
+		if documentOnly && result.DidDocument != nil {
+			w.Header().Set("Content-Type", types.DidLdJsonContentType)
+			output, err = json.MarshalIndent(result.DidDocument, "", "  ")
+		} else {
+			w.Header().Set("Content-Type", types.DidResolutionResultContentType)
+			output, err = json.MarshalIndent(result, "", "  ")
+		}
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.WriteHeader(status)
+		_, _ = w.Write(output)
+	}
+}
+
+func queryCredentialStatusRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+		vars := mux.Vars(r)
+		issuer := r.URL.Query().Get("issuer")
+
+		// The status at a past height is evaluated using that block's time
+		var height int64
+		var blockTime time.Time
+		if heightStr := r.URL.Query().Get("height"); heightStr != "" {
+			var err error
+			height, err = strconv.ParseInt(heightStr, 10, 64)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, "height is invalid")
+				return
+			}
+			node, err := cliCtx.GetNode()
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			block, err := node.Block(&height)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			blockTime = block.Block.Time
+		}
+
+		params := types.NewQueryCredentialStatusParams(
+			vars["did"], vars["credentialId"], issuer, height, blockTime)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute,
+			keeper.QueryCredentialStatus), bz)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(fmt.Sprintf("Could't query credential status. Error: %s", err.Error())))
+			return
+		}
+
+		var status types.CredentialStatus
+		cliCtx.Codec.MustUnmarshalJSON(res, &status)
+
+		rest.PostProcessResponse(w, cliCtx, status)
+	}
+}
+
+func queryCredentialsRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+			keeper.QueryCredentials, vars["did"]), nil)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(fmt.Sprintf("Could't query credentials. Error: %s", err.Error())))
+			return
+		}
+
+		var credentials []exported.DidCredential
+		cliCtx.Codec.MustUnmarshalJSON(res, &credentials)
+
+		rest.PostProcessResponse(w, cliCtx, credentials)
+	}
+}
+
+func queryCredentialSchemaRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+			keeper.QueryCredentialSchema, vars["schemaId"]), nil)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(fmt.Sprintf("Could't query credential schema. Error: %s", err.Error())))
+			return
+		}
+
+		var schema types.CredentialSchema
+		cliCtx.Codec.MustUnmarshalJSON(res, &schema)
+
+		rest.PostProcessResponse(w, cliCtx, schema)
+	}
+}
+
+func queryAllCredentialSchemasRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute,
+			keeper.QueryAllCredentialSchemas), nil)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(fmt.Sprintf("Could't query credential schemas. Error: %s", err.Error())))
+			return
+		}
+
+		var schemas []types.CredentialSchema
+		cliCtx.Codec.MustUnmarshalJSON(res, &schemas)
+
+		rest.PostProcessResponse(w, cliCtx, schemas)
+	}
+}
+
+func queryParamsRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute,
+			keeper.QueryParams), nil)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(fmt.Sprintf("Couldn't get query data %s", err.Error())))
+			return
+		}
+
+		var params types.Params
+		cliCtx.Codec.MustUnmarshalJSON(res, &params)
+
+		rest.PostProcessResponse(w, cliCtx, params)
+	}
+}
+
+func queryTrustedIssuersRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+		vars := mux.Vars(r)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+			keeper.QueryTrustedIssuers, vars["credType"]), nil)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(fmt.Sprintf("Could't query trusted issuers. Error: %s", err.Error())))
+			return
+		}
+
+		var issuers []exported.Did
+		cliCtx.Codec.MustUnmarshalJSON(res, &issuers)
+
+		rest.PostProcessResponse(w, cliCtx, issuers)
+	}
+}
@@ -15,12 +15,29 @@ import (
 func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/did/add_did", addDidRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/did/add_credential", addCredentialRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/update_did_pub_key", updateDidPubKeyRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/deactivate_did", deactivateDidRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/revoke_credential", revokeCredentialRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/add_credential_schema", addCredentialSchemaRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/add_verification_method", addVerificationMethodRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/remove_verification_method", removeVerificationMethodRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/add_service", addServiceRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/remove_service", removeServiceRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/grant_delegation", grantDelegationRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/revoke_delegation", revokeDelegationRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/set_multisig_key", setMultisigKeyRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/remove_multisig_key", removeMultisigKeyRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/set_guardians", setGuardiansRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/start_recovery", startRecoveryRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/approve_recovery", approveRecoveryRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/did/cancel_recovery", cancelRecoveryRequestHandler(cliCtx)).Methods("POST")
 }
 
 type addDidReq struct {
 	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
 	Did     exported.Did `json:"did" yaml:"did"`
 	PubKey  string       `json:"pubKey" yaml:"pubKey"`
+	KeyType string       `json:"keyType" yaml:"keyType"`
 }
 
 func addDidRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -35,7 +52,7 @@ func addDidRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgAddDid(req.Did, req.PubKey)
+		msg := types.NewMsgAddDid(req.Did, req.PubKey, req.KeyType)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -63,7 +80,465 @@ func addCredentialRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgAddCredential(req.Did, req.DidCredential.CredType, req.Did, req.DidCredential.Issued)
+		// Typed claims are expected to be signed by the issuer in the request
+		var msg types.MsgAddCredential
+		if req.DidCredential.Claim.IsTyped() {
+			credential := req.DidCredential
+			credential.Claim.Id = req.Did
+			msg = types.NewMsgAddSignedCredential(credential)
+		} else {
+			msg = types.NewMsgAddCredential(req.Did, req.DidCredential.CredType,
+				req.Did, req.DidCredential.Issued, req.DidCredential.Expires)
+		}
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type updateDidPubKeyReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Did     exported.Did `json:"did" yaml:"did"`
+	PubKey  string       `json:"pubKey" yaml:"pubKey"`
+	KeyType string       `json:"keyType" yaml:"keyType"`
+}
+
+func updateDidPubKeyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req updateDidPubKeyReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgUpdateDidPubKey(req.Did, req.PubKey, req.KeyType)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type deactivateDidReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Did     exported.Did `json:"did" yaml:"did"`
+}
+
+func deactivateDidRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req deactivateDidReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgDeactivateDid(req.Did)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type addVerificationMethodReq struct {
+	BaseReq            rest.BaseReq                `json:"base_req" yaml:"base_req"`
+	Did                exported.Did                `json:"did" yaml:"did"`
+	VerificationMethod exported.VerificationMethod `json:"verificationMethod" yaml:"verificationMethod"`
+}
+
+func addVerificationMethodRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req addVerificationMethodReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgAddVerificationMethod(req.Did, req.VerificationMethod)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type removeVerificationMethodReq struct {
+	BaseReq              rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Did                  exported.Did `json:"did" yaml:"did"`
+	VerificationMethodId string       `json:"verificationMethodId" yaml:"verificationMethodId"`
+}
+
+func removeVerificationMethodRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req removeVerificationMethodReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgRemoveVerificationMethod(req.Did, req.VerificationMethodId)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type addServiceReq struct {
+	BaseReq rest.BaseReq     `json:"base_req" yaml:"base_req"`
+	Did     exported.Did     `json:"did" yaml:"did"`
+	Service exported.Service `json:"service" yaml:"service"`
+}
+
+func addServiceRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req addServiceReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgAddService(req.Did, req.Service)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type removeServiceReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Did       exported.Did `json:"did" yaml:"did"`
+	ServiceId string       `json:"serviceId" yaml:"serviceId"`
+}
+
+func removeServiceRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req removeServiceReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgRemoveService(req.Did, req.ServiceId)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type grantDelegationReq struct {
+	BaseReq  rest.BaseReq      `json:"base_req" yaml:"base_req"`
+	Did      exported.Did      `json:"did" yaml:"did"`
+	Delegate exported.Delegate `json:"delegate" yaml:"delegate"`
+}
+
+func grantDelegationRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req grantDelegationReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgGrantDelegation(req.Did, req.Delegate)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type revokeDelegationReq struct {
+	BaseReq     rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Did         exported.Did `json:"did" yaml:"did"`
+	DelegateDid exported.Did `json:"delegateDid" yaml:"delegateDid"`
+}
+
+func revokeDelegationRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req revokeDelegationReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgRevokeDelegation(req.Did, req.DelegateDid)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type setMultisigKeyReq struct {
+	BaseReq     rest.BaseReq         `json:"base_req" yaml:"base_req"`
+	Did         exported.Did         `json:"did" yaml:"did"`
+	MultisigKey exported.MultisigKey `json:"multisigKey" yaml:"multisigKey"`
+}
+
+func setMultisigKeyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req setMultisigKeyReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgSetMultisigKey(req.Did, req.MultisigKey)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type removeMultisigKeyReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Did     exported.Did `json:"did" yaml:"did"`
+}
+
+func removeMultisigKeyRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req removeMultisigKeyReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgRemoveMultisigKey(req.Did)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type revokeCredentialReq struct {
+	BaseReq      rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Did          exported.Did `json:"did" yaml:"did"`
+	CredentialId string       `json:"credentialId" yaml:"credentialId"`
+	Issuer       exported.Did `json:"issuer" yaml:"issuer"`
+}
+
+func revokeCredentialRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req revokeCredentialReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgRevokeCredential(req.Did, req.CredentialId, req.Issuer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type addCredentialSchemaReq struct {
+	BaseReq rest.BaseReq           `json:"base_req" yaml:"base_req"`
+	Schema  types.CredentialSchema `json:"schema" yaml:"schema"`
+}
+
+func addCredentialSchemaRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req addCredentialSchemaReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgAddCredentialSchema(req.Schema)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type setGuardiansReq struct {
+	BaseReq   rest.BaseReq       `json:"base_req" yaml:"base_req"`
+	Did       exported.Did       `json:"did" yaml:"did"`
+	Guardians exported.Guardians `json:"guardians" yaml:"guardians"`
+}
+
+func setGuardiansRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req setGuardiansReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgSetGuardians(req.Did, req.Guardians)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type startRecoveryReq struct {
+	BaseReq     rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Did         exported.Did `json:"did" yaml:"did"`
+	GuardianDid exported.Did `json:"guardianDid" yaml:"guardianDid"`
+	PubKey      string       `json:"pubKey" yaml:"pubKey"`
+	KeyType     string       `json:"keyType" yaml:"keyType"`
+}
+
+func startRecoveryRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req startRecoveryReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgStartRecovery(req.Did, req.GuardianDid, req.PubKey, req.KeyType)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type approveRecoveryReq struct {
+	BaseReq     rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Did         exported.Did `json:"did" yaml:"did"`
+	GuardianDid exported.Did `json:"guardianDid" yaml:"guardianDid"`
+	PubKey      string       `json:"pubKey" yaml:"pubKey"`
+}
+
+func approveRecoveryRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req approveRecoveryReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgApproveRecovery(req.Did, req.GuardianDid, req.PubKey)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type cancelRecoveryReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Did     exported.Did `json:"did" yaml:"did"`
+}
+
+func cancelRecoveryRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req cancelRecoveryReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.NewMsgCancelRecovery(req.Did)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
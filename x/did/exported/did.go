@@ -10,10 +10,13 @@ import (
 	"github.com/btcsuite/btcutil/base58"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/go-bip39"
+	"github.com/tendermint/tendermint/crypto"
 	ed25519tm "github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"golang.org/x/crypto/ed25519"
 	naclBox "golang.org/x/crypto/nacl/box"
 	"io"
+	"time"
 )
 
 var DidPrefix = "did:ixo:"
@@ -38,6 +41,11 @@ type DidDoc interface {
 	GetDid() Did
 	SetPubKey(pubkey string) error
 	GetPubKey() string
+	GetKeyType() string
+	IsDeactivated() bool
+	GetAuthenticationPubKeys() []string
+	GetDelegateDids(route, msgType string) []Did
+	GetMultisigKey() MultisigKey
 	Address() sdk.AccAddress
 }
 
@@ -75,6 +83,7 @@ type IxoDid struct {
 	VerifyKey           string `json:"verifyKey" yaml:"verifyKey"`
 	EncryptionPublicKey string `json:"encryptionPublicKey" yaml:"encryptionPublicKey"`
 	Secret              Secret `json:"secret" yaml:"secret"`
+	KeyType             string `json:"keyType,omitempty" yaml:"keyType,omitempty"`
 }
 
 // Above IxoDid modelled after Sovrin documents
@@ -104,7 +113,8 @@ func (id IxoDid) Equals(other IxoDid) bool {
 	return id.Did == other.Did &&
 		id.VerifyKey == other.VerifyKey &&
 		id.EncryptionPublicKey == other.EncryptionPublicKey &&
-		id.Secret.Equals(other.Secret)
+		id.Secret.Equals(other.Secret) &&
+		id.KeyType == other.KeyType
 }
 
 func (id IxoDid) String() string {
@@ -123,7 +133,24 @@ func VerifyKeyToAddr(verifyKey string) sdk.AccAddress {
 }
 
 func (id IxoDid) Address() sdk.AccAddress {
-	return VerifyKeyToAddr(id.VerifyKey)
+	return PubKeyToAddr(id.KeyType, id.VerifyKey)
+}
+
+func (id IxoDid) PubKey() crypto.PubKey {
+	return PubKeyFromBase58(id.KeyType, id.VerifyKey)
+}
+
+func (id IxoDid) PrivKey() crypto.PrivKey {
+	if IsSecp256k1(id.KeyType) {
+		var privateKey secp256k1.PrivKeySecp256k1
+		copy(privateKey[:], base58.Decode(id.Secret.SignKey))
+		return privateKey
+	}
+
+	var privateKey ed25519tm.PrivKeyEd25519
+	copy(privateKey[:], base58.Decode(id.Secret.SignKey))
+	copy(privateKey[32:], base58.Decode(id.VerifyKey))
+	return privateKey
 }
 
 func GenerateMnemonic() (string, error) {
@@ -134,6 +161,9 @@ func GenerateMnemonic() (string, error) {
 	return bip39.NewMnemonic(entropy)
 }
 
+// FromMnemonic is the legacy derivation, which uses the SHA-256 hash of the
+// mnemonic as the seed. It is kept so that existing DIDs remain recoverable;
+// new DIDs should be derived using FromMnemonicAccount.
 func FromMnemonic(mnemonic string) (IxoDid, error) {
 	seed := sha256.New()
 	seed.Write([]byte(mnemonic))
@@ -180,26 +210,270 @@ func FromSeed(seed [32]byte) (IxoDid, error) {
 }
 
 func (id IxoDid) SignMessage(msg []byte) ([]byte, error) {
-	var privateKey ed25519tm.PrivKeyEd25519
-	copy(privateKey[:], base58.Decode(id.Secret.SignKey))
-	copy(privateKey[32:], base58.Decode(id.VerifyKey))
-	return privateKey.Sign(msg)
+	return id.PrivKey().Sign(msg)
 }
 
 func (id IxoDid) VerifySignedMessage(msg []byte, sig []byte) bool {
-	var publicKey ed25519tm.PubKeyEd25519
-	copy(publicKey[:], base58.Decode(id.VerifyKey))
-	return publicKey.VerifyBytes(msg, sig)
+	return id.PubKey().VerifyBytes(msg, sig)
 }
 
+// Claim is the subject of a credential. Besides the legacy KYCValidated flag,
+// a claim can carry a typed JSON body conforming to a registered schema.
 type Claim struct {
-	Id           Did  `json:"id" yaml:"id"`
-	KYCValidated bool `json:"KYCValidated" yaml:"KYCValidated"`
+	Id           Did    `json:"id" yaml:"id"`
+	KYCValidated bool   `json:"KYCValidated" yaml:"KYCValidated"`
+	SchemaId     string `json:"schemaId" yaml:"schemaId"`
+	Body         string `json:"body" yaml:"body"`
+}
+
+func NewClaim(id Did, schemaId, body string) Claim {
+	return Claim{
+		Id:       id,
+		SchemaId: schemaId,
+		Body:     body,
+	}
 }
 
+func (c Claim) IsTyped() bool { return c.SchemaId != "" }
+
+// IsAttested is true if the claim is either KYC validated or typed
+func (c Claim) IsAttested() bool { return c.KYCValidated || c.IsTyped() }
+
+// DidCredential is a credential about a DID. The ID and heights are assigned
+// on-chain. Revoked credentials are kept in the DID doc's history but are no
+// longer valid, and neither are credentials past their (optional) expiry time.
 type DidCredential struct {
-	CredType []string `json:"type" yaml:"type"`
-	Issuer   Did      `json:"issuer" yaml:"issuer"`
-	Issued   string   `json:"issued" yaml:"issued"`
-	Claim    Claim    `json:"claim" yaml:"claim"`
+	CredType      []string `json:"type" yaml:"type"`
+	Issuer        Did      `json:"issuer" yaml:"issuer"`
+	Issued        string   `json:"issued" yaml:"issued"`
+	Claim         Claim    `json:"claim" yaml:"claim"`
+	Id            string   `json:"id" yaml:"id"`
+	Expires       string   `json:"expires" yaml:"expires"`
+	IssuedHeight  int64    `json:"issuedHeight" yaml:"issuedHeight"`
+	Revoked       bool     `json:"revoked" yaml:"revoked"`
+	RevokedHeight int64    `json:"revokedHeight" yaml:"revokedHeight"`
+	Signature     string   `json:"signature" yaml:"signature"`
+}
+
+func NewDidCredential(credType []string, issuer Did, issued, expires string, claim Claim) DidCredential {
+	return DidCredential{
+		CredType: credType,
+		Issuer:   issuer,
+		Issued:   issued,
+		Claim:    claim,
+		Expires:  expires,
+	}
+}
+
+// SignBytes are the bytes signed by the credential's issuer, i.e. the sorted
+// JSON of the credential excluding the signature and the on-chain fields (ID,
+// issue height, and revocation), so that the credential can be verified
+// off-chain by zeroing these fields
+func (dc DidCredential) SignBytes() []byte {
+	unsigned := NewDidCredential(dc.CredType, dc.Issuer, dc.Issued, dc.Expires, dc.Claim)
+	bz, err := json.Marshal(unsigned)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+func (dc DidCredential) HasType(credType string) bool {
+	for _, t := range dc.CredType {
+		if t == credType {
+			return true
+		}
+	}
+	return false
+}
+
+func (dc DidCredential) HasSameTypes(other DidCredential) bool {
+	if len(dc.CredType) != len(other.CredType) {
+		return false
+	}
+	for i := range dc.CredType {
+		if dc.CredType[i] != other.CredType[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsExpiredAt assumes that the expiry time, if any, is a valid RFC3339 time
+func (dc DidCredential) IsExpiredAt(t time.Time) bool {
+	if dc.Expires == "" {
+		return false
+	}
+	expires, err := time.Parse(time.RFC3339, dc.Expires)
+	if err != nil {
+		return false
+	}
+	return !t.Before(expires)
+}
+
+func (dc DidCredential) IsRevokedAt(height int64) bool {
+	return dc.Revoked && dc.RevokedHeight <= height
+}
+
+// IsValidAt checks that the credential was issued, and had not been revoked
+// or expired, by the specified height and time
+func (dc DidCredential) IsValidAt(height int64, t time.Time) bool {
+	return dc.IssuedHeight <= height &&
+		!dc.IsRevokedAt(height) && !dc.IsExpiredAt(t)
+}
+
+// Verification method types, as per the W3C DID Specification Registries
+const (
+	Ed25519VerificationKey2018   = "Ed25519VerificationKey2018"
+	Secp256k1VerificationKey2019 = "EcdsaSecp256k1VerificationKey2019"
+	X25519KeyAgreementKey2019    = "X25519KeyAgreementKey2019"
+)
+
+// Verification relationships, as per the W3C DID Core specification
+const (
+	Authentication  = "authentication"
+	AssertionMethod = "assertionMethod"
+	KeyAgreement    = "keyAgreement"
+)
+
+// VerificationMethod is an additional key of a DID, identified by a DID URL
+// (e.g. did:ixo:U7GK8p8rVhJMKhBVRCJJ8c#key-2). The relationships indicate what
+// the key can be used for. The DID doc's PubKey is always the DID's primary
+// authentication key and is not listed as a verification method.
+type VerificationMethod struct {
+	Id              string   `json:"id" yaml:"id"`
+	Type            string   `json:"type" yaml:"type"`
+	PublicKeyBase58 string   `json:"publicKeyBase58" yaml:"publicKeyBase58"`
+	Relationships   []string `json:"relationships" yaml:"relationships"`
+}
+
+func NewVerificationMethod(id, methodType, publicKeyBase58 string,
+	relationships []string) VerificationMethod {
+	return VerificationMethod{
+		Id:              id,
+		Type:            methodType,
+		PublicKeyBase58: publicKeyBase58,
+		Relationships:   relationships,
+	}
+}
+
+func (vm VerificationMethod) HasRelationship(relationship string) bool {
+	for _, r := range vm.Relationships {
+		if r == relationship {
+			return true
+		}
+	}
+	return false
+}
+
+// Service is an endpoint through which the DID subject can be reached, such
+// as a cell node URL or a messaging endpoint, identified by a DID URL.
+type Service struct {
+	Id              string `json:"id" yaml:"id"`
+	Type            string `json:"type" yaml:"type"`
+	ServiceEndpoint string `json:"serviceEndpoint" yaml:"serviceEndpoint"`
+}
+
+func NewService(id, serviceType, serviceEndpoint string) Service {
+	return Service{
+		Id:              id,
+		Type:            serviceType,
+		ServiceEndpoint: serviceEndpoint,
+	}
+}
+
+// Delegate is a DID that can sign messages on behalf of the DID subject (the
+// principal). Each scope is either a module (e.g. bonds), which covers all of
+// the module's messages, or a message type within a module (e.g. bonds/buy).
+type Delegate struct {
+	Did    Did      `json:"did" yaml:"did"`
+	Scopes []string `json:"scopes" yaml:"scopes"`
+}
+
+func NewDelegate(did Did, scopes []string) Delegate {
+	return Delegate{
+		Did:    did,
+		Scopes: scopes,
+	}
+}
+
+// IsAuthorisedFor checks whether the delegate can sign messages of the
+// specified route (module) and type
+func (d Delegate) IsAuthorisedFor(route, msgType string) bool {
+	for _, scope := range d.Scopes {
+		if scope == route || scope == route+"/"+msgType {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessPolicy restricts an action to DIDs holding credentials of all of the
+// required types, with either a KYC validated or a typed claim. If any trusted
+// issuers are specified, only credentials issued by one of these count towards
+// satisfying the policy. An empty policy allows any DID.
+type AccessPolicy struct {
+	RequiredCredentialTypes []string `json:"required_credential_types" yaml:"required_credential_types"`
+	TrustedIssuers          []Did    `json:"trusted_issuers" yaml:"trusted_issuers"`
+}
+
+func NewAccessPolicy(requiredCredentialTypes []string, trustedIssuers []Did) AccessPolicy {
+	return AccessPolicy{
+		RequiredCredentialTypes: requiredCredentialTypes,
+		TrustedIssuers:          trustedIssuers,
+	}
+}
+
+func (ap AccessPolicy) IsEmpty() bool {
+	return len(ap.RequiredCredentialTypes) == 0 && len(ap.TrustedIssuers) == 0
+}
+
+func (ap AccessPolicy) IsTrustedIssuer(issuer Did) bool {
+	if len(ap.TrustedIssuers) == 0 {
+		return true
+	}
+	for _, trusted := range ap.TrustedIssuers {
+		if trusted == issuer {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSatisfiedBy checks whether the credentials held by the DID satisfy the
+// policy. Revoked and expired credentials are not filtered out, so callers
+// must only pass credentials that are valid at the current block.
+func (ap AccessPolicy) IsSatisfiedBy(did Did, credentials []DidCredential) bool {
+	if ap.IsEmpty() {
+		return true
+	}
+
+	// Only consider validated credentials about the DID from trusted issuers
+	var accepted []DidCredential
+	for _, cred := range credentials {
+		if cred.Claim.Id == did && cred.Claim.IsAttested() &&
+			ap.IsTrustedIssuer(cred.Issuer) {
+			accepted = append(accepted, cred)
+		}
+	}
+
+	// With no required types, any accepted credential is sufficient
+	if len(ap.RequiredCredentialTypes) == 0 {
+		return len(accepted) > 0
+	}
+
+	// Otherwise, each required type must be present in an accepted credential
+	for _, required := range ap.RequiredCredentialTypes {
+		found := false
+		for _, cred := range accepted {
+			if cred.HasType(required) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,57 @@
+package exported
+
+import (
+	"bytes"
+	"errors"
+	"github.com/btcsuite/btcutil/base58"
+	"strings"
+)
+
+// did:key method, where the DID is the multibase (base58btc) encoding of the
+// multicodec-prefixed Ed25519 public key, so that the DID doc can be resolved
+// from the DID alone.
+// Ref: https://w3c-ccg.github.io/did-method-key/
+const (
+	DidKeyPrefix = "did:key:"
+
+	// multibase prefix for base58btc
+	base58btcMultibase = "z"
+)
+
+// multicodec prefix for ed25519-pub (0xed as a varint)
+var ed25519MulticodecPrefix = []byte{0xed, 0x01}
+
+func IsDidKey(did Did) bool {
+	return strings.HasPrefix(did, DidKeyPrefix)
+}
+
+// DidKeyFromPubKey returns the did:key DID of a base58-encoded Ed25519 PubKey
+func DidKeyFromPubKey(pubKey string) Did {
+	pubKeyBz := base58.Decode(pubKey)
+	multicodecBz := append(append([]byte{}, ed25519MulticodecPrefix...), pubKeyBz...)
+	return DidKeyPrefix + base58btcMultibase + base58.Encode(multicodecBz)
+}
+
+// PubKeyFromDidKey returns the base58-encoded Ed25519 PubKey of a did:key DID
+func PubKeyFromDidKey(did Did) (string, error) {
+	if !IsDidKey(did) {
+		return "", errors.New("did is not a did:key")
+	}
+
+	identifier := strings.TrimPrefix(did, DidKeyPrefix)
+	if !strings.HasPrefix(identifier, base58btcMultibase) {
+		return "", errors.New("did:key identifier is not base58btc encoded")
+	}
+
+	multicodecBz := base58.Decode(strings.TrimPrefix(identifier, base58btcMultibase))
+	if !bytes.HasPrefix(multicodecBz, ed25519MulticodecPrefix) {
+		return "", errors.New("did:key is not an ed25519 public key")
+	}
+
+	pubKeyBz := multicodecBz[len(ed25519MulticodecPrefix):]
+	if len(pubKeyBz) != 32 {
+		return "", errors.New("did:key public key has an invalid length")
+	}
+
+	return base58.Encode(pubKeyBz), nil
+}
@@ -3,9 +3,12 @@ package exported
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/go-bip39"
 	"github.com/stretchr/testify/require"
+	"strings"
 	"testing"
 )
 
@@ -160,6 +163,36 @@ func TestFromSeed(t *testing.T) {
 	require.Equal(t, validIxoDid, ixoDid)
 }
 
+func TestFromSecp256k1Mnemonic(t *testing.T) {
+	ixoDid, err := FromSecp256k1Mnemonic(validMnemonic)
+	require.Nil(t, err)
+	require.Equal(t, Secp256k1KeyType, ixoDid.KeyType)
+	require.True(t, IsValidPubKeyOfType(Secp256k1KeyType, ixoDid.VerifyKey))
+	require.Equal(t, UnprefixedDidFromPubKey(ixoDid.VerifyKey), UnprefixedDid(ixoDid.Did))
+
+	// Same address as a Cosmos wallet created from the same mnemonic
+	kb := keys.NewInMemory()
+	info, err := kb.CreateAccount("wallet", validMnemonic, "", "password", 0, 0)
+	require.Nil(t, err)
+	require.Equal(t, info.GetAddress(), ixoDid.Address())
+
+	// Seed recovers the same DID
+	var seed [32]byte
+	seedBz, err := hex.DecodeString(ixoDid.Secret.Seed)
+	require.Nil(t, err)
+	copy(seed[:], seedBz)
+	fromSeed, err := FromSecp256k1Seed(seed)
+	require.Nil(t, err)
+	require.Equal(t, ixoDid, fromSeed)
+
+	// Signatures are verified using the secp256k1 PubKey
+	msg := []byte("abcdefghijklmnopqrstuvwxyz1234567890")
+	sig, err := ixoDid.SignMessage(msg)
+	require.Nil(t, err)
+	require.True(t, ixoDid.VerifySignedMessage(msg, sig))
+	require.False(t, validIxoDid.VerifySignedMessage(msg, sig))
+}
+
 func TestSignAndVerify(t *testing.T) {
 	bz1 := []byte("abcdefghijklmnopqrstuvwxyz1234567890")  // "correct" msg
 	bz2 := []byte("abcdefghijklmnopqrstuvwxyz1234567890_") // "incorrect" msg
@@ -186,3 +219,20 @@ func TestSignAndVerify(t *testing.T) {
 	require.False(t, validIxoDid.VerifySignedMessage(bz1, sig2))
 	require.False(t, validIxoDid.VerifySignedMessage(bz2, sig2))
 }
+
+func TestDidKey(t *testing.T) {
+	pubKey := validIxoDid.VerifyKey
+
+	didKey := DidKeyFromPubKey(pubKey)
+	require.True(t, IsDidKey(didKey))
+	require.True(t, strings.HasPrefix(didKey, DidKeyPrefix+"z6Mk"))
+
+	decodedPubKey, err := PubKeyFromDidKey(didKey)
+	require.Nil(t, err)
+	require.Equal(t, pubKey, decodedPubKey)
+
+	_, err = PubKeyFromDidKey(validIxoDid.Did)
+	require.NotNil(t, err)
+	_, err = PubKeyFromDidKey(DidKeyPrefix + "z" + pubKey)
+	require.NotNil(t, err)
+}
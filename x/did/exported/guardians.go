@@ -0,0 +1,35 @@
+package exported
+
+import "time"
+
+// MaxGuardians is the maximum number of guardians of a DID
+const MaxGuardians = 7
+
+// Guardians are the DIDs that can recover a DID whose sign key was lost, by
+// installing a new PubKey. A recovery is executed once at least Threshold of
+// the guardians have approved it and the RecoveryDelay has passed, during
+// which the DID's current key can still cancel the recovery.
+type Guardians struct {
+	Threshold     uint          `json:"threshold" yaml:"threshold"`
+	Dids          []Did         `json:"dids" yaml:"dids"`
+	RecoveryDelay time.Duration `json:"recoveryDelay" yaml:"recoveryDelay"`
+}
+
+func NewGuardians(threshold uint, dids []Did, recoveryDelay time.Duration) Guardians {
+	return Guardians{
+		Threshold:     threshold,
+		Dids:          dids,
+		RecoveryDelay: recoveryDelay,
+	}
+}
+
+func (g Guardians) IsEmpty() bool { return len(g.Dids) == 0 }
+
+func (g Guardians) IsGuardian(did Did) bool {
+	for _, guardian := range g.Dids {
+		if guardian == did {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package exported
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/go-bip39"
+	"strconv"
+	"strings"
+)
+
+// SLIP-0010 hierarchical deterministic derivation of ed25519 keys
+// Ref: https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+const (
+	slip10Ed25519Curve = "ed25519 seed"
+	hardenedOffset     = uint32(0x80000000)
+)
+
+// DidHDPath returns the default HD path of the DID with the account index,
+// i.e. m/44'/118'/<account>'/0'/0'. All levels are hardened, since SLIP-0010
+// only supports hardened derivation for ed25519 keys.
+func DidHDPath(account uint32) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0'/0'", sdk.CoinType, account)
+}
+
+// ParseHDPath parses a path of the form m/44'/118'/0'/0'/0', in which every
+// index has to be hardened (marked by ' or h)
+func ParseHDPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("hd path %s should start with m", path)
+	}
+
+	indices := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		if !strings.HasSuffix(part, "'") && !strings.HasSuffix(part, "h") {
+			return nil, fmt.Errorf("hd path %s has a non-hardened index %s", path, part)
+		}
+		index, err := strconv.ParseUint(part[:len(part)-1], 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("hd path %s has an invalid index %s", path, part)
+		}
+		indices = append(indices, uint32(index)+hardenedOffset)
+	}
+	return indices, nil
+}
+
+// DeriveEd25519Key derives the ed25519 private key (seed) at the HD path from
+// the BIP39 seed, as per SLIP-0010
+func DeriveEd25519Key(bip39Seed []byte, path string) ([32]byte, error) {
+	indices, err := ParseHDPath(path)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	key, chainCode := slip10Hmac([]byte(slip10Ed25519Curve), bip39Seed)
+	for _, index := range indices {
+		data := make([]byte, 1+32+4)
+		copy(data[1:33], key[:])
+		binary.BigEndian.PutUint32(data[33:], index)
+		key, chainCode = slip10Hmac(chainCode[:], data)
+	}
+	return key, nil
+}
+
+func slip10Hmac(key, data []byte) (il [32]byte, ir [32]byte) {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	i := mac.Sum(nil)
+	copy(il[:], i[:32])
+	copy(ir[:], i[32:])
+	return il, ir
+}
+
+// FromMnemonicWithPath derives the DID at the HD path from the BIP39 mnemonic
+// and (optional) passphrase. Unlike FromMnemonic, the mnemonic has to be a
+// valid BIP39 mnemonic.
+func FromMnemonicWithPath(mnemonic, passphrase, path string) (IxoDid, error) {
+	bip39Seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return IxoDid{}, err
+	}
+
+	seed, err := DeriveEd25519Key(bip39Seed, path)
+	if err != nil {
+		return IxoDid{}, err
+	}
+	return FromSeed(seed)
+}
+
+// FromMnemonicAccount derives the DID with the account index at the default
+// HD path (see DidHDPath)
+func FromMnemonicAccount(mnemonic, passphrase string, account uint32) (IxoDid, error) {
+	return FromMnemonicWithPath(mnemonic, passphrase, DidHDPath(account))
+}
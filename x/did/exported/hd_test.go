@@ -0,0 +1,67 @@
+package exported
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDeriveEd25519Key(t *testing.T) {
+	// SLIP-0010 test vector 1 for ed25519
+	bip39Seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	testCases := []struct {
+		path       string
+		privateKey string
+		publicKey  string
+	}{
+		{"m", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+			"a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed"},
+		{"m/0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+			"8c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c"},
+		{"m/0'/1'", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+			"1932a5270f335bed617d5b935c80aedb1a35bd9fc1e31acafd5372c30f5c1187"},
+	}
+
+	for _, tc := range testCases {
+		key, err := DeriveEd25519Key(bip39Seed, tc.path)
+		require.Nil(t, err)
+		require.Equal(t, tc.privateKey, hex.EncodeToString(key[:]))
+
+		ixoDid, err := FromSeed(key)
+		require.Nil(t, err)
+		require.Equal(t, tc.publicKey, hex.EncodeToString(base58.Decode(ixoDid.VerifyKey)))
+	}
+}
+
+func TestParseHDPath(t *testing.T) {
+	indices, err := ParseHDPath(DidHDPath(3))
+	require.Nil(t, err)
+	require.Equal(t, []uint32{44 + hardenedOffset, 118 + hardenedOffset,
+		3 + hardenedOffset, hardenedOffset, hardenedOffset}, indices)
+
+	_, err = ParseHDPath("m/44'/118'/0'/0/0")
+	require.NotNil(t, err) // non-hardened
+	_, err = ParseHDPath("44'/118'")
+	require.NotNil(t, err) // no m
+}
+
+func TestFromMnemonicAccount(t *testing.T) {
+	ixoDid0, err := FromMnemonicAccount(validMnemonic, "", 0)
+	require.Nil(t, err)
+	ixoDid1, err := FromMnemonicAccount(validMnemonic, "", 1)
+	require.Nil(t, err)
+	withPassphrase, err := FromMnemonicAccount(validMnemonic, "passphrase", 0)
+	require.Nil(t, err)
+
+	// Account indices and passphrases give different DIDs, and differ from
+	// the legacy derivation
+	require.NotEqual(t, ixoDid0.Did, ixoDid1.Did)
+	require.NotEqual(t, ixoDid0.Did, withPassphrase.Did)
+	require.NotEqual(t, validIxoDid.Did, ixoDid0.Did)
+
+	// Derivation is deterministic
+	again, err := FromMnemonicWithPath(validMnemonic, "", DidHDPath(1))
+	require.Nil(t, err)
+	require.Equal(t, ixoDid1, again)
+}
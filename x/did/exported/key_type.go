@@ -0,0 +1,100 @@
+package exported
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/go-bip39"
+	"github.com/tendermint/tendermint/crypto"
+	ed25519tm "github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	naclBox "golang.org/x/crypto/nacl/box"
+)
+
+// Key types of a DID's (primary) PubKey. An empty key type is treated as
+// ed25519, so that DIDs created before key types were introduced still work.
+const (
+	Ed25519KeyType   = "ed25519"
+	Secp256k1KeyType = "secp256k1"
+)
+
+func IsValidKeyType(keyType string) bool {
+	return keyType == "" || keyType == Ed25519KeyType || keyType == Secp256k1KeyType
+}
+
+func IsSecp256k1(keyType string) bool { return keyType == Secp256k1KeyType }
+
+// IsValidPubKeyOfType checks that the base58-encoded PubKey has the length of
+// a PubKey of the specified key type (a compressed PubKey for secp256k1)
+func IsValidPubKeyOfType(keyType, pubKey string) bool {
+	pubKeyBz := base58.Decode(pubKey)
+	if IsSecp256k1(keyType) {
+		return len(pubKeyBz) == secp256k1.PubKeySecp256k1Size &&
+			(pubKeyBz[0] == 0x02 || pubKeyBz[0] == 0x03)
+	}
+	return len(pubKeyBz) == ed25519tm.PubKeyEd25519Size
+}
+
+// PubKeyFromBase58 assumes that the PubKey is valid for the key type (check
+// IsValidPubKeyOfType)
+func PubKeyFromBase58(keyType, pubKey string) crypto.PubKey {
+	if IsSecp256k1(keyType) {
+		var pubKeySecp256k1 secp256k1.PubKeySecp256k1
+		copy(pubKeySecp256k1[:], base58.Decode(pubKey))
+		return pubKeySecp256k1
+	}
+	var pubKeyEd25519 ed25519tm.PubKeyEd25519
+	copy(pubKeyEd25519[:], base58.Decode(pubKey))
+	return pubKeyEd25519
+}
+
+func PubKeyToAddr(keyType, pubKey string) sdk.AccAddress {
+	return sdk.AccAddress(PubKeyFromBase58(keyType, pubKey).Address())
+}
+
+func GenSecp256k1() (IxoDid, error) {
+	return FromSecp256k1Seed([32]byte(secp256k1.GenPrivKey()))
+}
+
+// FromSecp256k1Mnemonic derives the secp256k1 key at the Cosmos HD path
+// (44'/118'/0'/0/0), so that the DID has the same key (and address) as a
+// Cosmos wallet created from the same mnemonic
+func FromSecp256k1Mnemonic(mnemonic string) (IxoDid, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return IxoDid{}, err
+	}
+
+	masterPriv, chainCode := hd.ComputeMastersFromSeed(seed)
+	privKey, err := hd.DerivePrivateKeyForPath(masterPriv, chainCode, sdk.FullFundraiserPath)
+	if err != nil {
+		return IxoDid{}, err
+	}
+
+	return FromSecp256k1Seed(privKey)
+}
+
+// FromSecp256k1Seed uses the seed as the secp256k1 private key
+func FromSecp256k1Seed(seed [32]byte) (IxoDid, error) {
+	privateKey := secp256k1.PrivKeySecp256k1(seed)
+	publicKey := privateKey.PubKey().(secp256k1.PubKeySecp256k1)
+
+	keyPairPublicKey, keyPairPrivateKey, err := naclBox.GenerateKey(bytes.NewReader(privateKey[:]))
+	if err != nil {
+		return IxoDid{}, err
+	}
+
+	return IxoDid{
+		Did:                 DidPrefix + base58.Encode(publicKey[:16]),
+		VerifyKey:           base58.Encode(publicKey[:]),
+		EncryptionPublicKey: base58.Encode(keyPairPublicKey[:]),
+		Secret: Secret{
+			Seed:                 hex.EncodeToString(seed[:]),
+			SignKey:              base58.Encode(privateKey[:]),
+			EncryptionPrivateKey: base58.Encode(keyPairPrivateKey[:]),
+		},
+		KeyType: Secp256k1KeyType,
+	}, nil
+}
@@ -0,0 +1,134 @@
+package exported
+
+import (
+	cryptoRand "crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github.com/btcsuite/btcutil/base58"
+	naclBox "golang.org/x/crypto/nacl/box"
+)
+
+const (
+	// MaxMemoLength is the maximum length (in bytes) of a decrypted memo
+	MaxMemoLength = 512
+
+	memoNonceLength = 24
+	memoKeyLength   = 32
+)
+
+// EncryptedMemo is a memo encrypted (using NaCl box) by the sender for the
+// X25519 encryption key published by the recipient DID in its DID doc as a
+// keyAgreement verification method. Only the recipient can decrypt the memo.
+type EncryptedMemo struct {
+	RecipientDid    Did    `json:"recipient_did" yaml:"recipient_did"`
+	RecipientPubKey string `json:"recipient_pub_key" yaml:"recipient_pub_key"`
+	SenderPubKey    string `json:"sender_pub_key" yaml:"sender_pub_key"`
+	Nonce           string `json:"nonce" yaml:"nonce"`
+	Ciphertext      string `json:"ciphertext" yaml:"ciphertext"`
+}
+
+// EncryptedMemoMsg is implemented by messages that can carry an encrypted memo
+type EncryptedMemoMsg interface {
+	GetEncryptedMemo() EncryptedMemo
+}
+
+func (m EncryptedMemo) IsEmpty() bool { return m == EncryptedMemo{} }
+
+// Validate checks the encoding and lengths of the memo's fields, but not
+// whether the recipient DID exists or has published the recipient PubKey
+func (m EncryptedMemo) Validate() error {
+	if m.RecipientDid == "" {
+		return fmt.Errorf("memo recipient did is empty")
+	} else if len(base58.Decode(m.RecipientPubKey)) != memoKeyLength {
+		return fmt.Errorf("memo recipient pubKey is invalid")
+	} else if len(base58.Decode(m.SenderPubKey)) != memoKeyLength {
+		return fmt.Errorf("memo sender pubKey is invalid")
+	} else if len(base58.Decode(m.Nonce)) != memoNonceLength {
+		return fmt.Errorf("memo nonce is invalid")
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(m.Ciphertext)
+	if err != nil {
+		return fmt.Errorf("memo ciphertext is not valid base64")
+	} else if len(ciphertext) < naclBox.Overhead {
+		return fmt.Errorf("memo ciphertext is too short")
+	} else if len(ciphertext) > MaxMemoLength+naclBox.Overhead {
+		return fmt.Errorf("memo should be at most %d bytes long", MaxMemoLength)
+	}
+
+	return nil
+}
+
+func decodeKey(key string) (*[memoKeyLength]byte, error) {
+	keyBz := base58.Decode(key)
+	if len(keyBz) != memoKeyLength {
+		return nil, fmt.Errorf("encryption key is invalid")
+	}
+	var keyArr [memoKeyLength]byte
+	copy(keyArr[:], keyBz)
+	return &keyArr, nil
+}
+
+// EncryptMemo encrypts the memo from the sender to the recipient DID's
+// (base58-encoded X25519) encryption PubKey
+func EncryptMemo(sender IxoDid, recipientDid Did, recipientPubKey, memo string) (EncryptedMemo, error) {
+	if len(memo) == 0 {
+		return EncryptedMemo{}, fmt.Errorf("memo is empty")
+	} else if len(memo) > MaxMemoLength {
+		return EncryptedMemo{}, fmt.Errorf("memo should be at most %d bytes long", MaxMemoLength)
+	}
+
+	peerPublicKey, err := decodeKey(recipientPubKey)
+	if err != nil {
+		return EncryptedMemo{}, err
+	}
+	privateKey, err := decodeKey(sender.Secret.EncryptionPrivateKey)
+	if err != nil {
+		return EncryptedMemo{}, err
+	}
+
+	var nonce [memoNonceLength]byte
+	if _, err := cryptoRand.Read(nonce[:]); err != nil {
+		return EncryptedMemo{}, err
+	}
+
+	ciphertext := naclBox.Seal(nil, []byte(memo), &nonce, peerPublicKey, privateKey)
+
+	return EncryptedMemo{
+		RecipientDid:    recipientDid,
+		RecipientPubKey: recipientPubKey,
+		SenderPubKey:    sender.EncryptionPublicKey,
+		Nonce:           base58.Encode(nonce[:]),
+		Ciphertext:      base64.StdEncoding.EncodeToString(ciphertext),
+	}, nil
+}
+
+// Decrypt decrypts the memo using the recipient's encryption private key
+func (m EncryptedMemo) Decrypt(recipient IxoDid) (string, error) {
+	if m.RecipientDid != recipient.Did {
+		return "", fmt.Errorf("memo is addressed to %s", m.RecipientDid)
+	} else if m.RecipientPubKey != recipient.EncryptionPublicKey {
+		return "", fmt.Errorf("memo is not encrypted for the did's encryption key")
+	} else if err := m.Validate(); err != nil {
+		return "", err
+	}
+
+	peerPublicKey, err := decodeKey(m.SenderPubKey)
+	if err != nil {
+		return "", err
+	}
+	privateKey, err := decodeKey(recipient.Secret.EncryptionPrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	var nonce [memoNonceLength]byte
+	copy(nonce[:], base58.Decode(m.Nonce))
+	ciphertext, _ := base64.StdEncoding.DecodeString(m.Ciphertext)
+
+	memo, ok := naclBox.Open(nil, ciphertext, &nonce, peerPublicKey, privateKey)
+	if !ok {
+		return "", fmt.Errorf("memo decryption failed")
+	}
+	return string(memo), nil
+}
@@ -0,0 +1,42 @@
+package exported
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestEncryptAndDecryptMemo(t *testing.T) {
+	sender, err := Gen()
+	require.Nil(t, err)
+	recipient, err := Gen()
+	require.Nil(t, err)
+	other, err := Gen()
+	require.Nil(t, err)
+
+	memo, err := EncryptMemo(sender, recipient.Did, recipient.EncryptionPublicKey, "invoice #42")
+	require.Nil(t, err)
+	require.Nil(t, memo.Validate())
+
+	// Only the recipient can decrypt the memo
+	plaintext, err := memo.Decrypt(recipient)
+	require.Nil(t, err)
+	require.Equal(t, "invoice #42", plaintext)
+	_, err = memo.Decrypt(other)
+	require.NotNil(t, err)
+	_, err = memo.Decrypt(sender)
+	require.NotNil(t, err)
+
+	// Tampered memos cannot be decrypted
+	tampered := memo
+	tampered.Nonce = memo.SenderPubKey[:len(memo.Nonce)]
+	_, err = tampered.Decrypt(recipient)
+	require.NotNil(t, err)
+
+	// Empty and overly long memos cannot be encrypted
+	_, err = EncryptMemo(sender, recipient.Did, recipient.EncryptionPublicKey, "")
+	require.NotNil(t, err)
+	_, err = EncryptMemo(sender, recipient.Did, recipient.EncryptionPublicKey,
+		strings.Repeat("a", MaxMemoLength+1))
+	require.NotNil(t, err)
+}
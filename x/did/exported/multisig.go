@@ -0,0 +1,48 @@
+package exported
+
+import (
+	"github.com/btcsuite/btcutil/base58"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+	ed25519tm "github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/multisig"
+)
+
+// MaxMultisigPubKeys is the maximum number of keys in a multisig key set,
+// which matches the default auth TxSigLimit
+const MaxMultisigPubKeys = 7
+
+// MultisigKey is an M-of-N threshold multisig key set of base58-encoded
+// Ed25519 keys. A DID backed by a multisig key set can only sign messages
+// using a multisignature by at least Threshold of the keys.
+type MultisigKey struct {
+	Threshold uint     `json:"threshold" yaml:"threshold"`
+	PubKeys   []string `json:"pubKeys" yaml:"pubKeys"`
+}
+
+func NewMultisigKey(threshold uint, pubKeys []string) MultisigKey {
+	return MultisigKey{
+		Threshold: threshold,
+		PubKeys:   pubKeys,
+	}
+}
+
+func (mk MultisigKey) IsEmpty() bool { return len(mk.PubKeys) == 0 }
+
+// PubKey returns the Tendermint threshold multisig PubKey of the key set. The
+// order of the keys matters, since it determines the PubKey and its address.
+// Assumes that the key set is valid (check ValidateMultisigKey).
+func (mk MultisigKey) PubKey() multisig.PubKeyMultisigThreshold {
+	pubKeys := make([]crypto.PubKey, len(mk.PubKeys))
+	for i, key := range mk.PubKeys {
+		var pubKey ed25519tm.PubKeyEd25519
+		copy(pubKey[:], base58.Decode(key))
+		pubKeys[i] = pubKey
+	}
+	return multisig.NewPubKeyMultisigThreshold(
+		int(mk.Threshold), pubKeys).(multisig.PubKeyMultisigThreshold)
+}
+
+func (mk MultisigKey) Address() sdk.AccAddress {
+	return sdk.AccAddress(mk.PubKey().Address())
+}
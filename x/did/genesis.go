@@ -6,16 +6,37 @@ import (
 )
 
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) []abci.ValidatorUpdate {
-	// Initialise did docs
+	// Initialise did docs, which also rebuilds the address index. The history
+	// is imported as is, so importing did docs does not add any versions.
 	for _, d := range data.DidDocs {
 		keeper.AddDidDoc(ctx, d)
 	}
+	for _, v := range data.DidDocHistory {
+		keeper.SetDidDocVersion(ctx, v)
+	}
+
+	// Initialise in-progress recoveries
+	for _, r := range data.Recoveries {
+		keeper.SetRecovery(ctx, r)
+	}
+
+	// Initialise params
+	keeper.SetParams(ctx, data.Params)
+
+	// Initialise credential schemas
+	for _, s := range data.CredentialSchemas {
+		keeper.SetCredentialSchema(ctx, s)
+	}
 
 	return []abci.ValidatorUpdate{}
 }
 
 func ExportGenesis(ctx sdk.Context, keeper Keeper) (data GenesisState) {
 	return GenesisState{
-		DidDocs: keeper.GetAllDidDocs(ctx),
+		DidDocs:           keeper.GetAllDidDocs(ctx),
+		CredentialSchemas: keeper.GetAllCredentialSchemas(ctx),
+		Params:            keeper.GetParams(ctx),
+		DidDocHistory:     keeper.GetAllDidDocVersions(ctx),
+		Recoveries:        keeper.GetAllRecoveries(ctx),
 	}
 }
@@ -3,13 +3,85 @@ package did
 import (
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
 	"github.com/ixofoundation/ixo-blockchain/x/did/internal/keeper"
 	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
-func NewHandler(k keeper.Keeper) sdk.Handler {
+// EndBlocker executes the recoveries that were approved by the guardians and
+// whose recovery delay has passed
+func EndBlocker(ctx sdk.Context, k keeper.Keeper, bk bank.Keeper, ak auth.AccountKeeper) []abci.ValidatorUpdate {
+	for _, recovery := range k.GetAllRecoveries(ctx) {
+		if !recovery.ShouldExecute(ctx.BlockTime()) {
+			continue
+		}
+
+		// The recovery is executed in a cached context, so that a recovery
+		// that fails (e.g. because the coins cannot be migrated) has no effect
+		// and does not prevent the other recoveries from being executed. A
+		// failed recovery is deleted, after which it can be started again.
+		migratedCoins, err := executeRecovery(ctx, k, bk, ak, recovery)
+		if err != nil {
+			k.Logger(ctx).Error(fmt.Sprintf("recovery of %s failed: %s", recovery.Did, err.Error()))
+			_ = k.CancelRecovery(ctx, recovery.Did)
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeRecoveryFailed,
+					sdk.NewAttribute(types.AttributeKeyDid, recovery.Did),
+					sdk.NewAttribute(types.AttributeKeyReason, err.Error()),
+				),
+			)
+			continue
+		}
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeRecoverDid,
+				sdk.NewAttribute(types.AttributeKeyDid, recovery.Did),
+				sdk.NewAttribute(types.AttributeKeyPubKey, recovery.PubKey),
+				sdk.NewAttribute(types.AttributeKeyApprovals, strings.Join(recovery.Approvals, ",")),
+				sdk.NewAttribute(types.AttributeKeyMigratedCoins, migratedCoins.String()),
+			),
+		)
+	}
+	return []abci.ValidatorUpdate{}
+}
+
+// executeRecovery executes the recovery and migrates the DID's coins to its
+// new address, only committing the changes if both succeed
+func executeRecovery(ctx sdk.Context, k keeper.Keeper, bk bank.Keeper, ak auth.AccountKeeper,
+	recovery types.Recovery) (sdk.Coins, sdk.Error) {
+	cacheCtx, write := ctx.CacheContext()
+
+	didDoc, err := k.GetDidDoc(cacheCtx, recovery.Did)
+	if err != nil {
+		return nil, err
+	}
+	oldAddr := didDoc.Address()
+
+	err = k.ExecuteRecovery(cacheCtx, recovery)
+	if err != nil {
+		return nil, err
+	}
+	newAddr := k.MustGetDidDoc(cacheCtx, recovery.Did).Address()
+
+	migratedCoins, err := migrateDidCoins(cacheCtx, bk, ak, oldAddr, newAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	write()
+	return migratedCoins, nil
+}
+
+func NewHandler(k keeper.Keeper, bk bank.Keeper, ak auth.AccountKeeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
 		ctx = ctx.WithEventManager(sdk.NewEventManager())
 		switch msg := msg.(type) {
@@ -17,6 +89,38 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleMsgAddDidDoc(ctx, k, msg)
 		case types.MsgAddCredential:
 			return handleMsgAddCredential(ctx, k, msg)
+		case types.MsgUpdateDidPubKey:
+			return handleMsgUpdateDidPubKey(ctx, k, bk, ak, msg)
+		case types.MsgDeactivateDid:
+			return handleMsgDeactivateDid(ctx, k, msg)
+		case types.MsgRevokeCredential:
+			return handleMsgRevokeCredential(ctx, k, msg)
+		case types.MsgAddCredentialSchema:
+			return handleMsgAddCredentialSchema(ctx, k, msg)
+		case types.MsgAddVerificationMethod:
+			return handleMsgAddVerificationMethod(ctx, k, msg)
+		case types.MsgRemoveVerificationMethod:
+			return handleMsgRemoveVerificationMethod(ctx, k, msg)
+		case types.MsgAddService:
+			return handleMsgAddService(ctx, k, msg)
+		case types.MsgRemoveService:
+			return handleMsgRemoveService(ctx, k, msg)
+		case types.MsgGrantDelegation:
+			return handleMsgGrantDelegation(ctx, k, msg)
+		case types.MsgRevokeDelegation:
+			return handleMsgRevokeDelegation(ctx, k, msg)
+		case types.MsgSetMultisigKey:
+			return handleMsgSetMultisigKey(ctx, k, bk, ak, msg)
+		case types.MsgRemoveMultisigKey:
+			return handleMsgRemoveMultisigKey(ctx, k, bk, ak, msg)
+		case types.MsgSetGuardians:
+			return handleMsgSetGuardians(ctx, k, msg)
+		case types.MsgStartRecovery:
+			return handleMsgStartRecovery(ctx, k, msg)
+		case types.MsgApproveRecovery:
+			return handleMsgApproveRecovery(ctx, k, msg)
+		case types.MsgCancelRecovery:
+			return handleMsgCancelRecovery(ctx, k, msg)
 		default:
 			return sdk.ErrUnknownRequest("No match for message type.").Result()
 		}
@@ -25,6 +129,7 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 
 func handleMsgAddDidDoc(ctx sdk.Context, k keeper.Keeper, msg types.MsgAddDid) sdk.Result {
 	didDoc := types.NewBaseDidDoc(msg.Did, msg.PubKey)
+	didDoc.KeyType = msg.KeyType
 
 	err := k.SetDidDoc(ctx, didDoc)
 	if err != nil {
@@ -46,11 +151,21 @@ func handleMsgAddDidDoc(ctx sdk.Context, k keeper.Keeper, msg types.MsgAddDid) s
 }
 
 func handleMsgAddCredential(ctx sdk.Context, k keeper.Keeper, msg types.MsgAddCredential) sdk.Result {
-	err := k.AddCredentials(ctx, msg.DidCredential.Claim.Id, msg.DidCredential)
+	// Only trusted issuers can issue credentials of the restricted types
+	err := k.CheckIssuerAuthorised(ctx, msg.DidCredential)
 	if err != nil {
 		return err.Result()
 	}
 
+	err = k.AddCredentials(ctx, msg.DidCredential.Claim.Id, msg.DidCredential)
+	if err != nil {
+		return err.Result()
+	}
+
+	// The added credential is the latest one in the DID doc
+	credentials := k.MustGetDidDoc(ctx, msg.DidCredential.Claim.Id).(types.BaseDidDoc).GetCredentials()
+	credentialId := credentials[len(credentials)-1].Id
+
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeAddCredential,
@@ -59,6 +174,418 @@ func handleMsgAddCredential(ctx sdk.Context, k keeper.Keeper, msg types.MsgAddCr
 			sdk.NewAttribute(types.AttributeKeyIssued, msg.DidCredential.Issued),
 			sdk.NewAttribute(types.AttributeKeyClaimID, msg.DidCredential.Claim.Id),
 			sdk.NewAttribute(types.AttributeKeyKYCValidated, strconv.FormatBool(msg.DidCredential.Claim.KYCValidated)),
+			sdk.NewAttribute(types.AttributeKeyCredentialId, credentialId),
+			sdk.NewAttribute(types.AttributeKeyExpires, msg.DidCredential.Expires),
+			sdk.NewAttribute(types.AttributeKeySchemaId, msg.DidCredential.Claim.SchemaId),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgRevokeCredential(ctx sdk.Context, k keeper.Keeper, msg types.MsgRevokeCredential) sdk.Result {
+	err := k.RevokeCredential(ctx, msg.Did, msg.CredentialId, msg.Issuer)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRevokeCredential,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
+			sdk.NewAttribute(types.AttributeKeyCredentialId, msg.CredentialId),
+			sdk.NewAttribute(types.AttributeKeyIssuer, msg.Issuer),
+			sdk.NewAttribute(types.AttributeKeyRevokedHeight, strconv.FormatInt(ctx.BlockHeight(), 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgAddCredentialSchema(ctx sdk.Context, k keeper.Keeper, msg types.MsgAddCredentialSchema) sdk.Result {
+	err := k.AddCredentialSchema(ctx, msg.Schema)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeAddCredentialSchema,
+			sdk.NewAttribute(types.AttributeKeySchemaId, msg.Schema.Id),
+			sdk.NewAttribute(types.AttributeKeyCreator, msg.Schema.Creator),
+			sdk.NewAttribute(types.AttributeKeySchemaName, msg.Schema.Name),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgUpdateDidPubKey(ctx sdk.Context, k keeper.Keeper, bk bank.Keeper, ak auth.AccountKeeper, msg types.MsgUpdateDidPubKey) sdk.Result {
+	didDoc, err := k.GetDidDoc(ctx, msg.Did)
+	if err != nil {
+		return err.Result()
+	}
+	oldAddr := didDoc.Address()
+
+	err = k.UpdateDidPubKey(ctx, msg.Did, msg.PubKey, msg.KeyType)
+	if err != nil {
+		return err.Result()
+	}
+	newAddr := k.MustGetDidDoc(ctx, msg.Did).Address()
+
+	// Since the DID's address is derived from its PubKey, any coins held by
+	// the address of the old PubKey are migrated to the new PubKey's address
+	migratedCoins, err := migrateDidCoins(ctx, bk, ak, oldAddr, newAddr)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUpdateDidPubKey,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
+			sdk.NewAttribute(types.AttributeKeyPubKey, msg.PubKey),
+			sdk.NewAttribute(types.AttributeKeyPubKeyRotationHeight, strconv.FormatInt(ctx.BlockHeight(), 10)),
+			sdk.NewAttribute(types.AttributeKeyMigratedCoins, migratedCoins.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgDeactivateDid(ctx sdk.Context, k keeper.Keeper, msg types.MsgDeactivateDid) sdk.Result {
+	err := k.DeactivateDid(ctx, msg.Did)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeDeactivateDid,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+
+	// Deactivation is allowed even if the DID still owns objects in other
+	// modules (e.g. bonds), which emit warning events through the DID hooks
+	k.AfterDidDeactivated(ctx, msg.Did)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgAddVerificationMethod(ctx sdk.Context, k keeper.Keeper, msg types.MsgAddVerificationMethod) sdk.Result {
+	err := k.AddVerificationMethod(ctx, msg.Did, msg.VerificationMethod)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeAddVerificationMethod,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
+			sdk.NewAttribute(types.AttributeKeyVerificationMethodId, msg.VerificationMethod.Id),
+			sdk.NewAttribute(types.AttributeKeyVerificationType, msg.VerificationMethod.Type),
+			sdk.NewAttribute(types.AttributeKeyPubKey, msg.VerificationMethod.PublicKeyBase58),
+			sdk.NewAttribute(types.AttributeKeyRelationships, strings.Join(msg.VerificationMethod.Relationships, ",")),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgRemoveVerificationMethod(ctx sdk.Context, k keeper.Keeper, msg types.MsgRemoveVerificationMethod) sdk.Result {
+	err := k.RemoveVerificationMethod(ctx, msg.Did, msg.VerificationMethodId)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRemoveVerificationMethod,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
+			sdk.NewAttribute(types.AttributeKeyVerificationMethodId, msg.VerificationMethodId),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgAddService(ctx sdk.Context, k keeper.Keeper, msg types.MsgAddService) sdk.Result {
+	err := k.AddService(ctx, msg.Did, msg.Service)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeAddService,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
+			sdk.NewAttribute(types.AttributeKeyServiceId, msg.Service.Id),
+			sdk.NewAttribute(types.AttributeKeyServiceType, msg.Service.Type),
+			sdk.NewAttribute(types.AttributeKeyServiceEndpoint, msg.Service.ServiceEndpoint),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgRemoveService(ctx sdk.Context, k keeper.Keeper, msg types.MsgRemoveService) sdk.Result {
+	err := k.RemoveService(ctx, msg.Did, msg.ServiceId)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRemoveService,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
+			sdk.NewAttribute(types.AttributeKeyServiceId, msg.ServiceId),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgGrantDelegation(ctx sdk.Context, k keeper.Keeper, msg types.MsgGrantDelegation) sdk.Result {
+	err := k.GrantDelegation(ctx, msg.Did, msg.Delegate)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeGrantDelegation,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
+			sdk.NewAttribute(types.AttributeKeyDelegateDid, msg.Delegate.Did),
+			sdk.NewAttribute(types.AttributeKeyScopes, strings.Join(msg.Delegate.Scopes, ",")),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgRevokeDelegation(ctx sdk.Context, k keeper.Keeper, msg types.MsgRevokeDelegation) sdk.Result {
+	err := k.RevokeDelegation(ctx, msg.Did, msg.DelegateDid)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRevokeDelegation,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
+			sdk.NewAttribute(types.AttributeKeyDelegateDid, msg.DelegateDid),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// migrateDidCoins migrates any coins held by the DID's old address to its new
+// address, for any change to the DID doc that changes the DID's address (i.e.
+// a PubKey rotation, setting or removing a multisig key set, or a recovery).
+// Only the spendable coins are migrated. Coins that are still locked in a
+// vesting account cannot be sent, so these remain at the old address, where
+// they stay controlled by the old key.
+func migrateDidCoins(ctx sdk.Context, bk bank.Keeper, ak auth.AccountKeeper,
+	oldAddr, newAddr sdk.AccAddress) (sdk.Coins, sdk.Error) {
+	oldAcc := ak.GetAccount(ctx, oldAddr)
+	if oldAcc == nil {
+		return sdk.Coins{}, nil
+	}
+
+	migratedCoins := oldAcc.SpendableCoins(ctx.BlockTime())
+	if !migratedCoins.IsZero() && !oldAddr.Equals(newAddr) {
+		err := bk.SendCoins(ctx, oldAddr, newAddr, migratedCoins)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return migratedCoins, nil
+}
+
+func handleMsgSetMultisigKey(ctx sdk.Context, k keeper.Keeper, bk bank.Keeper, ak auth.AccountKeeper, msg types.MsgSetMultisigKey) sdk.Result {
+	didDoc, err := k.GetDidDoc(ctx, msg.Did)
+	if err != nil {
+		return err.Result()
+	}
+	oldAddr := didDoc.Address()
+
+	err = k.SetMultisigKey(ctx, msg.Did, msg.MultisigKey)
+	if err != nil {
+		return err.Result()
+	}
+	newAddr := k.MustGetDidDoc(ctx, msg.Did).Address()
+
+	migratedCoins, err := migrateDidCoins(ctx, bk, ak, oldAddr, newAddr)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSetMultisigKey,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
+			sdk.NewAttribute(types.AttributeKeyThreshold, fmt.Sprint(msg.MultisigKey.Threshold)),
+			sdk.NewAttribute(types.AttributeKeyPubKeys, strings.Join(msg.MultisigKey.PubKeys, ",")),
+			sdk.NewAttribute(types.AttributeKeyMigratedCoins, migratedCoins.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgRemoveMultisigKey(ctx sdk.Context, k keeper.Keeper, bk bank.Keeper, ak auth.AccountKeeper, msg types.MsgRemoveMultisigKey) sdk.Result {
+	didDoc, err := k.GetDidDoc(ctx, msg.Did)
+	if err != nil {
+		return err.Result()
+	}
+	oldAddr := didDoc.Address()
+
+	err = k.RemoveMultisigKey(ctx, msg.Did)
+	if err != nil {
+		return err.Result()
+	}
+	newAddr := k.MustGetDidDoc(ctx, msg.Did).Address()
+
+	migratedCoins, err := migrateDidCoins(ctx, bk, ak, oldAddr, newAddr)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRemoveMultisigKey,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
+			sdk.NewAttribute(types.AttributeKeyMigratedCoins, migratedCoins.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgSetGuardians(ctx sdk.Context, k keeper.Keeper, msg types.MsgSetGuardians) sdk.Result {
+	err := k.SetGuardians(ctx, msg.Did, msg.Guardians)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSetGuardians,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
+			sdk.NewAttribute(types.AttributeKeyThreshold, fmt.Sprint(msg.Guardians.Threshold)),
+			sdk.NewAttribute(types.AttributeKeyGuardians, strings.Join(msg.Guardians.Dids, ",")),
+			sdk.NewAttribute(types.AttributeKeyRecoveryDelay, msg.Guardians.RecoveryDelay.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// newRecoveryEvent returns the event emitted when a guardian starts or
+// approves a recovery. The execution time is empty until the recovery has
+// been approved by the threshold of guardians.
+func newRecoveryEvent(eventType string, guardianDid exported.Did, recovery types.Recovery) sdk.Event {
+	var executionTime string
+	if recovery.IsApproved() {
+		executionTime = recovery.ExecutionTime.UTC().Format(time.RFC3339)
+	}
+	return sdk.NewEvent(
+		eventType,
+		sdk.NewAttribute(types.AttributeKeyDid, recovery.Did),
+		sdk.NewAttribute(types.AttributeKeyGuardianDid, guardianDid),
+		sdk.NewAttribute(types.AttributeKeyPubKey, recovery.PubKey),
+		sdk.NewAttribute(types.AttributeKeyApprovals, strconv.Itoa(len(recovery.Approvals))),
+		sdk.NewAttribute(types.AttributeKeyExecutionTime, executionTime),
+	)
+}
+
+func handleMsgStartRecovery(ctx sdk.Context, k keeper.Keeper, msg types.MsgStartRecovery) sdk.Result {
+	recovery, err := k.StartRecovery(ctx, msg.Did, msg.GuardianDid, msg.PubKey, msg.KeyType)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		newRecoveryEvent(types.EventTypeStartRecovery, msg.GuardianDid, recovery),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgApproveRecovery(ctx sdk.Context, k keeper.Keeper, msg types.MsgApproveRecovery) sdk.Result {
+	recovery, err := k.ApproveRecovery(ctx, msg.Did, msg.GuardianDid, msg.PubKey)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		newRecoveryEvent(types.EventTypeApproveRecovery, msg.GuardianDid, recovery),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgCancelRecovery(ctx sdk.Context, k keeper.Keeper, msg types.MsgCancelRecovery) sdk.Result {
+	err := k.CancelRecovery(ctx, msg.Did)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCancelRecovery,
+			sdk.NewAttribute(types.AttributeKeyDid, msg.Did),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
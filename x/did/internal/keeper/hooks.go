@@ -0,0 +1,16 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+)
+
+// Implements DidHooks
+var _ types.DidHooks = Keeper{}
+
+func (k Keeper) AfterDidDeactivated(ctx sdk.Context, did exported.Did) {
+	if k.hooks != nil {
+		k.hooks.AfterDidDeactivated(ctx, did)
+	}
+}
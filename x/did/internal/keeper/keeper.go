@@ -1,30 +1,65 @@
 package keeper
 
 import (
+	"encoding/base64"
 	"fmt"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
 	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"time"
 )
 
 type Keeper struct {
-	storeKey sdk.StoreKey
-	cdc      *codec.Codec
+	storeKey   sdk.StoreKey
+	cdc        *codec.Codec
+	paramSpace params.Subspace
+	hooks      types.DidHooks
 }
 
-func NewKeeper(cdc *codec.Codec, key sdk.StoreKey) Keeper {
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramSpace params.Subspace) Keeper {
 	return Keeper{
-		storeKey: key,
-		cdc:      cdc,
+		storeKey:   key,
+		cdc:        cdc,
+		paramSpace: paramSpace.WithKeyTable(types.ParamKeyTable()),
 	}
 }
 
+// GetParams returns the total set of did parameters.
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the total set of did parameters.
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// SetHooks sets the DID hooks
+func (k *Keeper) SetHooks(dh types.DidHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set did hooks twice")
+	}
+	k.hooks = dh
+	return k
+}
+
 func (k Keeper) GetDidDoc(ctx sdk.Context, did exported.Did) (exported.DidDoc, sdk.Error) {
 	store := ctx.KVStore(k.storeKey)
 	key := types.GetDidPrefixKey(did)
 	bz := store.Get(key)
 	if bz == nil {
+		if exported.IsDidKey(did) && k.GetParams(ctx).EnableDidKey {
+			return k.resolveDidKey(did)
+		}
 		return nil, types.ErrorInvalidDid(
 			types.DefaultCodespace, fmt.Sprintf("Invalid Did Address %s", did))
 	}
@@ -35,6 +70,17 @@ func (k Keeper) GetDidDoc(ctx sdk.Context, did exported.Did) (exported.DidDoc, s
 	return didDoc, nil
 }
 
+// resolveDidKey synthesises the DID doc of a did:key DID, which is not stored
+// unless it is changed (e.g. by adding credentials to it or deactivating it)
+func (k Keeper) resolveDidKey(did exported.Did) (exported.DidDoc, sdk.Error) {
+	pubKey, err := exported.PubKeyFromDidKey(did)
+	if err != nil {
+		return nil, types.ErrorInvalidDid(types.DefaultCodespace, err.Error())
+	}
+
+	return types.NewBaseDidDoc(did, pubKey), nil
+}
+
 func (k Keeper) MustGetDidDoc(ctx sdk.Context, did exported.Did) exported.DidDoc {
 	didDoc, err := k.GetDidDoc(ctx, did)
 	if err != nil {
@@ -49,14 +95,171 @@ func (k Keeper) SetDidDoc(ctx sdk.Context, did exported.DidDoc) (err sdk.Error)
 		return types.ErrorInvalidDid(types.DefaultCodespace, "Did already exists")
 	}
 
+	return k.updateDidDoc(ctx, did, types.EventTypeAddDidDoc, did.GetDid())
+}
+
+// updateDidDoc stores the DID doc and records it as a new version in the DID
+// doc's history. If the MaxDidDocVersions param is set, only that number of
+// most recent versions is kept. The DID doc is not stored if its address is
+// already used by another active DID.
+func (k Keeper) updateDidDoc(ctx sdk.Context, did exported.DidDoc,
+	changeType string, actor exported.Did) sdk.Error {
+	if err := k.checkAddressAvailable(ctx, did); err != nil {
+		return err
+	}
 	k.AddDidDoc(ctx, did)
+
+	var didDoc types.BaseDidDoc
+	switch d := did.(type) {
+	case types.BaseDidDoc:
+		didDoc = d
+	case *types.BaseDidDoc:
+		didDoc = *d
+	default:
+		panic(fmt.Sprintf("unexpected did doc type %T", did))
+	}
+
+	versionId := k.getLatestDidDocVersionId(ctx, didDoc.Did) + 1
+	k.SetDidDocVersion(ctx, types.NewDidDocVersion(versionId,
+		ctx.BlockHeight(), ctx.BlockTime(), changeType, actor, didDoc))
+
+	maxVersions := k.GetParams(ctx).MaxDidDocVersions
+	if maxVersions > 0 && versionId > maxVersions {
+		k.pruneDidDocHistory(ctx, didDoc.Did, versionId-maxVersions)
+	}
 	return nil
 }
 
+func (k Keeper) getLatestDidDocVersionId(ctx sdk.Context, did exported.Did) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, types.GetDidDocHistoryPrefixKey(did))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return 0
+	}
+
+	var version types.DidDocVersion
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &version)
+	return version.VersionId
+}
+
+// pruneDidDocHistory deletes the DID's versions up to (and including) the
+// specified version
+func (k Keeper) pruneDidDocHistory(ctx sdk.Context, did exported.Did, upToVersionId uint64) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetDidDocHistoryPrefixKey(did))
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		var version types.DidDocVersion
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &version)
+		if version.VersionId > upToVersionId {
+			break
+		}
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+func (k Keeper) SetDidDocVersion(ctx sdk.Context, version types.DidDocVersion) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetDidDocVersionKey(version.DidDoc.Did, version.VersionId)
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(version))
+}
+
+func (k Keeper) GetDidDocVersion(ctx sdk.Context, did exported.Did, versionId uint64) (types.DidDocVersion, sdk.Error) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetDidDocVersionKey(did, versionId))
+	if bz == nil {
+		return types.DidDocVersion{}, types.ErrorInvalidDid(types.DefaultCodespace,
+			fmt.Sprintf("version %d of did %s not found", versionId, did))
+	}
+
+	var version types.DidDocVersion
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &version)
+	return version, nil
+}
+
+// GetDidDocHistory returns the (unpruned) versions of the DID's DID doc, in
+// order of creation
+func (k Keeper) GetDidDocHistory(ctx sdk.Context, did exported.Did) (versions []types.DidDocVersion) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetDidDocHistoryPrefixKey(did))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var version types.DidDocVersion
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &version)
+		versions = append(versions, version)
+	}
+
+	return versions
+}
+
+func (k Keeper) GetAllDidDocVersions(ctx sdk.Context) (versions []types.DidDocVersion) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.DidDocVersionKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var version types.DidDocVersion
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &version)
+		versions = append(versions, version)
+	}
+
+	return versions
+}
+
 func (k Keeper) AddDidDoc(ctx sdk.Context, did exported.DidDoc) {
 	store := ctx.KVStore(k.storeKey)
 	key := types.GetDidPrefixKey(did.GetDid())
+
+	// The address index only maps the DID's current address to the DID, so
+	// the entry of the previous address is removed if the address changes
+	// (i.e. if the PubKey is rotated or a multisig key set is set/removed)
+	if bz := store.Get(key); bz != nil {
+		var existing types.BaseDidDoc
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &existing)
+		oldAddress := existing.Address()
+		if !oldAddress.Equals(did.Address()) &&
+			string(store.Get(types.GetAddressKey(oldAddress))) == did.GetDid() {
+			store.Delete(types.GetAddressKey(oldAddress))
+		}
+	}
+
 	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(did))
+	store.Set(types.GetAddressKey(did.Address()), []byte(did.GetDid()))
+}
+
+// checkAddressAvailable returns an error if the DID doc's address is already
+// indexed to a different DID that is active, so that a second DID (e.g. a
+// did:key DID with the same PubKey) cannot take over the address.
+func (k Keeper) checkAddressAvailable(ctx sdk.Context, did exported.DidDoc) sdk.Error {
+	indexedDid, err := k.GetDidByAddress(ctx, did.Address())
+	if err != nil || indexedDid == did.GetDid() {
+		return nil
+	}
+
+	indexedDidDoc, err := k.GetDidDoc(ctx, indexedDid)
+	if err == nil && !indexedDidDoc.IsDeactivated() {
+		return types.ErrorInvalidDid(types.DefaultCodespace, fmt.Sprintf(
+			"address %s is already used by did %s", did.Address(), indexedDid))
+	}
+	return nil
+}
+
+// GetDidByAddress returns the DID whose current address is the address. Note
+// that did:key DIDs are only indexed once their DID doc is stored.
+func (k Keeper) GetDidByAddress(ctx sdk.Context, address sdk.AccAddress) (exported.Did, sdk.Error) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetAddressKey(address))
+	if bz == nil {
+		return "", types.ErrorInvalidDid(types.DefaultCodespace,
+			fmt.Sprintf("no did found for address %s", address))
+	}
+	return exported.Did(bz), nil
 }
 
 func (k Keeper) AddCredentials(ctx sdk.Context, did exported.Did, credential exported.DidCredential) (err sdk.Error) {
@@ -66,20 +269,385 @@ func (k Keeper) AddCredentials(ctx sdk.Context, did exported.Did, credential exp
 	}
 
 	baseDidDoc := existedDid.(types.BaseDidDoc)
-	credentials := baseDidDoc.GetCredentials()
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "cannot add credentials to a deactivated did")
+	}
+
+	// Typed claims must conform to their schema and be signed by the issuer
+	if credential.Claim.IsTyped() {
+		if err := k.validateTypedCredential(ctx, credential); err != nil {
+			return err
+		}
+	}
 
+	// The same credential can only be issued again once it is no longer valid
+	credentials := baseDidDoc.GetValidCredentials(ctx.BlockHeight(), ctx.BlockTime())
 	for _, data := range credentials {
-		if data.Issuer == credential.Issuer && data.CredType[0] == credential.CredType[0] && data.CredType[1] == credential.CredType[1] && data.Claim.KYCValidated == credential.Claim.KYCValidated {
+		if data.Issuer == credential.Issuer && data.HasSameTypes(credential) && data.Claim.KYCValidated == credential.Claim.KYCValidated {
 			return types.ErrorInvalidCredentials(types.DefaultCodespace, "credentials already exist")
 		}
 	}
 
+	// Credentials are never removed, so the number of credentials is a unique ID
+	credential.Id = types.NewCredentialId(len(baseDidDoc.GetCredentials()))
+	credential.IssuedHeight = ctx.BlockHeight()
+	credential.Revoked = false
+	credential.RevokedHeight = 0
+
 	baseDidDoc.AddCredential(credential)
-	k.AddDidDoc(ctx, baseDidDoc)
+	return k.updateDidDoc(ctx, baseDidDoc, types.EventTypeAddCredential, credential.Issuer)
+}
+
+func (k Keeper) validateTypedCredential(ctx sdk.Context, credential exported.DidCredential) sdk.Error {
+	schema, err := k.GetCredentialSchema(ctx, credential.Claim.SchemaId)
+	if err != nil {
+		return err
+	} else if err := schema.ValidateClaimBody(credential.Claim.Body); err != nil {
+		return err
+	}
+
+	issuerDidDoc, err := k.GetDidDoc(ctx, credential.Issuer)
+	if err != nil {
+		return types.ErrorInvalidIssuer(types.DefaultCodespace, "issuer did not found")
+	}
+
+	// The signature can be made using any of the issuer's assertion keys
+	sig, _ := base64.StdEncoding.DecodeString(credential.Signature)
+	signBytes := credential.SignBytes()
+	for _, pubKey := range issuerDidDoc.(types.BaseDidDoc).GetAssertionPubKeys() {
+		if pubKey.VerifyBytes(signBytes, sig) {
+			return nil
+		}
+	}
+
+	return types.ErrorInvalidCredentials(types.DefaultCodespace, "credential signature verification failed")
+}
+
+// CheckIssuerAuthorised checks the credential's issuer against the trusted
+// issuer registry in the params
+func (k Keeper) CheckIssuerAuthorised(ctx sdk.Context, credential exported.DidCredential) sdk.Error {
+	if !k.GetParams(ctx).IsAuthorisedIssuer(credential.CredType, credential.Issuer) {
+		return types.ErrorInvalidIssuer(types.DefaultCodespace, fmt.Sprintf(
+			"issuer %s is not trusted to issue credentials of type %v",
+			credential.Issuer, credential.CredType))
+	}
+	return nil
+}
+
+func (k Keeper) RevokeCredential(ctx sdk.Context, did exported.Did, credentialId string, issuer exported.Did) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	credential, found := baseDidDoc.GetCredential(credentialId)
+	if !found {
+		return types.ErrorInvalidCredentials(types.DefaultCodespace, "credential not found")
+	} else if credential.Issuer != issuer {
+		return types.ErrorInvalidIssuer(types.DefaultCodespace, "only the issuer of a credential can revoke it")
+	} else if credential.Revoked {
+		return types.ErrorInvalidCredentials(types.DefaultCodespace, "credential is already revoked")
+	}
+
+	// The credential is kept in the DID doc's history
+	baseDidDoc.RevokeCredential(credentialId, ctx.BlockHeight())
+	return k.updateDidDoc(ctx, baseDidDoc, types.EventTypeRevokeCredential, issuer)
+}
+
+func (k Keeper) GetCredentialStatus(ctx sdk.Context, did exported.Did, credentialId string,
+	issuer exported.Did, height int64, t time.Time) (types.CredentialStatus, sdk.Error) {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return types.CredentialStatus{}, err
+	}
+
+	credential, found := existedDid.(types.BaseDidDoc).GetCredential(credentialId)
+	if !found {
+		return types.CredentialStatus{}, types.ErrorInvalidCredentials(
+			types.DefaultCodespace, "credential not found")
+	} else if credential.Issuer != issuer {
+		return types.CredentialStatus{}, types.ErrorInvalidIssuer(
+			types.DefaultCodespace, fmt.Sprintf("credential not issued by %s", issuer))
+	}
+
+	return types.NewCredentialStatus(credential, height, t), nil
+}
+
+func (k Keeper) UpdateDidPubKey(ctx sdk.Context, did exported.Did, pubKey, keyType string) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "cannot update the pubKey of a deactivated did")
+	} else if baseDidDoc.GetPubKey() == pubKey {
+		return types.ErrorInvalidPubKey(types.DefaultCodespace, "pubKey is already the did's pubKey")
+	}
+
+	baseDidDoc.RotatePubKey(pubKey, keyType, ctx.BlockHeight())
+	return k.updateDidDoc(ctx, baseDidDoc, types.EventTypeUpdateDidPubKey, did)
+}
+
+func (k Keeper) DeactivateDid(ctx sdk.Context, did exported.Did) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "did is already deactivated")
+	}
+
+	// The DID doc is kept (tombstoned) rather than deleted, so that the DID
+	// cannot be added again and so that it can still be resolved
+	baseDidDoc.Deactivate()
+	if err := k.updateDidDoc(ctx, baseDidDoc, types.EventTypeDeactivateDid, did); err != nil {
+		return err
+	}
+
+	// A deactivated DID can no longer be recovered
+	k.deleteRecovery(ctx, did)
 
 	return nil
 }
 
+func (k Keeper) AddVerificationMethod(ctx sdk.Context, did exported.Did, method exported.VerificationMethod) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "cannot add a verification method to a deactivated did")
+	} else if baseDidDoc.HasVerificationMethod(method.Id) {
+		return types.ErrorInvalidVerificationMethod(types.DefaultCodespace, "verification method already exists")
+	} else if baseDidDoc.GetPubKey() == method.PublicKeyBase58 {
+		return types.ErrorInvalidVerificationMethod(types.DefaultCodespace, "verification method key is the did's pubKey")
+	}
+
+	baseDidDoc.AddVerificationMethod(method)
+	return k.updateDidDoc(ctx, baseDidDoc, types.EventTypeAddVerificationMethod, did)
+}
+
+func (k Keeper) RemoveVerificationMethod(ctx sdk.Context, did exported.Did, methodId string) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "cannot remove a verification method from a deactivated did")
+	} else if !baseDidDoc.HasVerificationMethod(methodId) {
+		return types.ErrorInvalidVerificationMethod(types.DefaultCodespace, "verification method not found")
+	}
+
+	baseDidDoc.RemoveVerificationMethod(methodId)
+	return k.updateDidDoc(ctx, baseDidDoc, types.EventTypeRemoveVerificationMethod, did)
+}
+
+func (k Keeper) AddService(ctx sdk.Context, did exported.Did, service exported.Service) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "cannot add a service to a deactivated did")
+	} else if baseDidDoc.HasService(service.Id) {
+		return types.ErrorInvalidService(types.DefaultCodespace, "service already exists")
+	}
+
+	baseDidDoc.AddService(service)
+	return k.updateDidDoc(ctx, baseDidDoc, types.EventTypeAddService, did)
+}
+
+func (k Keeper) RemoveService(ctx sdk.Context, did exported.Did, serviceId string) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "cannot remove a service from a deactivated did")
+	} else if !baseDidDoc.HasService(serviceId) {
+		return types.ErrorInvalidService(types.DefaultCodespace, "service not found")
+	}
+
+	baseDidDoc.RemoveService(serviceId)
+	return k.updateDidDoc(ctx, baseDidDoc, types.EventTypeRemoveService, did)
+}
+
+// GrantDelegation allows the delegate to sign messages within its scopes on
+// behalf of the DID. If the delegate already exists, its scopes are replaced.
+func (k Keeper) GrantDelegation(ctx sdk.Context, did exported.Did, delegate exported.Delegate) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "cannot grant a delegation for a deactivated did")
+	}
+
+	delegateDidDoc, err := k.GetDidDoc(ctx, delegate.Did)
+	if err != nil {
+		return types.ErrorInvalidDelegate(types.DefaultCodespace, "delegate did not found")
+	} else if delegateDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "delegate did is deactivated")
+	}
+
+	baseDidDoc.SetDelegate(delegate)
+	return k.updateDidDoc(ctx, baseDidDoc, types.EventTypeGrantDelegation, did)
+}
+
+// SetMultisigKey sets (or replaces) the DID's multisig key set, after which
+// the DID can only sign messages using a multisignature of the key set
+func (k Keeper) SetMultisigKey(ctx sdk.Context, did exported.Did, multisigKey exported.MultisigKey) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "cannot set the multisig key set of a deactivated did")
+	}
+
+	baseDidDoc.SetMultisigKey(multisigKey)
+	return k.updateDidDoc(ctx, baseDidDoc, types.EventTypeSetMultisigKey, did)
+}
+
+func (k Keeper) RemoveMultisigKey(ctx sdk.Context, did exported.Did) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "cannot remove the multisig key set of a deactivated did")
+	} else if !baseDidDoc.IsMultisig() {
+		return types.ErrorInvalidMultisigKey(types.DefaultCodespace, "did does not have a multisig key set")
+	}
+
+	baseDidDoc.RemoveMultisigKey()
+	return k.updateDidDoc(ctx, baseDidDoc, types.EventTypeRemoveMultisigKey, did)
+}
+
+func (k Keeper) RevokeDelegation(ctx sdk.Context, did, delegateDid exported.Did) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "cannot revoke a delegation of a deactivated did")
+	} else if _, found := baseDidDoc.GetDelegate(delegateDid); !found {
+		return types.ErrorInvalidDelegate(types.DefaultCodespace, "delegate not found")
+	}
+
+	baseDidDoc.RemoveDelegate(delegateDid)
+	return k.updateDidDoc(ctx, baseDidDoc, types.EventTypeRevokeDelegation, did)
+}
+
+func (k Keeper) CheckAccessPolicy(ctx sdk.Context, did exported.Did, policy exported.AccessPolicy) sdk.Error {
+	if policy.IsEmpty() {
+		return nil
+	}
+
+	didDoc, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	// Revoked and expired credentials do not count towards the policy
+	credentials := didDoc.(types.BaseDidDoc).GetValidCredentials(ctx.BlockHeight(), ctx.BlockTime())
+	if !policy.IsSatisfiedBy(did, credentials) {
+		return types.ErrorAccessDenied(types.DefaultCodespace, fmt.Sprintf(
+			"did %s does not hold the credentials required by the access policy", did))
+	}
+
+	return nil
+}
+
+// CheckEncryptedMemo checks that the memo's recipient DID exists and has
+// published the PubKey that the memo was encrypted for as a keyAgreement key
+func (k Keeper) CheckEncryptedMemo(ctx sdk.Context, memo exported.EncryptedMemo) sdk.Error {
+	if memo.IsEmpty() {
+		return nil
+	}
+
+	didDoc, err := k.GetDidDoc(ctx, memo.RecipientDid)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := didDoc.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "memo recipient did is deactivated")
+	}
+	for _, pubKey := range baseDidDoc.GetKeyAgreementPubKeys() {
+		if pubKey == memo.RecipientPubKey {
+			return nil
+		}
+	}
+
+	return types.ErrorInvalidEncryptedMemo(types.DefaultCodespace, fmt.Sprintf(
+		"memo recipient pubKey is not a keyAgreement key of did %s", memo.RecipientDid))
+}
+
+func (k Keeper) GetCredentialSchema(ctx sdk.Context, schemaId string) (types.CredentialSchema, sdk.Error) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetCredentialSchemaKey(schemaId))
+	if bz == nil {
+		return types.CredentialSchema{}, types.ErrorInvalidSchema(
+			types.DefaultCodespace, fmt.Sprintf("credential schema %s not found", schemaId))
+	}
+
+	var schema types.CredentialSchema
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &schema)
+
+	return schema, nil
+}
+
+func (k Keeper) SetCredentialSchema(ctx sdk.Context, schema types.CredentialSchema) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetCredentialSchemaKey(schema.Id), k.cdc.MustMarshalBinaryLengthPrefixed(schema))
+}
+
+func (k Keeper) AddCredentialSchema(ctx sdk.Context, schema types.CredentialSchema) sdk.Error {
+	if _, err := k.GetCredentialSchema(ctx, schema.Id); err == nil {
+		return types.ErrorInvalidSchema(types.DefaultCodespace, "credential schema already exists")
+	}
+
+	// Schemas are immutable once registered, so that existing claims remain valid
+	k.SetCredentialSchema(ctx, schema)
+	return nil
+}
+
+func (k Keeper) GetAllCredentialSchemas(ctx sdk.Context) (schemas []types.CredentialSchema) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.CredentialSchemaKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var schema types.CredentialSchema
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &schema)
+		schemas = append(schemas, schema)
+	}
+
+	return schemas
+}
+
 func (k Keeper) GetAllDidDocs(ctx sdk.Context) (didDocs []exported.DidDoc) {
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, types.DidKey)
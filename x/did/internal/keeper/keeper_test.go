@@ -1,8 +1,10 @@
 package keeper
 
 import (
+	"encoding/base64"
 	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -21,3 +23,697 @@ func TestKeeper(t *testing.T) {
 	_, err = k.GetDidDoc(ctx, types.ValidDidDoc.GetDid())
 	require.Nil(t, err)
 }
+
+func TestKeeperCheckAccessPolicy(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := types.ValidDidDoc.GetDid()
+	issuer := "did:ixo:4XJLBfGtWSGKSz4BeRxdun"
+
+	err := k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+
+	kycPolicy := exported.NewAccessPolicy([]string{"ProofOfKYC"}, []exported.Did{issuer})
+
+	// Empty policy allows any DID, even without credentials
+	require.Nil(t, k.CheckAccessPolicy(ctx, did, exported.AccessPolicy{}))
+
+	// DID without credentials is denied
+	err = k.CheckAccessPolicy(ctx, did, kycPolicy)
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeAccessDenied, int(err.Code()))
+
+	// Credential from an untrusted issuer is not accepted
+	credential := types.NewMsgAddCredential(did, []string{"Credential", "ProofOfKYC"},
+		"did:ixo:UKzkhVSHc3qEFva5EY2XHt", "2020-01-01", "").DidCredential
+	require.Nil(t, k.AddCredentials(ctx, did, credential))
+	require.NotNil(t, k.CheckAccessPolicy(ctx, did, kycPolicy))
+
+	// Credential from the trusted issuer is accepted
+	credential.Issuer = issuer
+	require.Nil(t, k.AddCredentials(ctx, did, credential))
+	require.Nil(t, k.CheckAccessPolicy(ctx, did, kycPolicy))
+
+	// Missing credential type is denied
+	accreditedPolicy := exported.NewAccessPolicy([]string{"ProofOfKYC", "Accredited"}, nil)
+	require.NotNil(t, k.CheckAccessPolicy(ctx, did, accreditedPolicy))
+}
+
+func TestKeeperUpdateDidPubKey(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := types.ValidDidDoc.GetDid()
+	newPubKey := "47mm6LCDAyJmqkbUbqGoZKZkBixjBgvDFRMwQRF9HWMU"
+	ctx = ctx.WithBlockHeight(10)
+
+	// Cannot rotate the PubKey of a non-existent DID
+	err := k.UpdateDidPubKey(ctx, did, newPubKey, "")
+	require.NotNil(t, err)
+
+	err = k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+	oldAddr := k.MustGetDidDoc(ctx, did).Address()
+
+	// Cannot rotate to the current PubKey
+	err = k.UpdateDidPubKey(ctx, did, types.ValidDidDoc.GetPubKey(), "")
+	require.NotNil(t, err)
+
+	// Rotation keeps the DID but changes the PubKey and address
+	err = k.UpdateDidPubKey(ctx, did, newPubKey, "")
+	require.Nil(t, err)
+
+	didDoc := k.MustGetDidDoc(ctx, did).(types.BaseDidDoc)
+	require.Equal(t, did, didDoc.GetDid())
+	require.Equal(t, newPubKey, didDoc.GetPubKey())
+	require.Equal(t, int64(10), didDoc.PubKeyRotationHeight)
+	require.NotEqual(t, oldAddr, didDoc.Address())
+}
+
+func TestKeeperDeactivateDid(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := types.ValidDidDoc.GetDid()
+
+	// Cannot deactivate a non-existent DID
+	err := k.DeactivateDid(ctx, did)
+	require.NotNil(t, err)
+
+	err = k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+	require.False(t, k.MustGetDidDoc(ctx, did).IsDeactivated())
+
+	err = k.DeactivateDid(ctx, did)
+	require.Nil(t, err)
+	require.True(t, k.MustGetDidDoc(ctx, did).IsDeactivated())
+
+	// Deactivated DID is tombstoned: it cannot be deactivated again, re-added,
+	// given credentials, or have its PubKey rotated
+	err = k.DeactivateDid(ctx, did)
+	require.NotNil(t, err)
+	err = k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.NotNil(t, err)
+	credential := types.NewMsgAddCredential(did, []string{"Credential", "ProofOfKYC"},
+		"did:ixo:4XJLBfGtWSGKSz4BeRxdun", "2020-01-01", "").DidCredential
+	err = k.AddCredentials(ctx, did, credential)
+	require.Equal(t, types.CodeDidDeactivated, int(err.Code()))
+	err = k.UpdateDidPubKey(ctx, did, "47mm6LCDAyJmqkbUbqGoZKZkBixjBgvDFRMwQRF9HWMU", "")
+	require.Equal(t, types.CodeDidDeactivated, int(err.Code()))
+}
+
+func TestKeeperVerificationMethodsAndServices(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := types.ValidDidDoc.GetDid()
+
+	method := exported.NewVerificationMethod(did+"#key-2",
+		exported.Ed25519VerificationKey2018, "47mm6LCDAyJmqkbUbqGoZKZkBixjBgvDFRMwQRF9HWMU",
+		[]string{exported.Authentication})
+	service := exported.NewService(did+"#cellnode",
+		"CellNode", "https://cellnode.ixo.world")
+
+	// Cannot add to a non-existent DID
+	err := k.AddVerificationMethod(ctx, did, method)
+	require.NotNil(t, err)
+
+	err = k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+
+	// Add verification method, which becomes an authentication key
+	err = k.AddVerificationMethod(ctx, did, method)
+	require.Nil(t, err)
+	require.Equal(t, []string{method.PublicKeyBase58},
+		k.MustGetDidDoc(ctx, did).GetAuthenticationPubKeys())
+
+	// Cannot add the same verification method again, or one using the PubKey
+	err = k.AddVerificationMethod(ctx, did, method)
+	require.Equal(t, types.CodeInvalidVerificationMethod, int(err.Code()))
+	primary := exported.NewVerificationMethod(did+"#key-3",
+		exported.Ed25519VerificationKey2018, types.ValidDidDoc.PubKey,
+		[]string{exported.Authentication})
+	err = k.AddVerificationMethod(ctx, did, primary)
+	require.Equal(t, types.CodeInvalidVerificationMethod, int(err.Code()))
+
+	// Add service, which cannot be added again
+	err = k.AddService(ctx, did, service)
+	require.Nil(t, err)
+	err = k.AddService(ctx, did, service)
+	require.Equal(t, types.CodeInvalidService, int(err.Code()))
+	require.Equal(t, []exported.Service{service},
+		k.MustGetDidDoc(ctx, did).(types.BaseDidDoc).GetServices())
+
+	// Remove verification method and service, which cannot be removed again
+	err = k.RemoveVerificationMethod(ctx, did, method.Id)
+	require.Nil(t, err)
+	require.Empty(t, k.MustGetDidDoc(ctx, did).GetAuthenticationPubKeys())
+	err = k.RemoveVerificationMethod(ctx, did, method.Id)
+	require.Equal(t, types.CodeInvalidVerificationMethod, int(err.Code()))
+	err = k.RemoveService(ctx, did, service.Id)
+	require.Nil(t, err)
+	require.Empty(t, k.MustGetDidDoc(ctx, did).(types.BaseDidDoc).GetServices())
+	err = k.RemoveService(ctx, did, service.Id)
+	require.Equal(t, types.CodeInvalidService, int(err.Code()))
+}
+
+func TestKeeperRevokeCredential(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := types.ValidDidDoc.GetDid()
+	issuer := "did:ixo:4XJLBfGtWSGKSz4BeRxdun"
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	ctx = ctx.WithBlockHeight(10).WithBlockTime(now)
+
+	err := k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+
+	credential := types.NewMsgAddCredential(did, []string{"Credential", "ProofOfKYC"},
+		issuer, "2020-01-01T00:00:00Z", "2021-01-01T00:00:00Z").DidCredential
+	err = k.AddCredentials(ctx, did, credential)
+	require.Nil(t, err)
+
+	// Credential is assigned an ID and is valid until it expires
+	credentialId := types.NewCredentialId(0)
+	status, err := k.GetCredentialStatus(ctx, did, credentialId, issuer, 10, now)
+	require.Nil(t, err)
+	require.True(t, status.Valid)
+	require.Equal(t, int64(10), status.Credential.IssuedHeight)
+	status, err = k.GetCredentialStatus(ctx, did, credentialId, issuer, 9, now)
+	require.Nil(t, err)
+	require.False(t, status.Valid)
+	status, err = k.GetCredentialStatus(ctx, did, credentialId, issuer, 10, now.AddDate(1, 0, 0))
+	require.Nil(t, err)
+	require.False(t, status.Valid)
+	require.True(t, status.Expired)
+
+	// Status is only reported for the credential's issuer
+	_, err = k.GetCredentialStatus(ctx, did, credentialId, "did:ixo:UKzkhVSHc3qEFva5EY2XHt", 10, now)
+	require.Equal(t, types.CodeInvalidIssuer, int(err.Code()))
+
+	// Same credential cannot be added while still valid
+	err = k.AddCredentials(ctx, did, credential)
+	require.Equal(t, types.CodeInvalidCredentials, int(err.Code()))
+
+	// Only the issuer can revoke the credential
+	ctx = ctx.WithBlockHeight(20)
+	err = k.RevokeCredential(ctx, did, credentialId, "did:ixo:UKzkhVSHc3qEFva5EY2XHt")
+	require.Equal(t, types.CodeInvalidIssuer, int(err.Code()))
+	err = k.RevokeCredential(ctx, did, credentialId, issuer)
+	require.Nil(t, err)
+	err = k.RevokeCredential(ctx, did, credentialId, issuer)
+	require.Equal(t, types.CodeInvalidCredentials, int(err.Code()))
+
+	// Revoked credential is kept but is no longer valid from the revocation height
+	status, err = k.GetCredentialStatus(ctx, did, credentialId, issuer, 19, now)
+	require.Nil(t, err)
+	require.True(t, status.Valid)
+	status, err = k.GetCredentialStatus(ctx, did, credentialId, issuer, 20, now)
+	require.Nil(t, err)
+	require.False(t, status.Valid)
+	require.True(t, status.Revoked)
+	policy := exported.NewAccessPolicy([]string{"ProofOfKYC"}, nil)
+	require.NotNil(t, k.CheckAccessPolicy(ctx, did, policy))
+
+	// Credential can be issued again once revoked, with a new ID
+	err = k.AddCredentials(ctx, did, credential)
+	require.Nil(t, err)
+	require.Len(t, k.MustGetDidDoc(ctx, did).(types.BaseDidDoc).GetCredentials(), 2)
+	require.Nil(t, k.CheckAccessPolicy(ctx, did, policy))
+	_, err = k.GetCredentialStatus(ctx, did, types.NewCredentialId(1), issuer, 20, now)
+	require.Nil(t, err)
+}
+
+func TestKeeperTypedCredential(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := types.ValidDidDoc.GetDid()
+
+	var seed [32]byte
+	issuer, genErr := exported.FromSeed(seed)
+	require.Nil(t, genErr)
+
+	err := k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+	err = k.SetDidDoc(ctx, types.NewBaseDidDoc(issuer.Did, issuer.VerifyKey))
+	require.Nil(t, err)
+
+	schema := types.NewCredentialSchema("accredited-investor-v1", issuer.Did,
+		"Accredited Investor", []types.SchemaField{
+			types.NewSchemaField("jurisdiction", types.FieldTypeString, true),
+			types.NewSchemaField("netWorth", types.FieldTypeNumber, false),
+		})
+	require.Nil(t, schema.Validate())
+
+	newCredential := func(body string) exported.DidCredential {
+		claim := exported.NewClaim(did, schema.Id, body)
+		credential := exported.NewDidCredential([]string{"Credential", "Accredited"},
+			issuer.Did, "2020-01-01T00:00:00Z", "", claim)
+		sig, err := issuer.SignMessage(credential.SignBytes())
+		require.Nil(t, err)
+		credential.Signature = base64.StdEncoding.EncodeToString(sig)
+		return credential
+	}
+
+	// Schema must be registered, and only once
+	err = k.AddCredentials(ctx, did, newCredential(`{"jurisdiction": "ZA"}`))
+	require.Equal(t, types.CodeInvalidSchema, int(err.Code()))
+	require.Nil(t, k.AddCredentialSchema(ctx, schema))
+	err = k.AddCredentialSchema(ctx, schema)
+	require.Equal(t, types.CodeInvalidSchema, int(err.Code()))
+
+	// Claim body must conform to the schema
+	for _, body := range []string{`{}`, `{"jurisdiction": 1}`, `{"jurisdiction": "ZA", "age": 30}`, `[]`} {
+		err = k.AddCredentials(ctx, did, newCredential(body))
+		require.Equal(t, types.CodeInvalidCredentials, int(err.Code()), body)
+	}
+
+	// Credential must be signed by the issuer
+	credential := newCredential(`{"jurisdiction": "ZA", "netWorth": 2000000}`)
+	tampered := credential
+	tampered.Claim.Body = `{"jurisdiction": "US", "netWorth": 2000000}`
+	err = k.AddCredentials(ctx, did, tampered)
+	require.Equal(t, types.CodeInvalidCredentials, int(err.Code()))
+
+	err = k.AddCredentials(ctx, did, credential)
+	require.Nil(t, err)
+
+	// Stored credential can still be verified against its signature
+	stored := k.MustGetDidDoc(ctx, did).(types.BaseDidDoc).GetCredentials()[0]
+	sig, _ := base64.StdEncoding.DecodeString(stored.Signature)
+	require.True(t, issuer.VerifySignedMessage(stored.SignBytes(), sig))
+
+	// Typed claims count towards access policies
+	policy := exported.NewAccessPolicy([]string{"Accredited"}, []exported.Did{issuer.Did})
+	require.Nil(t, k.CheckAccessPolicy(ctx, did, policy))
+}
+
+func TestKeeperCheckIssuerAuthorised(t *testing.T) {
+	ctx, k, _ := CreateTestInput()
+	did := types.ValidDidDoc.GetDid()
+	issuer := "did:ixo:4XJLBfGtWSGKSz4BeRxdun"
+
+	credential := types.NewMsgAddCredential(did, []string{"Credential", "ProofOfKYC"},
+		"did:ixo:UKzkhVSHc3qEFva5EY2XHt", "2020-01-01", "").DidCredential
+
+	// Any issuer is authorised by default
+	require.Nil(t, k.CheckIssuerAuthorised(ctx, credential))
+
+	// Restricted credential type only accepted from trusted issuer
+	k.SetParams(ctx, types.NewParams([]types.TrustedIssuer{
+		types.NewTrustedIssuer("ProofOfKYC", []exported.Did{issuer}),
+	}, false, 0))
+	err := k.CheckIssuerAuthorised(ctx, credential)
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeInvalidIssuer, int(err.Code()))
+
+	credential.Issuer = issuer
+	require.Nil(t, k.CheckIssuerAuthorised(ctx, credential))
+}
+
+func TestKeeperDidKey(t *testing.T) {
+	ctx, k, _ := CreateTestInput()
+	pubKey := types.ValidDidDoc.GetPubKey()
+	did := exported.DidKeyFromPubKey(pubKey)
+	require.True(t, types.IsValidDid(did))
+
+	// did:key dids cannot be resolved by default
+	_, err := k.GetDidDoc(ctx, did)
+	require.NotNil(t, err)
+
+	// Once enabled, the did doc is resolved from the did
+	k.SetParams(ctx, types.NewParams(nil, true, 0))
+	didDoc, err := k.GetDidDoc(ctx, did)
+	require.Nil(t, err)
+	require.Equal(t, did, didDoc.GetDid())
+	require.Equal(t, pubKey, didDoc.GetPubKey())
+	require.Equal(t, exported.VerifyKeyToAddr(pubKey), didDoc.Address())
+
+	// Changes to the did doc are stored
+	require.Nil(t, k.DeactivateDid(ctx, did))
+	didDoc, err = k.GetDidDoc(ctx, did)
+	require.Nil(t, err)
+	require.True(t, didDoc.IsDeactivated())
+
+	// The address of the deactivated did:key DID can be used by another DID
+	require.Nil(t, k.SetDidDoc(ctx, &types.ValidDidDoc))
+	indexedDid, err := k.GetDidByAddress(ctx, didDoc.Address())
+	require.Nil(t, err)
+	require.Equal(t, types.ValidDidDoc.Did, indexedDid)
+
+	// A did:key DID cannot be stored if its address is used by an active DID
+	otherPubKey := "47mm6LCDAyJmqkbUbqGoZKZkBixjBgvDFRMwQRF9HWMU"
+	otherDidDoc := types.NewBaseDidDoc("did:ixo:4XJLBfGtWSGKSz4BeRxdun", otherPubKey)
+	require.Nil(t, k.SetDidDoc(ctx, otherDidDoc))
+	require.NotNil(t, k.DeactivateDid(ctx, exported.DidKeyFromPubKey(otherPubKey)))
+	indexedDid, err = k.GetDidByAddress(ctx, otherDidDoc.Address())
+	require.Nil(t, err)
+	require.Equal(t, otherDidDoc.Did, indexedDid)
+}
+
+func TestKeeperDelegation(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := types.ValidDidDoc.GetDid()
+	delegateDidDoc := types.NewBaseDidDoc("did:ixo:4XJLBfGtWSGKSz4BeRxdun",
+		"2vMHhssdhrBCRFiq9vj7TxGYDybW4yYdrYh9JG56RaAt")
+	delegate := exported.NewDelegate(delegateDidDoc.Did, []string{"bonds", "payments/create-payment-contract"})
+
+	err := k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+
+	// Delegate DID has to exist
+	err = k.GrantDelegation(ctx, did, delegate)
+	require.Equal(t, types.CodeInvalidDelegate, int(err.Code()))
+	err = k.SetDidDoc(ctx, delegateDidDoc)
+	require.Nil(t, err)
+
+	// Delegate can only sign messages within its scopes
+	err = k.GrantDelegation(ctx, did, delegate)
+	require.Nil(t, err)
+	didDoc := k.MustGetDidDoc(ctx, did)
+	require.Equal(t, []exported.Did{delegate.Did}, didDoc.GetDelegateDids("bonds", "buy"))
+	require.Equal(t, []exported.Did{delegate.Did}, didDoc.GetDelegateDids("payments", "create-payment-contract"))
+	require.Empty(t, didDoc.GetDelegateDids("payments", "effect-payment"))
+	require.Empty(t, didDoc.GetDelegateDids("did", "add-credential"))
+
+	// Scopes cannot cover key management messages
+	delegate.Scopes = []string{"did"}
+	require.NotNil(t, types.ValidateDelegate(did, delegate))
+	delegate.Scopes = []string{"did/update-did-pub-key"}
+	require.NotNil(t, types.ValidateDelegate(did, delegate))
+	delegate.Scopes = []string{"did/add-verification-method"}
+	require.NotNil(t, types.ValidateDelegate(did, delegate))
+
+	// Granting the delegation again replaces the scopes
+	delegate.Scopes = []string{"did/add-credential"}
+	err = k.GrantDelegation(ctx, did, delegate)
+	require.Nil(t, err)
+	didDoc = k.MustGetDidDoc(ctx, did)
+	require.Equal(t, []exported.Delegate{delegate}, didDoc.(types.BaseDidDoc).GetDelegates())
+	require.Empty(t, didDoc.GetDelegateDids("bonds", "buy"))
+
+	// Revoke delegation, which cannot be revoked again
+	err = k.RevokeDelegation(ctx, did, delegate.Did)
+	require.Nil(t, err)
+	require.Empty(t, k.MustGetDidDoc(ctx, did).GetDelegateDids("did", "add-credential"))
+	err = k.RevokeDelegation(ctx, did, delegate.Did)
+	require.Equal(t, types.CodeInvalidDelegate, int(err.Code()))
+}
+
+func TestKeeperSecp256k1DidDoc(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	ixoDid, genErr := exported.GenSecp256k1()
+	require.Nil(t, genErr)
+
+	didDoc := types.NewBaseDidDoc(ixoDid.Did, ixoDid.VerifyKey)
+	didDoc.KeyType = ixoDid.KeyType
+	err := k.SetDidDoc(ctx, didDoc)
+	require.Nil(t, err)
+
+	// Address is derived from the secp256k1 PubKey
+	stored := k.MustGetDidDoc(ctx, ixoDid.Did)
+	require.Equal(t, exported.Secp256k1KeyType, stored.GetKeyType())
+	require.Equal(t, ixoDid.Address(), stored.Address())
+
+	// Rotation can change the key type
+	err = k.UpdateDidPubKey(ctx, ixoDid.Did, types.ValidDidDoc.PubKey, "")
+	require.Nil(t, err)
+	stored = k.MustGetDidDoc(ctx, ixoDid.Did)
+	require.Equal(t, exported.Ed25519KeyType, stored.GetKeyType())
+	require.Equal(t, types.ValidDidDoc.Address(), stored.Address())
+}
+
+func TestKeeperMultisigKey(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := types.ValidDidDoc.GetDid()
+	singleKeyAddress := types.ValidDidDoc.Address()
+	multisigKey := exported.NewMultisigKey(2, []string{
+		"96UYka2KZEw3nNb58GfP48wPeBUjPrUFrM4AnFhoBzqx",
+		"2vMHhssdhrBCRFiq9vj7TxGYDybW4yYdrYh9JG56RaAt",
+		"Gu9zs4ygJh2KafMSm3tbpTfiD2KDXDbfhXoWpNbr7ALz",
+	})
+
+	err := k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+
+	// DID address becomes the address of the multisig key set
+	err = k.SetMultisigKey(ctx, did, multisigKey)
+	require.Nil(t, err)
+	didDoc := k.MustGetDidDoc(ctx, did)
+	require.Equal(t, multisigKey, didDoc.GetMultisigKey())
+	require.Equal(t, multisigKey.Address(), didDoc.Address())
+	require.NotEqual(t, singleKeyAddress, didDoc.Address())
+
+	// Remove key set, which cannot be removed again
+	err = k.RemoveMultisigKey(ctx, did)
+	require.Nil(t, err)
+	didDoc = k.MustGetDidDoc(ctx, did)
+	require.True(t, didDoc.GetMultisigKey().IsEmpty())
+	require.Equal(t, singleKeyAddress, didDoc.Address())
+	err = k.RemoveMultisigKey(ctx, did)
+	require.Equal(t, types.CodeInvalidMultisigKey, int(err.Code()))
+}
+
+func TestKeeperCheckEncryptedMemo(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := types.ValidDidDoc.GetDid()
+
+	sender, err := exported.Gen()
+	require.Nil(t, err)
+	recipient, err := exported.Gen()
+	require.Nil(t, err)
+	memo, err := exported.EncryptMemo(sender, did, recipient.EncryptionPublicKey, "memo")
+	require.Nil(t, err)
+
+	// Empty memos are always valid, but the recipient DID has to exist
+	require.Nil(t, k.CheckEncryptedMemo(ctx, exported.EncryptedMemo{}))
+	require.NotNil(t, k.CheckEncryptedMemo(ctx, memo))
+
+	// Recipient has to have published the key as a keyAgreement key
+	err = k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+	sdkErr := k.CheckEncryptedMemo(ctx, memo)
+	require.Equal(t, types.CodeInvalidEncryptedMemo, int(sdkErr.Code()))
+
+	method := exported.NewVerificationMethod(did+"#encryption-key",
+		exported.X25519KeyAgreementKey2019, recipient.EncryptionPublicKey,
+		[]string{exported.KeyAgreement})
+	err = k.AddVerificationMethod(ctx, did, method)
+	require.Nil(t, err)
+	require.Nil(t, k.CheckEncryptedMemo(ctx, memo))
+}
+
+func TestKeeperAddressIndex(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := types.ValidDidDoc.GetDid()
+	oldAddr := types.ValidDidDoc.Address()
+
+	_, err := k.GetDidByAddress(ctx, oldAddr)
+	require.NotNil(t, err)
+
+	err = k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+	indexedDid, err := k.GetDidByAddress(ctx, oldAddr)
+	require.Nil(t, err)
+	require.Equal(t, did, indexedDid)
+
+	// Rotating the PubKey moves the DID to the new address
+	err = k.UpdateDidPubKey(ctx, did, "47mm6LCDAyJmqkbUbqGoZKZkBixjBgvDFRMwQRF9HWMU", "")
+	require.Nil(t, err)
+	newAddr := k.MustGetDidDoc(ctx, did).Address()
+	_, err = k.GetDidByAddress(ctx, oldAddr)
+	require.NotNil(t, err)
+	indexedDid, err = k.GetDidByAddress(ctx, newAddr)
+	require.Nil(t, err)
+	require.Equal(t, did, indexedDid)
+
+	// A DID cannot be added with, or rotated to, an address used by another
+	// active DID
+	otherDid := "did:ixo:4XJLBfGtWSGKSz4BeRxdun"
+	newPubKey := "47mm6LCDAyJmqkbUbqGoZKZkBixjBgvDFRMwQRF9HWMU"
+	err = k.SetDidDoc(ctx, types.NewBaseDidDoc(otherDid, newPubKey))
+	require.NotNil(t, err)
+	err = k.SetDidDoc(ctx, types.NewBaseDidDoc(otherDid, types.ValidDidDoc.PubKey))
+	require.Nil(t, err)
+	err = k.UpdateDidPubKey(ctx, otherDid, newPubKey, "")
+	require.NotNil(t, err)
+	indexedDid, err = k.GetDidByAddress(ctx, newAddr)
+	require.Nil(t, err)
+	require.Equal(t, did, indexedDid)
+
+	// Other changes to the DID doc keep the index entry
+	err = k.DeactivateDid(ctx, did)
+	require.Nil(t, err)
+	indexedDid, err = k.GetDidByAddress(ctx, newAddr)
+	require.Nil(t, err)
+	require.Equal(t, did, indexedDid)
+
+	// The address of a deactivated DID can be used by another DID
+	err = k.UpdateDidPubKey(ctx, otherDid, newPubKey, "")
+	require.Nil(t, err)
+	indexedDid, err = k.GetDidByAddress(ctx, newAddr)
+	require.Nil(t, err)
+	require.Equal(t, otherDid, indexedDid)
+}
+
+func TestKeeperDidDocHistory(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := types.ValidDidDoc.GetDid()
+	oldPubKey := types.ValidDidDoc.GetPubKey()
+
+	err := k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	err = k.UpdateDidPubKey(ctx, did, "47mm6LCDAyJmqkbUbqGoZKZkBixjBgvDFRMwQRF9HWMU", "")
+	require.Nil(t, err)
+
+	// Each change is recorded as a version, with the change type and actor
+	history := k.GetDidDocHistory(ctx, did)
+	require.Len(t, history, 2)
+	require.Equal(t, uint64(1), history[0].VersionId)
+	require.Equal(t, types.EventTypeAddDidDoc, history[0].ChangeType)
+	require.Equal(t, oldPubKey, history[0].DidDoc.PubKey)
+	require.Equal(t, uint64(2), history[1].VersionId)
+	require.Equal(t, ctx.BlockHeight(), history[1].Height)
+	require.Equal(t, types.EventTypeUpdateDidPubKey, history[1].ChangeType)
+	require.Equal(t, did, history[1].Actor)
+
+	version, err := k.GetDidDocVersion(ctx, did, 1)
+	require.Nil(t, err)
+	require.Equal(t, oldPubKey, version.DidDoc.PubKey)
+	_, err = k.GetDidDocVersion(ctx, did, 3)
+	require.NotNil(t, err)
+
+	// Once pruning is enabled, only the most recent versions are kept
+	k.SetParams(ctx, types.NewParams(nil, false, 2))
+	err = k.DeactivateDid(ctx, did)
+	require.Nil(t, err)
+	history = k.GetDidDocHistory(ctx, did)
+	require.Len(t, history, 2)
+	require.Equal(t, uint64(2), history[0].VersionId)
+	require.Equal(t, uint64(3), history[1].VersionId)
+	require.True(t, history[1].DidDoc.IsDeactivated())
+}
+
+func TestKeeperDidDocHistoryGenesis(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	did := "did:ixo:4XJLBfGtWSGKSz4BeRxdun"
+
+	err := k.SetDidDoc(ctx, types.NewBaseDidDoc(did, types.ValidDidDoc.GetPubKey()))
+	require.Nil(t, err)
+	err = k.UpdateDidPubKey(ctx, did, "47mm6LCDAyJmqkbUbqGoZKZkBixjBgvDFRMwQRF9HWMU", "")
+	require.Nil(t, err)
+
+	// Genesis history must be of known DIDs and within the versions bound
+	genesisState := types.GenesisState{
+		DidDocs:       k.GetAllDidDocs(ctx),
+		Params:        k.GetParams(ctx),
+		DidDocHistory: k.GetAllDidDocVersions(ctx),
+	}
+	require.Nil(t, types.ValidateGenesis(genesisState))
+	genesisState.Params.MaxDidDocVersions = 1
+	require.NotNil(t, types.ValidateGenesis(genesisState))
+	genesisState.Params.MaxDidDocVersions = 0
+	require.Nil(t, types.ValidateGenesis(genesisState))
+	genesisState.DidDocs = nil
+	require.NotNil(t, types.ValidateGenesis(genesisState))
+}
+
+func TestKeeperRecovery(t *testing.T) {
+	ctx, k, cdc := CreateTestInput()
+	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
+	ctx = ctx.WithBlockTime(time.Now())
+	did := types.ValidDidDoc.GetDid()
+	newPubKey := "47mm6LCDAyJmqkbUbqGoZKZkBixjBgvDFRMwQRF9HWMU"
+
+	var seed [32]byte
+	guardian1, genErr := exported.FromSeed(seed)
+	require.Nil(t, genErr)
+	guardian2 := "did:ixo:4XJLBfGtWSGKSz4BeRxdun"
+	guardians := exported.NewGuardians(2, []exported.Did{guardian1.Did, guardian2}, time.Hour)
+
+	err := k.SetDidDoc(ctx, &types.ValidDidDoc)
+	require.Nil(t, err)
+	err = k.SetDidDoc(ctx, types.NewBaseDidDoc(guardian1.Did, guardian1.VerifyKey))
+	require.Nil(t, err)
+
+	// Guardian DIDs have to exist
+	err = k.SetGuardians(ctx, did, guardians)
+	require.Equal(t, types.CodeInvalidGuardians, int(err.Code()))
+	err = k.SetDidDoc(ctx, types.NewBaseDidDoc(guardian2,
+		"2vMHhssdhrBCRFiq9vj7TxGYDybW4yYdrYh9JG56RaAt"))
+	require.Nil(t, err)
+	err = k.SetGuardians(ctx, did, guardians)
+	require.Nil(t, err)
+
+	// Additional signing keys and delegates, which may have been compromised
+	authMethod := exported.NewVerificationMethod(did+"#key-2",
+		exported.Ed25519VerificationKey2018, "3ahLFJrxjamFFBnhNKtGzdGRWTyt9ogGYTdMQpbtKfKx",
+		[]string{exported.Authentication, exported.AssertionMethod})
+	agreementMethod := exported.NewVerificationMethod(did+"#key-3",
+		exported.X25519KeyAgreementKey2019, "5Va8V5gSHzkCVVgVoR3G9pDWHCrSAGDA3iNNvFqC2ZRN",
+		[]string{exported.KeyAgreement})
+	err = k.AddVerificationMethod(ctx, did, authMethod)
+	require.Nil(t, err)
+	err = k.AddVerificationMethod(ctx, did, agreementMethod)
+	require.Nil(t, err)
+	err = k.GrantDelegation(ctx, did, exported.NewDelegate(guardian2, []string{"bonds"}))
+	require.Nil(t, err)
+
+	// Only guardians can start a recovery
+	_, err = k.StartRecovery(ctx, did, did, newPubKey, "")
+	require.Equal(t, types.CodeInvalidGuardians, int(err.Code()))
+	recovery, err := k.StartRecovery(ctx, did, guardian1.Did, newPubKey, "")
+	require.Nil(t, err)
+	require.False(t, recovery.IsApproved())
+	_, err = k.StartRecovery(ctx, did, guardian2, newPubKey, "")
+	require.Equal(t, types.CodeInvalidRecovery, int(err.Code()))
+
+	// Guardians cannot be changed during a recovery
+	err = k.SetGuardians(ctx, did, exported.Guardians{})
+	require.Equal(t, types.CodeInvalidRecovery, int(err.Code()))
+
+	// The DID can cancel the recovery
+	err = k.CancelRecovery(ctx, did)
+	require.Nil(t, err)
+	require.False(t, k.HasRecovery(ctx, did))
+
+	// Once the threshold is reached, the recovery delay starts
+	_, err = k.StartRecovery(ctx, did, guardian1.Did, newPubKey, "")
+	require.Nil(t, err)
+	_, err = k.ApproveRecovery(ctx, did, guardian2, types.ValidDidDoc.PubKey)
+	require.Equal(t, types.CodeInvalidRecovery, int(err.Code()))
+	recovery, err = k.ApproveRecovery(ctx, did, guardian2, newPubKey)
+	require.Nil(t, err)
+	require.True(t, recovery.IsApproved())
+	require.False(t, recovery.ShouldExecute(ctx.BlockTime()))
+	require.True(t, recovery.ShouldExecute(ctx.BlockTime().Add(time.Hour)))
+
+	// Executing the recovery installs the new PubKey
+	err = k.ExecuteRecovery(ctx, recovery)
+	require.Nil(t, err)
+	require.Equal(t, newPubKey, k.MustGetDidDoc(ctx, did).GetPubKey())
+	require.False(t, k.HasRecovery(ctx, did))
+
+	// Pre-recovery authentication/assertion keys and delegates can no longer
+	// sign on behalf of the DID, but other verification methods are kept
+	didDoc := k.MustGetDidDoc(ctx, did).(types.BaseDidDoc)
+	require.Empty(t, didDoc.GetAuthenticationPubKeys())
+	require.Len(t, didDoc.GetAssertionPubKeys(), 1)
+	require.Empty(t, didDoc.GetDelegateDids("bonds", "buy"))
+	require.Equal(t, []exported.VerificationMethod{agreementMethod}, didDoc.VerificationMethods)
+	history := k.GetDidDocHistory(ctx, did)
+	require.Equal(t, types.EventTypeRecoverDid, history[len(history)-1].ChangeType)
+	require.Equal(t, guardian1.Did, history[len(history)-1].Actor)
+
+	// Deactivating the DID deletes any recovery
+	_, err = k.StartRecovery(ctx, did, guardian1.Did, types.ValidDidDoc.PubKey, "")
+	require.Nil(t, err)
+	err = k.DeactivateDid(ctx, did)
+	require.Nil(t, err)
+	require.False(t, k.HasRecovery(ctx, did))
+}
@@ -6,13 +6,27 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"strconv"
 )
 
 const (
 	QueryDidDoc     = "queryDidDoc"
 	QueryAllDids    = "queryAllDids"
 	QueryAllDidDocs = "queryAllDidDocs"
+	QueryAddress    = "queryAddress"
+
+	QueryDidDocHistory = "queryDidDocHistory"
+	QueryDidDocVersion = "queryDidDocVersion"
+	QueryRecovery      = "queryRecovery"
+
+	QueryCredentialStatus     = "queryCredentialStatus"
+	QueryCredentials          = "queryCredentials"
+	QueryCredentialSchema     = "queryCredentialSchema"
+	QueryAllCredentialSchemas = "queryAllCredentialSchemas"
+	QueryParams               = "queryParams"
+	QueryTrustedIssuers       = "queryTrustedIssuers"
 )
 
 func NewQuerier(k Keeper) sdk.Querier {
@@ -24,6 +38,26 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return queryAllDids(ctx, k)
 		case QueryAllDidDocs:
 			return queryAllDidDocs(ctx, k)
+		case QueryAddress:
+			return queryDidByAddress(ctx, path[1:], k)
+		case QueryDidDocHistory:
+			return queryDidDocHistory(ctx, path[1:], k)
+		case QueryDidDocVersion:
+			return queryDidDocVersion(ctx, path[1:], k)
+		case QueryRecovery:
+			return queryRecovery(ctx, path[1:], k)
+		case QueryCredentialStatus:
+			return queryCredentialStatus(ctx, req, k)
+		case QueryCredentials:
+			return queryCredentials(ctx, path[1:], k)
+		case QueryCredentialSchema:
+			return queryCredentialSchema(ctx, path[1:], k)
+		case QueryAllCredentialSchemas:
+			return queryAllCredentialSchemas(ctx, k)
+		case QueryParams:
+			return queryParams(ctx, k)
+		case QueryTrustedIssuers:
+			return queryTrustedIssuers(ctx, path[1:], k)
 		default:
 			return nil, sdk.ErrUnknownRequest("Unknown did query endpoint")
 		}
@@ -44,6 +78,76 @@ func queryDidDoc(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
 	return res, nil
 }
 
+func queryDidDocHistory(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	history := k.GetDidDocHistory(ctx, path[0])
+	if history == nil {
+		history = []types.DidDocVersion{}
+	}
+
+	res, errRes := codec.MarshalJSONIndent(k.cdc, history)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal data %s", errRes))
+	}
+
+	return res, nil
+}
+
+func queryDidDocVersion(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	if len(path) < 2 {
+		return nil, sdk.ErrUnknownRequest("did and version id are required")
+	}
+
+	versionId, err := strconv.ParseUint(path[1], 10, 64)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid version id %s", path[1]))
+	}
+
+	version, sdkErr := k.GetDidDocVersion(ctx, path[0], versionId)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	res, errRes := codec.MarshalJSONIndent(k.cdc, version)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal data %s", errRes))
+	}
+
+	return res, nil
+}
+
+func queryRecovery(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	recovery, err := k.GetRecovery(ctx, path[0])
+	if err != nil {
+		return nil, err
+	}
+
+	res, errRes := codec.MarshalJSONIndent(k.cdc, recovery)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal data %s", errRes))
+	}
+
+	return res, nil
+}
+
+func queryDidByAddress(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	address, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(err.Error())
+	}
+
+	did, sdkErr := k.GetDidByAddress(ctx, address)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	res, errRes := json.Marshal(did)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal data %s", errRes.Error()))
+	}
+
+	return res, nil
+}
+
 func queryAllDids(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
 	allDids := k.GetAddDids(ctx)
 
@@ -66,3 +170,97 @@ func queryAllDidDocs(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
 
 	return res, nil
 }
+
+func queryCredentialStatus(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryCredentialStatusParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("failed to parse params: %s", err))
+	}
+
+	// Default to the latest height and time
+	height := params.Height
+	if height == 0 {
+		height = ctx.BlockHeight()
+	}
+	t := params.Time
+	if t.IsZero() {
+		t = ctx.BlockTime()
+	}
+
+	status, err := k.GetCredentialStatus(ctx, params.Did, params.CredentialId, params.Issuer, height, t)
+	if err != nil {
+		return nil, err
+	}
+
+	res, errRes := codec.MarshalJSONIndent(k.cdc, status)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal data %s", errRes))
+	}
+
+	return res, nil
+}
+
+func queryCredentials(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	didDoc, err := k.GetDidDoc(ctx, path[0])
+	if err != nil {
+		return nil, err
+	}
+
+	res, errRes := codec.MarshalJSONIndent(k.cdc, didDoc.(types.BaseDidDoc).GetCredentials())
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal data %s", errRes))
+	}
+
+	return res, nil
+}
+
+func queryCredentialSchema(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	schema, err := k.GetCredentialSchema(ctx, path[0])
+	if err != nil {
+		return nil, err
+	}
+
+	res, errRes := codec.MarshalJSONIndent(k.cdc, schema)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal data %s", errRes))
+	}
+
+	return res, nil
+}
+
+func queryAllCredentialSchemas(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	schemas := k.GetAllCredentialSchemas(ctx)
+
+	res, errRes := codec.MarshalJSONIndent(k.cdc, schemas)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal data %s", errRes))
+	}
+
+	return res, nil
+}
+
+func queryParams(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	params := k.GetParams(ctx)
+
+	res, err := codec.MarshalJSONIndent(k.cdc, params)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal JSON", err.Error()))
+	}
+
+	return res, nil
+}
+
+func queryTrustedIssuers(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	issuers, restricted := k.GetParams(ctx).GetTrustedIssuers(path[0])
+	if !restricted {
+		return nil, types.ErrorInvalidCredentials(types.DefaultCodespace, fmt.Sprintf(
+			"credential type %s can be issued by any did", path[0]))
+	}
+
+	res, err := codec.MarshalJSONIndent(k.cdc, issuers)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal JSON", err.Error()))
+	}
+
+	return res, nil
+}
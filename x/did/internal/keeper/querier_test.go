@@ -40,4 +40,7 @@ func TestQueryDidDocs(t *testing.T) {
 
 	_, _ = cdc.MarshalJSONIndent(b, "", " ")
 
+	resA, err := querier(ctx, []string{"queryAddress", types.ValidDidDoc.Address().String()}, query)
+	require.Nil(t, err)
+	require.Equal(t, `"`+types.ValidDidDoc.Did+`"`, string(resA))
 }
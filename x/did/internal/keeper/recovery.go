@@ -0,0 +1,205 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+)
+
+// SetGuardians sets (or, if empty, removes) the guardians of a DID. The
+// guardians cannot be changed while a recovery is in progress.
+func (k Keeper) SetGuardians(ctx sdk.Context, did exported.Did, guardians exported.Guardians) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "cannot set the guardians of a deactivated did")
+	} else if k.HasRecovery(ctx, did) {
+		return types.ErrorInvalidRecovery(types.DefaultCodespace,
+			"cannot set guardians while a recovery is in progress")
+	}
+
+	for _, guardian := range guardians.Dids {
+		guardianDidDoc, err := k.GetDidDoc(ctx, guardian)
+		if err != nil {
+			return types.ErrorInvalidGuardians(types.DefaultCodespace,
+				fmt.Sprintf("guardian %s not found", guardian))
+		} else if guardianDidDoc.IsDeactivated() {
+			return types.ErrorDidDeactivated(types.DefaultCodespace,
+				fmt.Sprintf("guardian %s is deactivated", guardian))
+		}
+	}
+
+	baseDidDoc.SetGuardians(guardians)
+	return k.updateDidDoc(ctx, baseDidDoc, types.EventTypeSetGuardians, did)
+}
+
+func (k Keeper) HasRecovery(ctx sdk.Context, did exported.Did) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetRecoveryKey(did))
+}
+
+func (k Keeper) GetRecovery(ctx sdk.Context, did exported.Did) (types.Recovery, sdk.Error) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetRecoveryKey(did))
+	if bz == nil {
+		return types.Recovery{}, types.ErrorInvalidRecovery(types.DefaultCodespace,
+			fmt.Sprintf("no recovery in progress for did %s", did))
+	}
+
+	var recovery types.Recovery
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &recovery)
+	return recovery, nil
+}
+
+func (k Keeper) SetRecovery(ctx sdk.Context, recovery types.Recovery) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetRecoveryKey(recovery.Did), k.cdc.MustMarshalBinaryLengthPrefixed(recovery))
+}
+
+func (k Keeper) deleteRecovery(ctx sdk.Context, did exported.Did) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetRecoveryKey(did))
+}
+
+func (k Keeper) GetAllRecoveries(ctx sdk.Context) (recoveries []types.Recovery) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.RecoveryKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var recovery types.Recovery
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &recovery)
+		recoveries = append(recoveries, recovery)
+	}
+
+	return recoveries
+}
+
+// getGuardians returns the guardians of a DID that can be recovered
+func (k Keeper) getGuardians(ctx sdk.Context, did exported.Did) (exported.Guardians, sdk.Error) {
+	existedDid, err := k.GetDidDoc(ctx, did)
+	if err != nil {
+		return exported.Guardians{}, err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return exported.Guardians{}, types.ErrorDidDeactivated(types.DefaultCodespace,
+			"cannot recover a deactivated did")
+	} else if baseDidDoc.GetGuardians().IsEmpty() {
+		return exported.Guardians{}, types.ErrorInvalidGuardians(types.DefaultCodespace,
+			fmt.Sprintf("did %s has no guardians", did))
+	}
+	return baseDidDoc.GetGuardians(), nil
+}
+
+// approve adds the guardian's approval to the recovery. Once the threshold is
+// reached, the recovery delay starts.
+func (k Keeper) approve(ctx sdk.Context, recovery *types.Recovery,
+	guardians exported.Guardians, guardian exported.Did) {
+	if !recovery.HasApproved(guardian) {
+		recovery.Approvals = append(recovery.Approvals, guardian)
+	}
+	if !recovery.IsApproved() && uint(len(recovery.Approvals)) >= guardians.Threshold {
+		recovery.ExecutionTime = ctx.BlockTime().Add(guardians.RecoveryDelay)
+	}
+}
+
+// StartRecovery starts the recovery of a DID by one of its guardians, which
+// counts as the guardian's approval
+func (k Keeper) StartRecovery(ctx sdk.Context, did, guardian exported.Did,
+	pubKey, keyType string) (types.Recovery, sdk.Error) {
+	guardians, err := k.getGuardians(ctx, did)
+	if err != nil {
+		return types.Recovery{}, err
+	} else if !guardians.IsGuardian(guardian) {
+		return types.Recovery{}, types.ErrorInvalidGuardians(types.DefaultCodespace,
+			fmt.Sprintf("%s is not a guardian of did %s", guardian, did))
+	} else if k.HasRecovery(ctx, did) {
+		return types.Recovery{}, types.ErrorInvalidRecovery(types.DefaultCodespace,
+			"a recovery is already in progress")
+	} else if k.MustGetDidDoc(ctx, did).GetPubKey() == pubKey {
+		return types.Recovery{}, types.ErrorInvalidPubKey(types.DefaultCodespace,
+			"pubKey is already the did's pubKey")
+	}
+
+	recovery := types.NewRecovery(did, pubKey, keyType, guardian, ctx.BlockTime())
+	k.approve(ctx, &recovery, guardians, guardian)
+	k.SetRecovery(ctx, recovery)
+
+	return recovery, nil
+}
+
+// ApproveRecovery adds a guardian's approval to the DID's recovery. The
+// guardian has to approve the same PubKey that the recovery installs, so that
+// an approval cannot be applied to a different recovery by mistake.
+func (k Keeper) ApproveRecovery(ctx sdk.Context, did, guardian exported.Did,
+	pubKey string) (types.Recovery, sdk.Error) {
+	guardians, err := k.getGuardians(ctx, did)
+	if err != nil {
+		return types.Recovery{}, err
+	} else if !guardians.IsGuardian(guardian) {
+		return types.Recovery{}, types.ErrorInvalidGuardians(types.DefaultCodespace,
+			fmt.Sprintf("%s is not a guardian of did %s", guardian, did))
+	}
+
+	recovery, err := k.GetRecovery(ctx, did)
+	if err != nil {
+		return types.Recovery{}, err
+	} else if recovery.PubKey != pubKey {
+		return types.Recovery{}, types.ErrorInvalidRecovery(types.DefaultCodespace,
+			"pubKey does not match the recovery's pubKey")
+	} else if recovery.HasApproved(guardian) {
+		return types.Recovery{}, types.ErrorInvalidRecovery(types.DefaultCodespace,
+			fmt.Sprintf("guardian %s has already approved the recovery", guardian))
+	}
+
+	k.approve(ctx, &recovery, guardians, guardian)
+	k.SetRecovery(ctx, recovery)
+
+	return recovery, nil
+}
+
+// CancelRecovery cancels the DID's recovery, which can be done by the DID
+// (i.e. using its current key) until the recovery is executed
+func (k Keeper) CancelRecovery(ctx sdk.Context, did exported.Did) sdk.Error {
+	if !k.HasRecovery(ctx, did) {
+		return types.ErrorInvalidRecovery(types.DefaultCodespace,
+			fmt.Sprintf("no recovery in progress for did %s", did))
+	}
+
+	k.deleteRecovery(ctx, did)
+	return nil
+}
+
+// ExecuteRecovery installs the recovery's PubKey as the DID's PubKey. Any
+// multisig key set, authentication and assertion verification methods, and
+// delegates of the DID are removed, since these may have been compromised
+// together with the lost key, so that the DID is only controlled by the
+// recovered key.
+func (k Keeper) ExecuteRecovery(ctx sdk.Context, recovery types.Recovery) sdk.Error {
+	existedDid, err := k.GetDidDoc(ctx, recovery.Did)
+	if err != nil {
+		return err
+	}
+
+	baseDidDoc := existedDid.(types.BaseDidDoc)
+	if baseDidDoc.IsDeactivated() {
+		return types.ErrorDidDeactivated(types.DefaultCodespace, "cannot recover a deactivated did")
+	}
+
+	baseDidDoc.RotatePubKey(recovery.PubKey, recovery.KeyType, ctx.BlockHeight())
+	baseDidDoc.RemoveMultisigKey()
+	baseDidDoc.RemoveSigningVerificationMethods()
+	baseDidDoc.RemoveAllDelegates()
+	if err := k.updateDidDoc(ctx, baseDidDoc, types.EventTypeRecoverDid, recovery.Approvals[0]); err != nil {
+		return err
+	}
+	k.deleteRecovery(ctx, recovery.Did)
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	dbm "github.com/tendermint/tm-db"
@@ -13,14 +14,21 @@ import (
 
 func CreateTestInput() (sdk.Context, Keeper, *codec.Codec) {
 	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+	keyParams := sdk.NewKVStoreKey("subspace")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(storeKey, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeIAVL, nil)
 	_ = ms.LoadLatestVersion()
 	ctx := sdk.NewContext(ms, abci.Header{}, true, log.NewNopLogger())
 	cdc := codec.New()
-	keeper := NewKeeper(cdc, storeKey)
+
+	pk1 := params.NewKeeper(cdc, keyParams, tkeyParams, params.DefaultCodespace)
+	keeper := NewKeeper(cdc, storeKey, pk1.Subspace(types.DefaultParamspace))
+	keeper.SetParams(ctx, types.DefaultParams())
 
 	return ctx, keeper, cdc
 }
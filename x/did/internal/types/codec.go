@@ -8,6 +8,22 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgAddDid{}, "did/AddDid", nil)
 	cdc.RegisterConcrete(MsgAddCredential{}, "did/AddCredential", nil)
+	cdc.RegisterConcrete(MsgUpdateDidPubKey{}, "did/UpdateDidPubKey", nil)
+	cdc.RegisterConcrete(MsgDeactivateDid{}, "did/DeactivateDid", nil)
+	cdc.RegisterConcrete(MsgRevokeCredential{}, "did/RevokeCredential", nil)
+	cdc.RegisterConcrete(MsgAddCredentialSchema{}, "did/AddCredentialSchema", nil)
+	cdc.RegisterConcrete(MsgAddVerificationMethod{}, "did/AddVerificationMethod", nil)
+	cdc.RegisterConcrete(MsgRemoveVerificationMethod{}, "did/RemoveVerificationMethod", nil)
+	cdc.RegisterConcrete(MsgAddService{}, "did/AddService", nil)
+	cdc.RegisterConcrete(MsgRemoveService{}, "did/RemoveService", nil)
+	cdc.RegisterConcrete(MsgGrantDelegation{}, "did/GrantDelegation", nil)
+	cdc.RegisterConcrete(MsgRevokeDelegation{}, "did/RevokeDelegation", nil)
+	cdc.RegisterConcrete(MsgSetMultisigKey{}, "did/SetMultisigKey", nil)
+	cdc.RegisterConcrete(MsgRemoveMultisigKey{}, "did/RemoveMultisigKey", nil)
+	cdc.RegisterConcrete(MsgSetGuardians{}, "did/SetGuardians", nil)
+	cdc.RegisterConcrete(MsgStartRecovery{}, "did/StartRecovery", nil)
+	cdc.RegisterConcrete(MsgApproveRecovery{}, "did/ApproveRecovery", nil)
+	cdc.RegisterConcrete(MsgCancelRecovery{}, "did/CancelRecovery", nil)
 
 	cdc.RegisterInterface((*exported.DidDoc)(nil), nil)
 
@@ -5,11 +5,21 @@ import (
 )
 
 const (
-	DefaultCodespace       sdk.CodespaceType = ModuleName
-	CodeInvalidDid                           = 201
-	CodeInvalidPubKey                        = 202
-	CodeInvalidIssuer                        = 203
-	CodeInvalidCredentials                   = 204
+	DefaultCodespace              sdk.CodespaceType = ModuleName
+	CodeInvalidDid                                  = 201
+	CodeInvalidPubKey                               = 202
+	CodeInvalidIssuer                               = 203
+	CodeInvalidCredentials                          = 204
+	CodeAccessDenied                                = 205
+	CodeDidDeactivated                              = 206
+	CodeInvalidVerificationMethod                   = 207
+	CodeInvalidService                              = 208
+	CodeInvalidSchema                               = 209
+	CodeInvalidDelegate                             = 210
+	CodeInvalidMultisigKey                          = 211
+	CodeInvalidEncryptedMemo                        = 212
+	CodeInvalidGuardians                            = 213
+	CodeInvalidRecovery                             = 214
 )
 
 func ErrorInvalidDid(codeSpace sdk.CodespaceType, msg string) sdk.Error {
@@ -31,3 +41,43 @@ func ErrorInvalidIssuer(codeSpace sdk.CodespaceType, msg string) sdk.Error {
 func ErrorInvalidCredentials(codeSpace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codeSpace, CodeInvalidCredentials, msg)
 }
+
+func ErrorAccessDenied(codeSpace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codeSpace, CodeAccessDenied, msg)
+}
+
+func ErrorDidDeactivated(codeSpace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codeSpace, CodeDidDeactivated, msg)
+}
+
+func ErrorInvalidVerificationMethod(codeSpace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codeSpace, CodeInvalidVerificationMethod, msg)
+}
+
+func ErrorInvalidService(codeSpace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codeSpace, CodeInvalidService, msg)
+}
+
+func ErrorInvalidSchema(codeSpace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codeSpace, CodeInvalidSchema, msg)
+}
+
+func ErrorInvalidDelegate(codeSpace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codeSpace, CodeInvalidDelegate, msg)
+}
+
+func ErrorInvalidMultisigKey(codeSpace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codeSpace, CodeInvalidMultisigKey, msg)
+}
+
+func ErrorInvalidEncryptedMemo(codeSpace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codeSpace, CodeInvalidEncryptedMemo, msg)
+}
+
+func ErrorInvalidGuardians(codeSpace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codeSpace, CodeInvalidGuardians, msg)
+}
+
+func ErrorInvalidRecovery(codeSpace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codeSpace, CodeInvalidRecovery, msg)
+}
@@ -1,15 +1,61 @@
 package types
 
 const (
-	EventTypeAddDidDoc     = "add_did_doc"
-	EventTypeAddCredential = "add_credential"
+	EventTypeAddDidDoc           = "add_did_doc"
+	EventTypeAddCredential       = "add_credential"
+	EventTypeUpdateDidPubKey     = "update_did_pub_key"
+	EventTypeDeactivateDid       = "deactivate_did"
+	EventTypeRevokeCredential    = "revoke_credential"
+	EventTypeAddCredentialSchema = "add_credential_schema"
 
-	AttributeKeyDid          = "did"
-	AttributeKeyPubKey       = "pub_key"
-	AttributeKeyCredType     = "cred_type"
-	AttributeKeyIssuer       = "issuer"
-	AttributeKeyIssued       = "issued"
-	AttributeKeyClaimID      = "claim"
-	AttributeKeyKYCValidated = "kyc_validated"
-	AttributeValueCategory   = ModuleName
+	EventTypeAddVerificationMethod    = "add_verification_method"
+	EventTypeRemoveVerificationMethod = "remove_verification_method"
+	EventTypeAddService               = "add_service"
+	EventTypeRemoveService            = "remove_service"
+	EventTypeGrantDelegation          = "grant_delegation"
+	EventTypeRevokeDelegation         = "revoke_delegation"
+	EventTypeSetMultisigKey           = "set_multisig_key"
+	EventTypeRemoveMultisigKey        = "remove_multisig_key"
+	EventTypeEncryptedMemo            = "encrypted_memo"
+	EventTypeSetGuardians             = "set_guardians"
+	EventTypeStartRecovery            = "start_recovery"
+	EventTypeApproveRecovery          = "approve_recovery"
+	EventTypeCancelRecovery           = "cancel_recovery"
+	EventTypeRecoverDid               = "recover_did"
+	EventTypeRecoveryFailed           = "recovery_failed"
+
+	AttributeKeyDid                  = "did"
+	AttributeKeyPubKey               = "pub_key"
+	AttributeKeyCredType             = "cred_type"
+	AttributeKeyIssuer               = "issuer"
+	AttributeKeyIssued               = "issued"
+	AttributeKeyClaimID              = "claim"
+	AttributeKeyKYCValidated         = "kyc_validated"
+	AttributeKeyPubKeyRotationHeight = "pub_key_rotation_height"
+	AttributeKeyMigratedCoins        = "migrated_coins"
+	AttributeKeyCredentialId         = "credential_id"
+	AttributeKeySchemaId             = "schema_id"
+	AttributeKeySchemaName           = "schema_name"
+	AttributeKeyCreator              = "creator"
+	AttributeKeyExpires              = "expires"
+	AttributeKeyRevokedHeight        = "revoked_height"
+	AttributeKeyVerificationMethodId = "verification_method_id"
+	AttributeKeyVerificationType     = "verification_type"
+	AttributeKeyRelationships        = "relationships"
+	AttributeKeyServiceId            = "service_id"
+	AttributeKeyServiceType          = "service_type"
+	AttributeKeyServiceEndpoint      = "service_endpoint"
+	AttributeKeyDelegateDid          = "delegate_did"
+	AttributeKeyScopes               = "scopes"
+	AttributeKeyThreshold            = "threshold"
+	AttributeKeyPubKeys              = "pub_keys"
+	AttributeKeySenderDid            = "sender_did"
+	AttributeKeyRecipientDid         = "recipient_did"
+	AttributeKeyGuardianDid          = "guardian_did"
+	AttributeKeyGuardians            = "guardians"
+	AttributeKeyRecoveryDelay        = "recovery_delay"
+	AttributeKeyApprovals            = "approvals"
+	AttributeKeyExecutionTime        = "execution_time"
+	AttributeKeyReason               = "reason"
+	AttributeValueCategory           = ModuleName
 )
@@ -0,0 +1,12 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+)
+
+// DidHooks event hooks for DID objects (implemented by modules that refer to
+// DIDs, e.g. to warn about objects owned by a DID being deactivated)
+type DidHooks interface {
+	AfterDidDeactivated(ctx sdk.Context, did exported.Did)
+}
@@ -1,24 +1,107 @@
 package types
 
-import "github.com/ixofoundation/ixo-blockchain/x/did/exported"
+import (
+	"fmt"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+)
 
 type GenesisState struct {
-	DidDocs []exported.DidDoc `json:"did_docs" yaml:"did_docs"`
+	DidDocs           []exported.DidDoc  `json:"did_docs" yaml:"did_docs"`
+	CredentialSchemas []CredentialSchema `json:"credential_schemas" yaml:"credential_schemas"`
+	Params            Params             `json:"params" yaml:"params"`
+	DidDocHistory     []DidDocVersion    `json:"did_doc_history" yaml:"did_doc_history"`
+	Recoveries        []Recovery         `json:"recoveries" yaml:"recoveries"`
 }
 
-func NewGenesisState(didDocs []exported.DidDoc) GenesisState {
+func NewGenesisState(didDocs []exported.DidDoc, credentialSchemas []CredentialSchema,
+	params Params, didDocHistory []DidDocVersion, recoveries []Recovery) GenesisState {
 	return GenesisState{
-		DidDocs: didDocs,
+		DidDocs:           didDocs,
+		CredentialSchemas: credentialSchemas,
+		Params:            params,
+		DidDocHistory:     didDocHistory,
+		Recoveries:        recoveries,
 	}
 }
 
-//noinspection GoUnusedParameter
 func ValidateGenesis(data GenesisState) error {
+	if err := ValidateParams(data.Params); err != nil {
+		return err
+	}
+
+	dids := make(map[exported.Did]bool)
+	for _, d := range data.DidDocs {
+		var didDoc BaseDidDoc
+		switch d := d.(type) {
+		case BaseDidDoc:
+			didDoc = d
+		case *BaseDidDoc:
+			didDoc = *d
+		default:
+			return fmt.Errorf("unexpected did doc type %T", d)
+		}
+
+		if err := ValidateDidDoc(didDoc); err != nil {
+			return err
+		} else if dids[didDoc.Did] {
+			return fmt.Errorf("duplicate did doc %s", didDoc.Did)
+		}
+		dids[didDoc.Did] = true
+	}
+
+	schemaIds := make(map[string]bool)
+	for _, schema := range data.CredentialSchemas {
+		if err := schema.Validate(); err != nil {
+			return err
+		} else if schemaIds[schema.Id] {
+			return fmt.Errorf("duplicate credential schema %s", schema.Id)
+		}
+		schemaIds[schema.Id] = true
+	}
+
+	versions := make(map[string]bool)
+	versionCounts := make(map[exported.Did]uint64)
+	maxVersions := data.Params.MaxDidDocVersions
+	for _, version := range data.DidDocHistory {
+		key := fmt.Sprintf("%s/%d", version.DidDoc.Did, version.VersionId)
+		if !dids[version.DidDoc.Did] {
+			return fmt.Errorf("did doc version of unknown did %s", version.DidDoc.Did)
+		} else if version.VersionId == 0 {
+			return fmt.Errorf("did doc version of %s has version id 0", version.DidDoc.Did)
+		} else if versions[key] {
+			return fmt.Errorf("duplicate did doc version %s", key)
+		}
+		versions[key] = true
+
+		versionCounts[version.DidDoc.Did]++
+		if maxVersions > 0 && versionCounts[version.DidDoc.Did] > maxVersions {
+			return fmt.Errorf("did %s has more than %d did doc versions",
+				version.DidDoc.Did, maxVersions)
+		}
+	}
+
+	recoveries := make(map[exported.Did]bool)
+	for _, recovery := range data.Recoveries {
+		if !dids[recovery.Did] {
+			return fmt.Errorf("recovery of unknown did %s", recovery.Did)
+		} else if recoveries[recovery.Did] {
+			return fmt.Errorf("duplicate recovery of did %s", recovery.Did)
+		} else if len(recovery.Approvals) == 0 {
+			return fmt.Errorf("recovery of did %s has no approvals", recovery.Did)
+		} else if !IsValidPubKeyOfType(recovery.KeyType, recovery.PubKey) {
+			return fmt.Errorf("recovery of did %s has invalid pubKey", recovery.Did)
+		}
+		recoveries[recovery.Did] = true
+	}
 	return nil
 }
 
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		DidDocs: nil,
+		DidDocs:           nil,
+		CredentialSchemas: nil,
+		Params:            DefaultParams(),
+		DidDocHistory:     nil,
+		Recoveries:        nil,
 	}
 }
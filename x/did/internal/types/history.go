@@ -0,0 +1,31 @@
+package types
+
+import (
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"time"
+)
+
+// DidDocVersion is a snapshot of a DID doc, recorded whenever the DID doc is
+// created or changed. The change type is the type of the event emitted for
+// the change (e.g. add_did_doc or update_did_pub_key), and the actor is the
+// DID that made the change (e.g. the issuer of a credential).
+type DidDocVersion struct {
+	VersionId  uint64       `json:"version_id" yaml:"version_id"`
+	Height     int64        `json:"height" yaml:"height"`
+	Time       time.Time    `json:"time" yaml:"time"`
+	ChangeType string       `json:"change_type" yaml:"change_type"`
+	Actor      exported.Did `json:"actor" yaml:"actor"`
+	DidDoc     BaseDidDoc   `json:"did_doc" yaml:"did_doc"`
+}
+
+func NewDidDocVersion(versionId uint64, height int64, t time.Time,
+	changeType string, actor exported.Did, didDoc BaseDidDoc) DidDocVersion {
+	return DidDocVersion{
+		VersionId:  versionId,
+		Height:     height,
+		Time:       t,
+		ChangeType: changeType,
+		Actor:      actor,
+		DidDoc:     didDoc,
+	}
+}
@@ -0,0 +1,19 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+)
+
+// combine multiple DID hooks, all hook functions are run in array sequence
+type MultiDidHooks []DidHooks
+
+func NewMultiDidHooks(hooks ...DidHooks) MultiDidHooks {
+	return hooks
+}
+
+func (h MultiDidHooks) AfterDidDeactivated(ctx sdk.Context, did exported.Did) {
+	for i := range h {
+		h[i].AfterDidDeactivated(ctx, did)
+	}
+}
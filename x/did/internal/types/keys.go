@@ -1,16 +1,56 @@
 package types
 
-import "github.com/ixofoundation/ixo-blockchain/x/did/exported"
+import (
+	"encoding/binary"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+)
 
 const (
 	ModuleName   = "did"
 	StoreKey     = ModuleName
 	RouterKey    = ModuleName
 	QuerierRoute = ModuleName
+
+	DefaultParamspace = ModuleName
 )
 
-var DidKey = []byte{0x01}
+var (
+	DidKey              = []byte{0x01}
+	CredentialSchemaKey = []byte{0x02}
+	AddressKey          = []byte{0x03}
+	DidDocVersionKey    = []byte{0x04}
+	RecoveryKey         = []byte{0x05}
+)
 
 func GetDidPrefixKey(did exported.Did) []byte {
 	return append(DidKey, []byte(did)...)
 }
+
+// GetAddressKey is the key of the address index entry, which maps the
+// address of a DID (see DidDoc.Address) back to the DID
+func GetAddressKey(address sdk.AccAddress) []byte {
+	return append(AddressKey, address.Bytes()...)
+}
+
+// GetDidDocHistoryPrefixKey is the prefix of the keys of a DID's DID doc
+// versions. The DID is terminated so that it is not a prefix of other DIDs.
+func GetDidDocHistoryPrefixKey(did exported.Did) []byte {
+	return append(append(DidDocVersionKey, []byte(did)...), '/')
+}
+
+// GetDidDocVersionKey is the key of a DID doc version. Versions are
+// big-endian encoded so that they are iterated in order.
+func GetDidDocVersionKey(did exported.Did, versionId uint64) []byte {
+	versionBz := make([]byte, 8)
+	binary.BigEndian.PutUint64(versionBz, versionId)
+	return append(GetDidDocHistoryPrefixKey(did), versionBz...)
+}
+
+func GetRecoveryKey(did exported.Did) []byte {
+	return append(RecoveryKey, []byte(did)...)
+}
+
+func GetCredentialSchemaKey(schemaId string) []byte {
+	return append(CredentialSchemaKey, []byte(schemaId)...)
+}
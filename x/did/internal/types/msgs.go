@@ -1,33 +1,71 @@
 package types
 
 import (
+	"encoding/base64"
 	"fmt"
 	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
 	"github.com/ixofoundation/ixo-blockchain/x/ixo"
 	"strings"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
-	TypeMsgAddDid        = "add-did"
-	TypeMsgAddCredential = "add-credential"
+	TypeMsgAddDid              = "add-did"
+	TypeMsgAddCredential       = "add-credential"
+	TypeMsgUpdateDidPubKey     = "update-did-pub-key"
+	TypeMsgDeactivateDid       = "deactivate-did"
+	TypeMsgRevokeCredential    = "revoke-credential"
+	TypeMsgAddCredentialSchema = "add-credential-schema"
+
+	TypeMsgAddVerificationMethod    = "add-verification-method"
+	TypeMsgRemoveVerificationMethod = "remove-verification-method"
+	TypeMsgAddService               = "add-service"
+	TypeMsgRemoveService            = "remove-service"
+	TypeMsgGrantDelegation          = "grant-delegation"
+	TypeMsgRevokeDelegation         = "revoke-delegation"
+	TypeMsgSetMultisigKey           = "set-multisig-key"
+	TypeMsgRemoveMultisigKey        = "remove-multisig-key"
+	TypeMsgSetGuardians             = "set-guardians"
+	TypeMsgStartRecovery            = "start-recovery"
+	TypeMsgApproveRecovery          = "approve-recovery"
+	TypeMsgCancelRecovery           = "cancel-recovery"
 )
 
 var (
 	_ ixo.IxoMsg = MsgAddDid{}
 	_ ixo.IxoMsg = MsgAddCredential{}
+	_ ixo.IxoMsg = MsgUpdateDidPubKey{}
+	_ ixo.IxoMsg = MsgDeactivateDid{}
+	_ ixo.IxoMsg = MsgRevokeCredential{}
+	_ ixo.IxoMsg = MsgAddCredentialSchema{}
+
+	_ ixo.IxoMsg = MsgAddVerificationMethod{}
+	_ ixo.IxoMsg = MsgRemoveVerificationMethod{}
+	_ ixo.IxoMsg = MsgAddService{}
+	_ ixo.IxoMsg = MsgRemoveService{}
+	_ ixo.IxoMsg = MsgGrantDelegation{}
+	_ ixo.IxoMsg = MsgRevokeDelegation{}
+	_ ixo.IxoMsg = MsgSetMultisigKey{}
+	_ ixo.IxoMsg = MsgRemoveMultisigKey{}
+	_ ixo.IxoMsg = MsgSetGuardians{}
+	_ ixo.IxoMsg = MsgStartRecovery{}
+	_ ixo.IxoMsg = MsgApproveRecovery{}
+	_ ixo.IxoMsg = MsgCancelRecovery{}
 )
 
 type MsgAddDid struct {
-	Did    exported.Did `json:"did" yaml:"did"`
-	PubKey string       `json:"pubKey" yaml:"pubKey"`
+	Did     exported.Did `json:"did" yaml:"did"`
+	PubKey  string       `json:"pubKey" yaml:"pubKey"`
+	KeyType string       `json:"keyType,omitempty" yaml:"keyType,omitempty"`
 }
 
-func NewMsgAddDid(did string, publicKey string) MsgAddDid {
+func NewMsgAddDid(did string, publicKey string, keyType string) MsgAddDid {
 	return MsgAddDid{
-		Did:    did,
-		PubKey: publicKey,
+		Did:     did,
+		PubKey:  publicKey,
+		KeyType: keyType,
 	}
 }
 
@@ -48,13 +86,20 @@ func (msg MsgAddDid) ValidateBasic() sdk.Error {
 		return ErrorInvalidPubKey(DefaultCodespace, "pubKey should not be empty")
 	}
 
-	// Check that DID and PubKey valid
+	// Check that DID, key type, and PubKey valid
 	if !IsValidDid(msg.Did) {
 		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
-	} else if !IsValidPubKey(msg.PubKey) {
+	} else if !IsValidKeyType(msg.KeyType) {
+		return ErrorInvalidPubKey(DefaultCodespace, "keyType is invalid")
+	} else if !IsValidPubKeyOfType(msg.KeyType, msg.PubKey) {
 		return ErrorInvalidPubKey(DefaultCodespace, "pubKey is invalid")
 	}
 
+	// Check that DID is not a did:key, which does not need to be added
+	if exported.IsDidKey(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did:key dids are resolved from the did and cannot be added")
+	}
+
 	// Check that DID matches the PubKey
 	unprefixedDid := exported.UnprefixedDid(msg.Did)
 	expectedUnprefixedDid := exported.UnprefixedDidFromPubKey(msg.PubKey)
@@ -72,14 +117,15 @@ func (msg MsgAddDid) GetSignBytes() []byte {
 }
 
 func (msg MsgAddDid) String() string {
-	return fmt.Sprintf("MsgAddDid{Did: %v, publicKey: %v}", msg.Did, msg.PubKey)
+	return fmt.Sprintf("MsgAddDid{Did: %v, publicKey: %v, keyType: %v}", msg.Did, msg.PubKey, msg.KeyType)
 }
 
 type MsgAddCredential struct {
 	DidCredential exported.DidCredential `json:"credential" yaml:"credential"`
 }
 
-func NewMsgAddCredential(did string, credType []string, issuer string, issued string) MsgAddCredential {
+func NewMsgAddCredential(did string, credType []string, issuer string,
+	issued string, expires string) MsgAddCredential {
 	didCredential := exported.DidCredential{
 		CredType: credType,
 		Issuer:   issuer,
@@ -88,6 +134,7 @@ func NewMsgAddCredential(did string, credType []string, issuer string, issued st
 			Id:           did,
 			KYCValidated: true,
 		},
+		Expires: expires,
 	}
 
 	return MsgAddCredential{
@@ -95,6 +142,14 @@ func NewMsgAddCredential(did string, credType []string, issuer string, issued st
 	}
 }
 
+// NewMsgAddSignedCredential adds a credential with a typed claim, which must
+// have been signed by the issuer (see DidCredential.SignBytes)
+func NewMsgAddSignedCredential(credential exported.DidCredential) MsgAddCredential {
+	return MsgAddCredential{
+		DidCredential: credential,
+	}
+}
+
 func (msg MsgAddCredential) Type() string  { return TypeMsgAddCredential }
 func (msg MsgAddCredential) Route() string { return RouterKey }
 
@@ -121,9 +176,804 @@ func (msg MsgAddCredential) ValidateBasic() sdk.Error {
 		return ErrorInvalidDid(DefaultCodespace, "issuer did is invalid")
 	}
 
+	// Check that credential has at least one type
+	if len(msg.DidCredential.CredType) == 0 {
+		return ErrorInvalidCredentials(DefaultCodespace, "credential type should not be empty")
+	}
+
+	// Check that expiry time, if any, is valid and after the issue time
+	if msg.DidCredential.Expires != "" {
+		expires, err := time.Parse(time.RFC3339, msg.DidCredential.Expires)
+		if err != nil {
+			return ErrorInvalidCredentials(DefaultCodespace, "expires should be an RFC3339 time")
+		}
+		issued, err := time.Parse(time.RFC3339, msg.DidCredential.Issued)
+		if err == nil && !expires.After(issued) {
+			return ErrorInvalidCredentials(DefaultCodespace, "expires should be after issued")
+		}
+	}
+
+	// Check that typed claims have a body and a signature by the issuer, and
+	// that other claims do not have a body
+	if msg.DidCredential.Claim.IsTyped() {
+		if strings.TrimSpace(msg.DidCredential.Claim.Body) == "" {
+			return ErrorInvalidCredentials(DefaultCodespace, "typed claim body should not be empty")
+		} else if _, err := base64.StdEncoding.DecodeString(msg.DidCredential.Signature); err != nil ||
+			msg.DidCredential.Signature == "" {
+			return ErrorInvalidCredentials(DefaultCodespace, "typed claim credential should have a base64 signature")
+		}
+	} else if msg.DidCredential.Claim.Body != "" {
+		return ErrorInvalidCredentials(DefaultCodespace, "claim body requires a schema id")
+	}
+
 	return nil
 }
 
 func (msg MsgAddCredential) GetSignBytes() []byte {
 	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
 }
+
+type MsgUpdateDidPubKey struct {
+	Did     exported.Did `json:"did" yaml:"did"`
+	PubKey  string       `json:"pubKey" yaml:"pubKey"`
+	KeyType string       `json:"keyType,omitempty" yaml:"keyType,omitempty"`
+}
+
+func NewMsgUpdateDidPubKey(did exported.Did, pubKey string, keyType string) MsgUpdateDidPubKey {
+	return MsgUpdateDidPubKey{
+		Did:     did,
+		PubKey:  pubKey,
+		KeyType: keyType,
+	}
+}
+
+func (msg MsgUpdateDidPubKey) Type() string  { return TypeMsgUpdateDidPubKey }
+func (msg MsgUpdateDidPubKey) Route() string { return RouterKey }
+
+// The update is signed using the DID's current PubKey
+func (msg MsgUpdateDidPubKey) GetSignerDid() exported.Did { return msg.Did }
+func (msg MsgUpdateDidPubKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgUpdateDidPubKey) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	} else if strings.TrimSpace(msg.PubKey) == "" {
+		return ErrorInvalidPubKey(DefaultCodespace, "pubKey should not be empty")
+	}
+
+	// Check that DID and PubKey valid. Note that, unlike in MsgAddDid, the
+	// DID is not expected to be deducible from the new PubKey.
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	} else if !IsValidKeyType(msg.KeyType) {
+		return ErrorInvalidPubKey(DefaultCodespace, "keyType is invalid")
+	} else if !IsValidPubKeyOfType(msg.KeyType, msg.PubKey) {
+		return ErrorInvalidPubKey(DefaultCodespace, "pubKey is invalid")
+	}
+
+	// Check that DID is not a did:key, since its PubKey is fixed by the DID
+	if exported.IsDidKey(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "the pubKey of a did:key did cannot be updated")
+	}
+
+	return nil
+}
+
+func (msg MsgUpdateDidPubKey) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgUpdateDidPubKey) String() string {
+	return fmt.Sprintf("MsgUpdateDidPubKey{Did: %v, publicKey: %v, keyType: %v}", msg.Did, msg.PubKey, msg.KeyType)
+}
+
+type MsgDeactivateDid struct {
+	Did exported.Did `json:"did" yaml:"did"`
+}
+
+func NewMsgDeactivateDid(did exported.Did) MsgDeactivateDid {
+	return MsgDeactivateDid{
+		Did: did,
+	}
+}
+
+func (msg MsgDeactivateDid) Type() string  { return TypeMsgDeactivateDid }
+func (msg MsgDeactivateDid) Route() string { return RouterKey }
+
+func (msg MsgDeactivateDid) GetSignerDid() exported.Did { return msg.Did }
+func (msg MsgDeactivateDid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgDeactivateDid) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	}
+
+	// Check that DID valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	}
+
+	return nil
+}
+
+func (msg MsgDeactivateDid) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgDeactivateDid) String() string {
+	return fmt.Sprintf("MsgDeactivateDid{Did: %v}", msg.Did)
+}
+
+type MsgAddVerificationMethod struct {
+	Did                exported.Did                `json:"did" yaml:"did"`
+	VerificationMethod exported.VerificationMethod `json:"verificationMethod" yaml:"verificationMethod"`
+}
+
+func NewMsgAddVerificationMethod(did exported.Did,
+	method exported.VerificationMethod) MsgAddVerificationMethod {
+	return MsgAddVerificationMethod{
+		Did:                did,
+		VerificationMethod: method,
+	}
+}
+
+func (msg MsgAddVerificationMethod) Type() string  { return TypeMsgAddVerificationMethod }
+func (msg MsgAddVerificationMethod) Route() string { return RouterKey }
+
+func (msg MsgAddVerificationMethod) GetSignerDid() exported.Did { return msg.Did }
+func (msg MsgAddVerificationMethod) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgAddVerificationMethod) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	}
+
+	// Check that DID valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	}
+
+	// Check that verification method valid
+	if err := ValidateVerificationMethod(msg.Did, msg.VerificationMethod); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (msg MsgAddVerificationMethod) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgAddVerificationMethod) String() string {
+	return fmt.Sprintf("MsgAddVerificationMethod{Did: %v, Id: %v, Type: %v, Relationships: %v}",
+		msg.Did, msg.VerificationMethod.Id, msg.VerificationMethod.Type,
+		msg.VerificationMethod.Relationships)
+}
+
+type MsgRemoveVerificationMethod struct {
+	Did                  exported.Did `json:"did" yaml:"did"`
+	VerificationMethodId string       `json:"verificationMethodId" yaml:"verificationMethodId"`
+}
+
+func NewMsgRemoveVerificationMethod(did exported.Did, methodId string) MsgRemoveVerificationMethod {
+	return MsgRemoveVerificationMethod{
+		Did:                  did,
+		VerificationMethodId: methodId,
+	}
+}
+
+func (msg MsgRemoveVerificationMethod) Type() string  { return TypeMsgRemoveVerificationMethod }
+func (msg MsgRemoveVerificationMethod) Route() string { return RouterKey }
+
+func (msg MsgRemoveVerificationMethod) GetSignerDid() exported.Did { return msg.Did }
+func (msg MsgRemoveVerificationMethod) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgRemoveVerificationMethod) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	} else if strings.TrimSpace(msg.VerificationMethodId) == "" {
+		return ErrorInvalidVerificationMethod(DefaultCodespace, "verification method id should not be empty")
+	}
+
+	// Check that DID valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	}
+
+	return nil
+}
+
+func (msg MsgRemoveVerificationMethod) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRemoveVerificationMethod) String() string {
+	return fmt.Sprintf("MsgRemoveVerificationMethod{Did: %v, Id: %v}",
+		msg.Did, msg.VerificationMethodId)
+}
+
+type MsgAddService struct {
+	Did     exported.Did     `json:"did" yaml:"did"`
+	Service exported.Service `json:"service" yaml:"service"`
+}
+
+func NewMsgAddService(did exported.Did, service exported.Service) MsgAddService {
+	return MsgAddService{
+		Did:     did,
+		Service: service,
+	}
+}
+
+func (msg MsgAddService) Type() string  { return TypeMsgAddService }
+func (msg MsgAddService) Route() string { return RouterKey }
+
+func (msg MsgAddService) GetSignerDid() exported.Did { return msg.Did }
+func (msg MsgAddService) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgAddService) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	}
+
+	// Check that DID valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	}
+
+	// Check that service valid
+	if err := ValidateService(msg.Did, msg.Service); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (msg MsgAddService) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgAddService) String() string {
+	return fmt.Sprintf("MsgAddService{Did: %v, Id: %v, Type: %v, ServiceEndpoint: %v}",
+		msg.Did, msg.Service.Id, msg.Service.Type, msg.Service.ServiceEndpoint)
+}
+
+type MsgRemoveService struct {
+	Did       exported.Did `json:"did" yaml:"did"`
+	ServiceId string       `json:"serviceId" yaml:"serviceId"`
+}
+
+func NewMsgRemoveService(did exported.Did, serviceId string) MsgRemoveService {
+	return MsgRemoveService{
+		Did:       did,
+		ServiceId: serviceId,
+	}
+}
+
+func (msg MsgRemoveService) Type() string  { return TypeMsgRemoveService }
+func (msg MsgRemoveService) Route() string { return RouterKey }
+
+func (msg MsgRemoveService) GetSignerDid() exported.Did { return msg.Did }
+func (msg MsgRemoveService) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgRemoveService) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	} else if strings.TrimSpace(msg.ServiceId) == "" {
+		return ErrorInvalidService(DefaultCodespace, "service id should not be empty")
+	}
+
+	// Check that DID valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	}
+
+	return nil
+}
+
+func (msg MsgRemoveService) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRemoveService) String() string {
+	return fmt.Sprintf("MsgRemoveService{Did: %v, Id: %v}", msg.Did, msg.ServiceId)
+}
+
+type MsgGrantDelegation struct {
+	Did      exported.Did      `json:"did" yaml:"did"`
+	Delegate exported.Delegate `json:"delegate" yaml:"delegate"`
+}
+
+func NewMsgGrantDelegation(did exported.Did, delegate exported.Delegate) MsgGrantDelegation {
+	return MsgGrantDelegation{
+		Did:      did,
+		Delegate: delegate,
+	}
+}
+
+func (msg MsgGrantDelegation) Type() string  { return TypeMsgGrantDelegation }
+func (msg MsgGrantDelegation) Route() string { return RouterKey }
+
+func (msg MsgGrantDelegation) GetSignerDid() exported.Did { return msg.Did }
+func (msg MsgGrantDelegation) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgGrantDelegation) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	}
+
+	// Check that DID valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	}
+
+	// Check that delegate valid
+	if err := ValidateDelegate(msg.Did, msg.Delegate); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (msg MsgGrantDelegation) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgGrantDelegation) String() string {
+	return fmt.Sprintf("MsgGrantDelegation{Did: %v, DelegateDid: %v, Scopes: %v}",
+		msg.Did, msg.Delegate.Did, msg.Delegate.Scopes)
+}
+
+type MsgRevokeDelegation struct {
+	Did         exported.Did `json:"did" yaml:"did"`
+	DelegateDid exported.Did `json:"delegateDid" yaml:"delegateDid"`
+}
+
+func NewMsgRevokeDelegation(did, delegateDid exported.Did) MsgRevokeDelegation {
+	return MsgRevokeDelegation{
+		Did:         did,
+		DelegateDid: delegateDid,
+	}
+}
+
+func (msg MsgRevokeDelegation) Type() string  { return TypeMsgRevokeDelegation }
+func (msg MsgRevokeDelegation) Route() string { return RouterKey }
+
+func (msg MsgRevokeDelegation) GetSignerDid() exported.Did { return msg.Did }
+func (msg MsgRevokeDelegation) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgRevokeDelegation) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	} else if strings.TrimSpace(msg.DelegateDid) == "" {
+		return ErrorInvalidDelegate(DefaultCodespace, "delegate did should not be empty")
+	}
+
+	// Check that DIDs valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	} else if !IsValidDid(msg.DelegateDid) {
+		return ErrorInvalidDelegate(DefaultCodespace, "delegate did is invalid")
+	}
+
+	return nil
+}
+
+func (msg MsgRevokeDelegation) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRevokeDelegation) String() string {
+	return fmt.Sprintf("MsgRevokeDelegation{Did: %v, DelegateDid: %v}", msg.Did, msg.DelegateDid)
+}
+
+type MsgSetMultisigKey struct {
+	Did         exported.Did         `json:"did" yaml:"did"`
+	MultisigKey exported.MultisigKey `json:"multisigKey" yaml:"multisigKey"`
+}
+
+func NewMsgSetMultisigKey(did exported.Did, multisigKey exported.MultisigKey) MsgSetMultisigKey {
+	return MsgSetMultisigKey{
+		Did:         did,
+		MultisigKey: multisigKey,
+	}
+}
+
+func (msg MsgSetMultisigKey) Type() string  { return TypeMsgSetMultisigKey }
+func (msg MsgSetMultisigKey) Route() string { return RouterKey }
+
+func (msg MsgSetMultisigKey) GetSignerDid() exported.Did { return msg.Did }
+func (msg MsgSetMultisigKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgSetMultisigKey) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	}
+
+	// Check that DID valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	}
+
+	// Check that multisig key set valid
+	if err := ValidateMultisigKey(msg.MultisigKey); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (msg MsgSetMultisigKey) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetMultisigKey) String() string {
+	return fmt.Sprintf("MsgSetMultisigKey{Did: %v, Threshold: %v, PubKeys: %v}",
+		msg.Did, msg.MultisigKey.Threshold, msg.MultisigKey.PubKeys)
+}
+
+type MsgRemoveMultisigKey struct {
+	Did exported.Did `json:"did" yaml:"did"`
+}
+
+func NewMsgRemoveMultisigKey(did exported.Did) MsgRemoveMultisigKey {
+	return MsgRemoveMultisigKey{
+		Did: did,
+	}
+}
+
+func (msg MsgRemoveMultisigKey) Type() string  { return TypeMsgRemoveMultisigKey }
+func (msg MsgRemoveMultisigKey) Route() string { return RouterKey }
+
+func (msg MsgRemoveMultisigKey) GetSignerDid() exported.Did { return msg.Did }
+func (msg MsgRemoveMultisigKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgRemoveMultisigKey) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	}
+
+	// Check that DID valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	}
+
+	return nil
+}
+
+func (msg MsgRemoveMultisigKey) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRemoveMultisigKey) String() string {
+	return fmt.Sprintf("MsgRemoveMultisigKey{Did: %v}", msg.Did)
+}
+
+type MsgSetGuardians struct {
+	Did       exported.Did       `json:"did" yaml:"did"`
+	Guardians exported.Guardians `json:"guardians" yaml:"guardians"`
+}
+
+func NewMsgSetGuardians(did exported.Did, guardians exported.Guardians) MsgSetGuardians {
+	return MsgSetGuardians{
+		Did:       did,
+		Guardians: guardians,
+	}
+}
+
+func (msg MsgSetGuardians) Type() string  { return TypeMsgSetGuardians }
+func (msg MsgSetGuardians) Route() string { return RouterKey }
+
+func (msg MsgSetGuardians) GetSignerDid() exported.Did { return msg.Did }
+func (msg MsgSetGuardians) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgSetGuardians) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	}
+
+	// Check that DID valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	}
+
+	// Check that guardians valid, unless the guardians are being removed
+	if !msg.Guardians.IsEmpty() {
+		if err := ValidateGuardians(msg.Did, msg.Guardians); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (msg MsgSetGuardians) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSetGuardians) String() string {
+	return fmt.Sprintf("MsgSetGuardians{Did: %v, Threshold: %v, Guardians: %v, RecoveryDelay: %v}",
+		msg.Did, msg.Guardians.Threshold, msg.Guardians.Dids, msg.Guardians.RecoveryDelay)
+}
+
+type MsgStartRecovery struct {
+	Did         exported.Did `json:"did" yaml:"did"`
+	GuardianDid exported.Did `json:"guardianDid" yaml:"guardianDid"`
+	PubKey      string       `json:"pubKey" yaml:"pubKey"`
+	KeyType     string       `json:"keyType,omitempty" yaml:"keyType,omitempty"`
+}
+
+func NewMsgStartRecovery(did, guardianDid exported.Did, pubKey, keyType string) MsgStartRecovery {
+	return MsgStartRecovery{
+		Did:         did,
+		GuardianDid: guardianDid,
+		PubKey:      pubKey,
+		KeyType:     keyType,
+	}
+}
+
+func (msg MsgStartRecovery) Type() string  { return TypeMsgStartRecovery }
+func (msg MsgStartRecovery) Route() string { return RouterKey }
+
+// The recovery is started by one of the DID's guardians
+func (msg MsgStartRecovery) GetSignerDid() exported.Did { return msg.GuardianDid }
+func (msg MsgStartRecovery) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgStartRecovery) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	} else if strings.TrimSpace(msg.GuardianDid) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "guardian did should not be empty")
+	} else if strings.TrimSpace(msg.PubKey) == "" {
+		return ErrorInvalidPubKey(DefaultCodespace, "pubKey should not be empty")
+	}
+
+	// Check that DIDs and PubKey valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	} else if !IsValidDid(msg.GuardianDid) {
+		return ErrorInvalidDid(DefaultCodespace, "guardian did is invalid")
+	} else if !IsValidKeyType(msg.KeyType) {
+		return ErrorInvalidPubKey(DefaultCodespace, "keyType is invalid")
+	} else if !IsValidPubKeyOfType(msg.KeyType, msg.PubKey) {
+		return ErrorInvalidPubKey(DefaultCodespace, "pubKey is invalid")
+	}
+
+	// Check that DID is not a did:key, since its PubKey is fixed by the DID
+	if exported.IsDidKey(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "the pubKey of a did:key did cannot be recovered")
+	}
+
+	return nil
+}
+
+func (msg MsgStartRecovery) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgStartRecovery) String() string {
+	return fmt.Sprintf("MsgStartRecovery{Did: %v, GuardianDid: %v, PubKey: %v, KeyType: %v}",
+		msg.Did, msg.GuardianDid, msg.PubKey, msg.KeyType)
+}
+
+type MsgApproveRecovery struct {
+	Did         exported.Did `json:"did" yaml:"did"`
+	GuardianDid exported.Did `json:"guardianDid" yaml:"guardianDid"`
+	PubKey      string       `json:"pubKey" yaml:"pubKey"`
+}
+
+func NewMsgApproveRecovery(did, guardianDid exported.Did, pubKey string) MsgApproveRecovery {
+	return MsgApproveRecovery{
+		Did:         did,
+		GuardianDid: guardianDid,
+		PubKey:      pubKey,
+	}
+}
+
+func (msg MsgApproveRecovery) Type() string  { return TypeMsgApproveRecovery }
+func (msg MsgApproveRecovery) Route() string { return RouterKey }
+
+// The recovery is approved by one of the DID's guardians
+func (msg MsgApproveRecovery) GetSignerDid() exported.Did { return msg.GuardianDid }
+func (msg MsgApproveRecovery) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgApproveRecovery) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	} else if strings.TrimSpace(msg.GuardianDid) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "guardian did should not be empty")
+	} else if strings.TrimSpace(msg.PubKey) == "" {
+		return ErrorInvalidPubKey(DefaultCodespace, "pubKey should not be empty")
+	}
+
+	// Check that DIDs valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	} else if !IsValidDid(msg.GuardianDid) {
+		return ErrorInvalidDid(DefaultCodespace, "guardian did is invalid")
+	}
+
+	return nil
+}
+
+func (msg MsgApproveRecovery) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgApproveRecovery) String() string {
+	return fmt.Sprintf("MsgApproveRecovery{Did: %v, GuardianDid: %v, PubKey: %v}",
+		msg.Did, msg.GuardianDid, msg.PubKey)
+}
+
+type MsgCancelRecovery struct {
+	Did exported.Did `json:"did" yaml:"did"`
+}
+
+func NewMsgCancelRecovery(did exported.Did) MsgCancelRecovery {
+	return MsgCancelRecovery{
+		Did: did,
+	}
+}
+
+func (msg MsgCancelRecovery) Type() string  { return TypeMsgCancelRecovery }
+func (msg MsgCancelRecovery) Route() string { return RouterKey }
+
+// The recovery is cancelled (vetoed) using the DID's current PubKey
+func (msg MsgCancelRecovery) GetSignerDid() exported.Did { return msg.Did }
+func (msg MsgCancelRecovery) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgCancelRecovery) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	}
+
+	// Check that DID valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	}
+
+	return nil
+}
+
+func (msg MsgCancelRecovery) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCancelRecovery) String() string {
+	return fmt.Sprintf("MsgCancelRecovery{Did: %v}", msg.Did)
+}
+
+type MsgRevokeCredential struct {
+	Did          exported.Did `json:"did" yaml:"did"`
+	CredentialId string       `json:"credentialId" yaml:"credentialId"`
+	Issuer       exported.Did `json:"issuer" yaml:"issuer"`
+}
+
+func NewMsgRevokeCredential(did exported.Did, credentialId string, issuer exported.Did) MsgRevokeCredential {
+	return MsgRevokeCredential{
+		Did:          did,
+		CredentialId: credentialId,
+		Issuer:       issuer,
+	}
+}
+
+func (msg MsgRevokeCredential) Type() string  { return TypeMsgRevokeCredential }
+func (msg MsgRevokeCredential) Route() string { return RouterKey }
+
+// Only the original issuer of the credential can revoke it
+func (msg MsgRevokeCredential) GetSignerDid() exported.Did { return msg.Issuer }
+func (msg MsgRevokeCredential) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgRevokeCredential) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Did) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "did should not be empty")
+	} else if strings.TrimSpace(msg.CredentialId) == "" {
+		return ErrorInvalidCredentials(DefaultCodespace, "credential id should not be empty")
+	} else if strings.TrimSpace(msg.Issuer) == "" {
+		return ErrorInvalidIssuer(DefaultCodespace, "issuer should not be empty")
+	}
+
+	// Check that DIDs valid
+	if !IsValidDid(msg.Did) {
+		return ErrorInvalidDid(DefaultCodespace, "did is invalid")
+	} else if !IsValidDid(msg.Issuer) {
+		return ErrorInvalidDid(DefaultCodespace, "issuer did is invalid")
+	}
+
+	return nil
+}
+
+func (msg MsgRevokeCredential) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRevokeCredential) String() string {
+	return fmt.Sprintf("MsgRevokeCredential{Did: %v, CredentialId: %v, Issuer: %v}",
+		msg.Did, msg.CredentialId, msg.Issuer)
+}
+
+type MsgAddCredentialSchema struct {
+	Schema CredentialSchema `json:"schema" yaml:"schema"`
+}
+
+func NewMsgAddCredentialSchema(schema CredentialSchema) MsgAddCredentialSchema {
+	return MsgAddCredentialSchema{
+		Schema: schema,
+	}
+}
+
+func (msg MsgAddCredentialSchema) Type() string  { return TypeMsgAddCredentialSchema }
+func (msg MsgAddCredentialSchema) Route() string { return RouterKey }
+
+func (msg MsgAddCredentialSchema) GetSignerDid() exported.Did { return msg.Schema.Creator }
+func (msg MsgAddCredentialSchema) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
+}
+
+func (msg MsgAddCredentialSchema) ValidateBasic() sdk.Error {
+	// Check that not empty
+	if strings.TrimSpace(msg.Schema.Creator) == "" {
+		return ErrorInvalidDid(DefaultCodespace, "creator did should not be empty")
+	}
+
+	// Check that schema valid
+	if err := msg.Schema.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (msg MsgAddCredentialSchema) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgAddCredentialSchema) String() string {
+	return fmt.Sprintf("MsgAddCredentialSchema{Id: %v, Creator: %v, Name: %v}",
+		msg.Schema.Id, msg.Schema.Creator, msg.Schema.Name)
+}
@@ -0,0 +1,133 @@
+package types
+
+import (
+	"fmt"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"strings"
+)
+
+// Parameter store keys
+var (
+	KeyTrustedIssuers    = []byte("TrustedIssuers")
+	KeyEnableDidKey      = []byte("EnableDidKey")
+	KeyMaxDidDocVersions = []byte("MaxDidDocVersions")
+)
+
+// TrustedIssuer lists the DIDs authorised to issue credentials of a type
+type TrustedIssuer struct {
+	CredentialType string         `json:"credential_type" yaml:"credential_type"`
+	Issuers        []exported.Did `json:"issuers" yaml:"issuers"`
+}
+
+func NewTrustedIssuer(credentialType string, issuers []exported.Did) TrustedIssuer {
+	return TrustedIssuer{
+		CredentialType: credentialType,
+		Issuers:        issuers,
+	}
+}
+
+// did parameters
+type Params struct {
+	TrustedIssuers    []TrustedIssuer `json:"trusted_issuers" yaml:"trusted_issuers"`
+	EnableDidKey      bool            `json:"enable_did_key" yaml:"enable_did_key"`
+	MaxDidDocVersions uint64          `json:"max_did_doc_versions" yaml:"max_did_doc_versions"`
+}
+
+// ParamTable for did module.
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+func NewParams(trustedIssuers []TrustedIssuer, enableDidKey bool,
+	maxDidDocVersions uint64) Params {
+	return Params{
+		TrustedIssuers:    trustedIssuers,
+		EnableDidKey:      enableDidKey,
+		MaxDidDocVersions: maxDidDocVersions,
+	}
+}
+
+// default did module parameters
+func DefaultParams() Params {
+	return Params{
+		TrustedIssuers:    []TrustedIssuer{}, // no restricted credential types
+		EnableDidKey:      false,
+		MaxDidDocVersions: 0, // DID doc history is not pruned
+	}
+}
+
+// validate params
+func ValidateParams(params Params) error {
+	credTypes := make(map[string]bool)
+	for _, trusted := range params.TrustedIssuers {
+		if strings.TrimSpace(trusted.CredentialType) == "" {
+			return fmt.Errorf("did parameter TrustedIssuers credential type should not be empty")
+		} else if credTypes[trusted.CredentialType] {
+			return fmt.Errorf("did parameter TrustedIssuers has duplicate credential type %s", trusted.CredentialType)
+		}
+		credTypes[trusted.CredentialType] = true
+
+		for _, issuer := range trusted.Issuers {
+			if !IsValidDid(issuer) {
+				return fmt.Errorf("did parameter TrustedIssuers issuer %s is invalid", issuer)
+			}
+		}
+	}
+	return nil
+}
+
+// GetTrustedIssuers returns the issuers authorised to issue credentials of
+// the specified type, and whether or not the type is restricted at all
+func (p Params) GetTrustedIssuers(credType string) ([]exported.Did, bool) {
+	for _, trusted := range p.TrustedIssuers {
+		if trusted.CredentialType == credType {
+			return trusted.Issuers, true
+		}
+	}
+	return nil, false
+}
+
+// IsAuthorisedIssuer checks that the issuer is trusted for each of the
+// credential types. Types not listed in the params can be issued by any DID.
+func (p Params) IsAuthorisedIssuer(credTypes []string, issuer exported.Did) bool {
+	for _, credType := range credTypes {
+		issuers, restricted := p.GetTrustedIssuers(credType)
+		if !restricted {
+			continue
+		}
+
+		trusted := false
+		for _, trustedIssuer := range issuers {
+			if trustedIssuer == issuer {
+				trusted = true
+				break
+			}
+		}
+		if !trusted {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Params) String() string {
+	var b strings.Builder
+	b.WriteString("Did Params:\n  Trusted Issuers:\n")
+	for _, trusted := range p.TrustedIssuers {
+		b.WriteString(fmt.Sprintf("    %s: %s\n", trusted.CredentialType,
+			strings.Join(trusted.Issuers, ", ")))
+	}
+	b.WriteString(fmt.Sprintf("  Enable did:key: %t\n", p.EnableDidKey))
+	b.WriteString(fmt.Sprintf("  Max DID Doc Versions: %d\n", p.MaxDidDocVersions))
+	return b.String()
+}
+
+// Implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyTrustedIssuers, &p.TrustedIssuers},
+		{KeyEnableDidKey, &p.EnableDidKey},
+		{KeyMaxDidDocVersions, &p.MaxDidDocVersions},
+	}
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"time"
+)
+
+// QueryCredentialStatusParams identifies a credential by its ID, the DID that
+// it is about, and its issuer. The height and time default to the latest ones.
+type QueryCredentialStatusParams struct {
+	Did          exported.Did `json:"did" yaml:"did"`
+	CredentialId string       `json:"credential_id" yaml:"credential_id"`
+	Issuer       exported.Did `json:"issuer" yaml:"issuer"`
+	Height       int64        `json:"height" yaml:"height"`
+	Time         time.Time    `json:"time" yaml:"time"`
+}
+
+func NewQueryCredentialStatusParams(did exported.Did, credentialId string,
+	issuer exported.Did, height int64, t time.Time) QueryCredentialStatusParams {
+	return QueryCredentialStatusParams{
+		Did:          did,
+		CredentialId: credentialId,
+		Issuer:       issuer,
+		Height:       height,
+		Time:         t,
+	}
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"time"
+)
+
+// Recovery is an in-progress recovery of a DID by its guardians, which
+// installs a new PubKey. The execution time is only set once the threshold of
+// guardians has approved the recovery, and is the time at which the recovery
+// delay ends. Until then, the recovery can be cancelled by the DID.
+type Recovery struct {
+	Did           exported.Did   `json:"did" yaml:"did"`
+	PubKey        string         `json:"pubKey" yaml:"pubKey"`
+	KeyType       string         `json:"keyType,omitempty" yaml:"keyType,omitempty"`
+	Approvals     []exported.Did `json:"approvals" yaml:"approvals"`
+	StartTime     time.Time      `json:"startTime" yaml:"startTime"`
+	ExecutionTime time.Time      `json:"executionTime" yaml:"executionTime"`
+}
+
+func NewRecovery(did exported.Did, pubKey, keyType string,
+	initiator exported.Did, startTime time.Time) Recovery {
+	return Recovery{
+		Did:       did,
+		PubKey:    pubKey,
+		KeyType:   keyType,
+		Approvals: []exported.Did{initiator},
+		StartTime: startTime,
+	}
+}
+
+func (r Recovery) HasApproved(guardian exported.Did) bool {
+	for _, approval := range r.Approvals {
+		if approval == guardian {
+			return true
+		}
+	}
+	return false
+}
+
+// IsApproved checks whether the threshold of guardians has approved
+func (r Recovery) IsApproved() bool { return !r.ExecutionTime.IsZero() }
+
+// ShouldExecute checks whether the recovery was approved and its delay has
+// passed by time t
+func (r Recovery) ShouldExecute(t time.Time) bool {
+	return r.IsApproved() && !t.Before(r.ExecutionTime)
+}
@@ -0,0 +1,144 @@
+package types
+
+import (
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"strconv"
+	"time"
+)
+
+// DID resolution as per the W3C DID Core and DID Resolution specifications
+// Ref: https://www.w3.org/TR/did-core/ and https://w3c-ccg.github.io/did-resolution/
+const (
+	DidCoreContext       = "https://www.w3.org/ns/did/v1"
+	Ed25519Context       = "https://w3id.org/security/suites/ed25519-2018/v1"
+	Secp256k1Context     = "https://w3id.org/security/suites/secp256k1-2019/v1"
+	X25519Context        = "https://w3id.org/security/suites/x25519-2019/v1"
+	DidResolutionContext = "https://w3id.org/did-resolution/v1"
+
+	DidLdJsonContentType           = "application/did+ld+json"
+	DidResolutionResultContentType = `application/ld+json;profile="https://w3id.org/did-resolution"`
+
+	ResolutionErrorInvalidDid = "invalidDid"
+	ResolutionErrorNotFound   = "notFound"
+)
+
+type ResolvedVerificationMethod struct {
+	Id              string       `json:"id"`
+	Type            string       `json:"type"`
+	Controller      exported.Did `json:"controller"`
+	PublicKeyBase58 string       `json:"publicKeyBase58"`
+}
+
+type ResolvedDidDocument struct {
+	Context            []string                     `json:"@context"`
+	Id                 exported.Did                 `json:"id"`
+	VerificationMethod []ResolvedVerificationMethod `json:"verificationMethod"`
+	Authentication     []string                     `json:"authentication"`
+	AssertionMethod    []string                     `json:"assertionMethod"`
+	KeyAgreement       []string                     `json:"keyAgreement,omitempty"`
+	Service            []exported.Service           `json:"service,omitempty"`
+}
+
+type DidResolutionMetadata struct {
+	ContentType string `json:"contentType,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+type DidDocumentMetadata struct {
+	Deactivated          bool   `json:"deactivated,omitempty"`
+	PubKeyRotationHeight int64  `json:"pubKeyRotationHeight,omitempty"`
+	VersionId            string `json:"versionId,omitempty"`
+	Updated              string `json:"updated,omitempty"`
+}
+
+type DidResolutionResult struct {
+	Context               string                `json:"@context"`
+	DidDocument           *ResolvedDidDocument  `json:"didDocument"`
+	DidResolutionMetadata DidResolutionMetadata `json:"didResolutionMetadata"`
+	DidDocumentMetadata   DidDocumentMetadata   `json:"didDocumentMetadata"`
+}
+
+// NewResolvedDidDocument converts a DID doc into a W3C DID Core document. The
+// DID doc's PubKey is presented as the verification method <did>#key-1, which
+// can be used for both authentication and assertions.
+func NewResolvedDidDocument(didDoc BaseDidDoc) ResolvedDidDocument {
+	primaryKeyId := didDoc.Did + "#" + PrimaryKeyFragment
+	primaryKeyType := exported.Ed25519VerificationKey2018
+	contexts := []string{DidCoreContext, Ed25519Context}
+	if exported.IsSecp256k1(didDoc.GetKeyType()) {
+		primaryKeyType = exported.Secp256k1VerificationKey2019
+		contexts = append(contexts, Secp256k1Context)
+	}
+
+	doc := ResolvedDidDocument{
+		Context: contexts,
+		Id:      didDoc.Did,
+		VerificationMethod: []ResolvedVerificationMethod{{
+			Id:              primaryKeyId,
+			Type:            primaryKeyType,
+			Controller:      didDoc.Did,
+			PublicKeyBase58: didDoc.PubKey,
+		}},
+		Authentication:  []string{primaryKeyId},
+		AssertionMethod: []string{primaryKeyId},
+		Service:         didDoc.Services,
+	}
+
+	for _, method := range didDoc.VerificationMethods {
+		doc.VerificationMethod = append(doc.VerificationMethod, ResolvedVerificationMethod{
+			Id:              method.Id,
+			Type:            method.Type,
+			Controller:      didDoc.Did,
+			PublicKeyBase58: method.PublicKeyBase58,
+		})
+		for _, relationship := range method.Relationships {
+			switch relationship {
+			case exported.Authentication:
+				doc.Authentication = append(doc.Authentication, method.Id)
+			case exported.AssertionMethod:
+				doc.AssertionMethod = append(doc.AssertionMethod, method.Id)
+			case exported.KeyAgreement:
+				doc.KeyAgreement = append(doc.KeyAgreement, method.Id)
+			}
+		}
+	}
+
+	if len(doc.KeyAgreement) > 0 {
+		doc.Context = append(doc.Context, X25519Context)
+	}
+
+	return doc
+}
+
+func NewDidResolutionResult(didDoc BaseDidDoc) DidResolutionResult {
+	doc := NewResolvedDidDocument(didDoc)
+	return DidResolutionResult{
+		Context:     DidResolutionContext,
+		DidDocument: &doc,
+		DidResolutionMetadata: DidResolutionMetadata{
+			ContentType: DidLdJsonContentType,
+		},
+		DidDocumentMetadata: DidDocumentMetadata{
+			Deactivated:          didDoc.Deactivated,
+			PubKeyRotationHeight: didDoc.PubKeyRotationHeight,
+		},
+	}
+}
+
+// NewDidDocVersionResolutionResult resolves a past version of a DID doc, as
+// requested using the versionId DID parameter
+func NewDidDocVersionResolutionResult(version DidDocVersion) DidResolutionResult {
+	result := NewDidResolutionResult(version.DidDoc)
+	result.DidDocumentMetadata.VersionId = strconv.FormatUint(version.VersionId, 10)
+	result.DidDocumentMetadata.Updated = version.Time.UTC().Format(time.RFC3339)
+	return result
+}
+
+func NewDidResolutionError(resolutionError string) DidResolutionResult {
+	return DidResolutionResult{
+		Context: DidResolutionContext,
+		DidResolutionMetadata: DidResolutionMetadata{
+			Error: resolutionError,
+		},
+	}
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Schema field types, corresponding to JSON value types
+const (
+	FieldTypeString  = "string"
+	FieldTypeNumber  = "number"
+	FieldTypeBoolean = "boolean"
+	FieldTypeObject  = "object"
+	FieldTypeArray   = "array"
+)
+
+type SchemaField struct {
+	Name     string `json:"name" yaml:"name"`
+	Type     string `json:"type" yaml:"type"`
+	Required bool   `json:"required" yaml:"required"`
+}
+
+func NewSchemaField(name, fieldType string, required bool) SchemaField {
+	return SchemaField{
+		Name:     name,
+		Type:     fieldType,
+		Required: required,
+	}
+}
+
+// CredentialSchema declares the fields of typed claim bodies (e.g. accredited
+// investor, certified evaluator, or residency claims). Claim bodies must be
+// JSON objects with exactly the declared fields, of the declared types.
+type CredentialSchema struct {
+	Id      string        `json:"id" yaml:"id"`
+	Creator exported.Did  `json:"creator" yaml:"creator"`
+	Name    string        `json:"name" yaml:"name"`
+	Fields  []SchemaField `json:"fields" yaml:"fields"`
+}
+
+func NewCredentialSchema(id string, creator exported.Did, name string,
+	fields []SchemaField) CredentialSchema {
+	return CredentialSchema{
+		Id:      id,
+		Creator: creator,
+		Name:    name,
+		Fields:  fields,
+	}
+}
+
+func isValidFieldType(fieldType string) bool {
+	switch fieldType {
+	case FieldTypeString, FieldTypeNumber, FieldTypeBoolean,
+		FieldTypeObject, FieldTypeArray:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s CredentialSchema) Validate() sdk.Error {
+	if strings.TrimSpace(s.Id) == "" || strings.ContainsAny(s.Id, " \t\n") {
+		return ErrorInvalidSchema(DefaultCodespace, "schema id should not be empty or contain whitespace")
+	} else if !IsValidDid(s.Creator) {
+		return ErrorInvalidDid(DefaultCodespace, "schema creator did is invalid")
+	} else if strings.TrimSpace(s.Name) == "" {
+		return ErrorInvalidSchema(DefaultCodespace, "schema name should not be empty")
+	} else if len(s.Fields) == 0 {
+		return ErrorInvalidSchema(DefaultCodespace, "schema should have at least one field")
+	}
+
+	names := make(map[string]bool)
+	for _, field := range s.Fields {
+		if strings.TrimSpace(field.Name) == "" {
+			return ErrorInvalidSchema(DefaultCodespace, "schema field name should not be empty")
+		} else if names[field.Name] {
+			return ErrorInvalidSchema(DefaultCodespace, fmt.Sprintf("duplicate schema field %s", field.Name))
+		} else if !isValidFieldType(field.Type) {
+			return ErrorInvalidSchema(DefaultCodespace, fmt.Sprintf("schema field type %s is invalid", field.Type))
+		}
+		names[field.Name] = true
+	}
+
+	return nil
+}
+
+func hasFieldType(value interface{}, fieldType string) bool {
+	switch value.(type) {
+	case string:
+		return fieldType == FieldTypeString
+	case float64:
+		return fieldType == FieldTypeNumber
+	case bool:
+		return fieldType == FieldTypeBoolean
+	case map[string]interface{}:
+		return fieldType == FieldTypeObject
+	case []interface{}:
+		return fieldType == FieldTypeArray
+	default:
+		return false
+	}
+}
+
+// ValidateClaimBody checks that a claim body is a JSON object conforming to
+// the schema
+func (s CredentialSchema) ValidateClaimBody(body string) sdk.Error {
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &values); err != nil || values == nil {
+		return ErrorInvalidCredentials(DefaultCodespace, "claim body should be a JSON object")
+	}
+
+	for _, field := range s.Fields {
+		value, ok := values[field.Name]
+		if !ok {
+			if field.Required {
+				return ErrorInvalidCredentials(DefaultCodespace, fmt.Sprintf(
+					"claim body is missing required field %s", field.Name))
+			}
+			continue
+		} else if !hasFieldType(value, field.Type) {
+			return ErrorInvalidCredentials(DefaultCodespace, fmt.Sprintf(
+				"claim body field %s should be of type %s", field.Name, field.Type))
+		}
+		delete(values, field.Name)
+	}
+
+	for name := range values {
+		return ErrorInvalidCredentials(DefaultCodespace, fmt.Sprintf(
+			"claim body field %s is not in schema %s", name, s.Id))
+	}
+
+	return nil
+}
@@ -6,11 +6,15 @@ import (
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/tendermint/tendermint/crypto"
+	"net/url"
 	"regexp"
+	"strings"
+	"time"
 )
 
 var (
-	ValidDid      = regexp.MustCompile(`^did:(ixo:|sov:)([a-zA-Z0-9]){21,22}([/][a-zA-Z0-9:]+|)$`)
+	ValidDid      = regexp.MustCompile(`^did:(ixo:|sov:)([a-zA-Z0-9]){21,22}([/][a-zA-Z0-9:]+|)$|^did:key:z6Mk[123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ]{42,45}$`)
 	ValidPubKey   = regexp.MustCompile(`^[123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ]{43,44}$`)
 	IsValidDid    = ValidDid.MatchString
 	IsValidPubKey = ValidPubKey.MatchString
@@ -19,27 +23,99 @@ var (
 	// TODO: ValidDid needs to be updated once we no longer want to be able
 	//   to consider project accounts as DIDs (especially in treasury module),
 	//   possibly should just be `^did:(ixo:|sov:)([a-zA-Z0-9]){21,22}$`.
+	// did:key DIDs (https://w3c-ccg.github.io/did-method-key/) are only
+	// accepted for Ed25519 keys, i.e. with the z6Mk prefix.
 )
 
+func IsValidKeyType(keyType string) bool { return exported.IsValidKeyType(keyType) }
+
+// IsValidPubKeyOfType checks that the PubKey is valid for the key type, where
+// an empty key type means ed25519 (check IsValidKeyType)
+func IsValidPubKeyOfType(keyType, pubKey string) bool {
+	if exported.IsSecp256k1(keyType) {
+		return exported.IsValidPubKeyOfType(keyType, pubKey)
+	}
+	return IsValidPubKey(pubKey)
+}
+
+// PrimaryKeyFragment identifies a DID doc's PubKey within the DID doc (i.e.
+// did:ixo:U7GK8p8rVhJMKhBVRCJJ8c#key-1), so it cannot be a verification method ID
+const PrimaryKeyFragment = "key-1"
+
 var _ exported.DidDoc = (*BaseDidDoc)(nil)
 
 type BaseDidDoc struct {
-	Did         exported.Did             `json:"did" yaml:"did"`
-	PubKey      string                   `json:"pubKey" yaml:"pubKey"`
-	Credentials []exported.DidCredential `json:"credentials" yaml:"credentials"`
+	Did                  exported.Did                  `json:"did" yaml:"did"`
+	PubKey               string                        `json:"pubKey" yaml:"pubKey"`
+	Credentials          []exported.DidCredential      `json:"credentials" yaml:"credentials"`
+	PubKeyRotationHeight int64                         `json:"pubKeyRotationHeight" yaml:"pubKeyRotationHeight"`
+	Deactivated          bool                          `json:"deactivated" yaml:"deactivated"`
+	VerificationMethods  []exported.VerificationMethod `json:"verificationMethods" yaml:"verificationMethods"`
+	Services             []exported.Service            `json:"services" yaml:"services"`
+	Delegates            []exported.Delegate           `json:"delegates" yaml:"delegates"`
+	MultisigKey          exported.MultisigKey          `json:"multisigKey" yaml:"multisigKey"`
+	KeyType              string                        `json:"keyType" yaml:"keyType"`
+	Guardians            exported.Guardians            `json:"guardians" yaml:"guardians"`
 }
 
 func NewBaseDidDoc(did exported.Did, pubKey string) BaseDidDoc {
 	return BaseDidDoc{
-		Did:         did,
-		PubKey:      pubKey,
-		Credentials: []exported.DidCredential{},
+		Did:                 did,
+		PubKey:              pubKey,
+		Credentials:         []exported.DidCredential{},
+		VerificationMethods: []exported.VerificationMethod{},
+		Services:            []exported.Service{},
+		Delegates:           []exported.Delegate{},
 	}
 }
 
 func (dd BaseDidDoc) GetDid() exported.Did                     { return dd.Did }
 func (dd BaseDidDoc) GetPubKey() string                        { return dd.PubKey }
 func (dd BaseDidDoc) GetCredentials() []exported.DidCredential { return dd.Credentials }
+func (dd BaseDidDoc) IsDeactivated() bool                      { return dd.Deactivated }
+func (dd BaseDidDoc) GetVerificationMethods() []exported.VerificationMethod {
+	return dd.VerificationMethods
+}
+func (dd BaseDidDoc) GetServices() []exported.Service      { return dd.Services }
+func (dd BaseDidDoc) GetDelegates() []exported.Delegate    { return dd.Delegates }
+func (dd BaseDidDoc) GetMultisigKey() exported.MultisigKey { return dd.MultisigKey }
+func (dd BaseDidDoc) IsMultisig() bool                     { return !dd.MultisigKey.IsEmpty() }
+func (dd BaseDidDoc) GetGuardians() exported.Guardians     { return dd.Guardians }
+
+// GetKeyType returns the key type of the DID doc's PubKey, which is ed25519
+// unless specified otherwise
+func (dd BaseDidDoc) GetKeyType() string {
+	if dd.KeyType == "" {
+		return exported.Ed25519KeyType
+	}
+	return dd.KeyType
+}
+
+// GetAuthenticationPubKeys returns the PubKeys of the DID doc's authentication
+// verification methods, which can sign on behalf of the DID in addition to the
+// DID doc's (primary) PubKey.
+func (dd BaseDidDoc) GetAuthenticationPubKeys() []string {
+	var pubKeys []string
+	for _, method := range dd.VerificationMethods {
+		if method.HasRelationship(exported.Authentication) {
+			pubKeys = append(pubKeys, method.PublicKeyBase58)
+		}
+	}
+	return pubKeys
+}
+
+// GetKeyAgreementPubKeys returns the (X25519) PubKeys of the DID doc's
+// keyAgreement verification methods, which can be used to encrypt data for
+// the DID, such as encrypted memos.
+func (dd BaseDidDoc) GetKeyAgreementPubKeys() []string {
+	var pubKeys []string
+	for _, method := range dd.VerificationMethods {
+		if method.HasRelationship(exported.KeyAgreement) {
+			pubKeys = append(pubKeys, method.PublicKeyBase58)
+		}
+	}
+	return pubKeys
+}
 
 func (dd BaseDidDoc) SetDid(did exported.Did) error {
 	if len(dd.Did) != 0 {
@@ -61,8 +137,38 @@ func (dd BaseDidDoc) SetPubKey(pubKey string) error {
 	return nil
 }
 
+// Address returns the address of the DID doc's multisig key set, if any, and
+// otherwise the address of the DID doc's PubKey
 func (dd BaseDidDoc) Address() sdk.AccAddress {
-	return exported.VerifyKeyToAddr(dd.GetPubKey())
+	if dd.IsMultisig() {
+		return dd.MultisigKey.Address()
+	}
+	return exported.PubKeyToAddr(dd.GetKeyType(), dd.GetPubKey())
+}
+
+func (dd *BaseDidDoc) SetMultisigKey(multisigKey exported.MultisigKey) {
+	dd.MultisigKey = multisigKey
+}
+
+func (dd *BaseDidDoc) RemoveMultisigKey() {
+	dd.MultisigKey = exported.MultisigKey{}
+}
+
+func (dd *BaseDidDoc) SetGuardians(guardians exported.Guardians) {
+	dd.Guardians = guardians
+}
+
+// RotatePubKey replaces the DID doc's PubKey, unlike SetPubKey which refuses to
+// override it. The DID itself remains unchanged, so that it does not have to be
+// deducible from the new PubKey, which can also be of a different key type.
+func (dd *BaseDidDoc) RotatePubKey(pubKey, keyType string, height int64) {
+	dd.PubKey = pubKey
+	dd.KeyType = keyType
+	dd.PubKeyRotationHeight = height
+}
+
+func (dd *BaseDidDoc) Deactivate() {
+	dd.Deactivated = true
 }
 
 func (dd *BaseDidDoc) AddCredential(cred exported.DidCredential) {
@@ -73,8 +179,440 @@ func (dd *BaseDidDoc) AddCredential(cred exported.DidCredential) {
 	dd.Credentials = append(dd.Credentials, cred)
 }
 
+// GetValidCredentials excludes credentials that are revoked or expired at the
+// specified height and time
+func (dd BaseDidDoc) GetValidCredentials(height int64, t time.Time) []exported.DidCredential {
+	var credentials []exported.DidCredential
+	for _, cred := range dd.Credentials {
+		if cred.IsValidAt(height, t) {
+			credentials = append(credentials, cred)
+		}
+	}
+	return credentials
+}
+
+func (dd BaseDidDoc) GetCredential(id string) (exported.DidCredential, bool) {
+	for _, cred := range dd.Credentials {
+		if cred.Id == id {
+			return cred, true
+		}
+	}
+	return exported.DidCredential{}, false
+}
+
+func (dd *BaseDidDoc) RevokeCredential(id string, height int64) {
+	for i := range dd.Credentials {
+		if dd.Credentials[i].Id == id {
+			dd.Credentials[i].Revoked = true
+			dd.Credentials[i].RevokedHeight = height
+		}
+	}
+}
+
+// GetAssertionPubKeys returns the DID doc's PubKey (of the DID doc's key type)
+// and the Ed25519 PubKeys of its assertion verification methods, which can
+// sign credentials issued by the DID
+func (dd BaseDidDoc) GetAssertionPubKeys() []crypto.PubKey {
+	pubKeys := []crypto.PubKey{exported.PubKeyFromBase58(dd.GetKeyType(), dd.PubKey)}
+	for _, method := range dd.VerificationMethods {
+		if method.HasRelationship(exported.AssertionMethod) {
+			pubKeys = append(pubKeys, exported.PubKeyFromBase58(
+				exported.Ed25519KeyType, method.PublicKeyBase58))
+		}
+	}
+	return pubKeys
+}
+
+func (dd BaseDidDoc) HasVerificationMethod(id string) bool {
+	for _, method := range dd.VerificationMethods {
+		if method.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (dd *BaseDidDoc) AddVerificationMethod(method exported.VerificationMethod) {
+	dd.VerificationMethods = append(dd.VerificationMethods, method)
+}
+
+func (dd *BaseDidDoc) RemoveVerificationMethod(id string) {
+	methods := make([]exported.VerificationMethod, 0)
+	for _, method := range dd.VerificationMethods {
+		if method.Id != id {
+			methods = append(methods, method)
+		}
+	}
+	dd.VerificationMethods = methods
+}
+
+// RemoveSigningVerificationMethods removes all verification methods that have
+// the authentication or assertion relationship, i.e. those that can sign on
+// behalf of the DID
+func (dd *BaseDidDoc) RemoveSigningVerificationMethods() {
+	methods := make([]exported.VerificationMethod, 0)
+	for _, method := range dd.VerificationMethods {
+		if !method.HasRelationship(exported.Authentication) &&
+			!method.HasRelationship(exported.AssertionMethod) {
+			methods = append(methods, method)
+		}
+	}
+	dd.VerificationMethods = methods
+}
+
+func (dd BaseDidDoc) HasService(id string) bool {
+	for _, service := range dd.Services {
+		if service.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (dd *BaseDidDoc) AddService(service exported.Service) {
+	dd.Services = append(dd.Services, service)
+}
+
+func (dd *BaseDidDoc) RemoveService(id string) {
+	services := make([]exported.Service, 0)
+	for _, service := range dd.Services {
+		if service.Id != id {
+			services = append(services, service)
+		}
+	}
+	dd.Services = services
+}
+
+func (dd BaseDidDoc) GetDelegate(did exported.Did) (exported.Delegate, bool) {
+	for _, delegate := range dd.Delegates {
+		if delegate.Did == did {
+			return delegate, true
+		}
+	}
+	return exported.Delegate{}, false
+}
+
+// GetDelegateDids returns the DIDs of the delegates that can sign messages
+// of the specified route (module) and type on behalf of the DID.
+func (dd BaseDidDoc) GetDelegateDids(route, msgType string) []exported.Did {
+	var dids []exported.Did
+	for _, delegate := range dd.Delegates {
+		if delegate.IsAuthorisedFor(route, msgType) {
+			dids = append(dids, delegate.Did)
+		}
+	}
+	return dids
+}
+
+// SetDelegate adds the delegate, or replaces the scopes of an existing one
+func (dd *BaseDidDoc) SetDelegate(delegate exported.Delegate) {
+	for i, existing := range dd.Delegates {
+		if existing.Did == delegate.Did {
+			dd.Delegates[i] = delegate
+			return
+		}
+	}
+	dd.Delegates = append(dd.Delegates, delegate)
+}
+
+func (dd *BaseDidDoc) RemoveDelegate(did exported.Did) {
+	delegates := make([]exported.Delegate, 0)
+	for _, delegate := range dd.Delegates {
+		if delegate.Did != did {
+			delegates = append(delegates, delegate)
+		}
+	}
+	dd.Delegates = delegates
+}
+
+func (dd *BaseDidDoc) RemoveAllDelegates() {
+	dd.Delegates = make([]exported.Delegate, 0)
+}
+
 type Credential struct{}
 
+func NewCredentialId(index int) string {
+	return fmt.Sprintf("cred-%d", index+1)
+}
+
+// CredentialStatus is the status of a credential at a specific height and time
+type CredentialStatus struct {
+	Credential exported.DidCredential `json:"credential" yaml:"credential"`
+	Height     int64                  `json:"height" yaml:"height"`
+	Time       time.Time              `json:"time" yaml:"time"`
+	Valid      bool                   `json:"valid" yaml:"valid"`
+	Revoked    bool                   `json:"revoked" yaml:"revoked"`
+	Expired    bool                   `json:"expired" yaml:"expired"`
+}
+
+func NewCredentialStatus(credential exported.DidCredential, height int64, t time.Time) CredentialStatus {
+	return CredentialStatus{
+		Credential: credential,
+		Height:     height,
+		Time:       t,
+		Valid:      credential.IsValidAt(height, t),
+		Revoked:    credential.IsRevokedAt(height),
+		Expired:    credential.IsExpiredAt(t),
+	}
+}
+
+// isValidDidUrl checks that the ID is a DID URL of the form <did>#<fragment>
+func isValidDidUrl(did exported.Did, id string) bool {
+	prefix := did + "#"
+	return strings.HasPrefix(id, prefix) && len(id) > len(prefix) &&
+		!strings.ContainsAny(id[len(prefix):], "# ")
+}
+
+func ValidateVerificationMethod(did exported.Did, method exported.VerificationMethod) sdk.Error {
+	if !isValidDidUrl(did, method.Id) {
+		return ErrorInvalidVerificationMethod(DefaultCodespace, fmt.Sprintf(
+			"verification method id should be of the form %s#<fragment>", did))
+	} else if method.Id == did+"#"+PrimaryKeyFragment {
+		return ErrorInvalidVerificationMethod(DefaultCodespace, fmt.Sprintf(
+			"verification method id fragment %s is reserved for the did's pubKey", PrimaryKeyFragment))
+	} else if !IsValidPubKey(method.PublicKeyBase58) {
+		return ErrorInvalidPubKey(DefaultCodespace, "verification method publicKeyBase58 is invalid")
+	} else if len(method.Relationships) == 0 {
+		return ErrorInvalidVerificationMethod(DefaultCodespace, "verification method relationships should not be empty")
+	}
+
+	// Ed25519 keys can be used for signing and X25519 keys for key agreement
+	for _, relationship := range method.Relationships {
+		switch relationship {
+		case exported.Authentication, exported.AssertionMethod:
+			if method.Type != exported.Ed25519VerificationKey2018 {
+				return ErrorInvalidVerificationMethod(DefaultCodespace, fmt.Sprintf(
+					"%s requires a verification method of type %s",
+					relationship, exported.Ed25519VerificationKey2018))
+			}
+		case exported.KeyAgreement:
+			if method.Type != exported.X25519KeyAgreementKey2019 {
+				return ErrorInvalidVerificationMethod(DefaultCodespace, fmt.Sprintf(
+					"%s requires a verification method of type %s",
+					relationship, exported.X25519KeyAgreementKey2019))
+			}
+		default:
+			return ErrorInvalidVerificationMethod(DefaultCodespace, fmt.Sprintf(
+				"verification relationship %s is invalid", relationship))
+		}
+	}
+
+	return nil
+}
+
+func ValidateService(did exported.Did, service exported.Service) sdk.Error {
+	if !isValidDidUrl(did, service.Id) {
+		return ErrorInvalidService(DefaultCodespace, fmt.Sprintf(
+			"service id should be of the form %s#<fragment>", did))
+	} else if strings.TrimSpace(service.Type) == "" {
+		return ErrorInvalidService(DefaultCodespace, "service type should not be empty")
+	}
+
+	endpoint, err := url.ParseRequestURI(service.ServiceEndpoint)
+	if err != nil || endpoint.Scheme == "" {
+		return ErrorInvalidService(DefaultCodespace, "service endpoint should be a valid URI")
+	}
+
+	return nil
+}
+
+// NonDelegableMsgTypes are the types of the did messages that manage a DID's
+// keys, delegates and guardians. These can only be signed by the DID itself,
+// so no delegate scope can cover them (including the did module scope).
+var NonDelegableMsgTypes = []string{
+	TypeMsgUpdateDidPubKey, TypeMsgDeactivateDid,
+	TypeMsgAddVerificationMethod, TypeMsgRemoveVerificationMethod,
+	TypeMsgGrantDelegation, TypeMsgRevokeDelegation,
+	TypeMsgSetMultisigKey, TypeMsgRemoveMultisigKey,
+	TypeMsgSetGuardians, TypeMsgCancelRecovery,
+}
+
+// coversNonDelegableMsg checks whether a delegate scope covers any of the
+// messages that cannot be delegated
+func coversNonDelegableMsg(scope string) bool {
+	if scope == RouterKey {
+		return true
+	}
+	for _, msgType := range NonDelegableMsgTypes {
+		if scope == RouterKey+"/"+msgType {
+			return true
+		}
+	}
+	return false
+}
+
+func ValidateDelegate(did exported.Did, delegate exported.Delegate) sdk.Error {
+	if !IsValidDid(delegate.Did) {
+		return ErrorInvalidDelegate(DefaultCodespace, "delegate did is invalid")
+	} else if delegate.Did == did {
+		return ErrorInvalidDelegate(DefaultCodespace, "a did cannot be its own delegate")
+	} else if len(delegate.Scopes) == 0 {
+		return ErrorInvalidDelegate(DefaultCodespace, "delegate scopes should not be empty")
+	}
+
+	// Scopes are of the form <module> or <module>/<msg type>
+	for _, scope := range delegate.Scopes {
+		parts := strings.Split(scope, "/")
+		if len(parts) > 2 || strings.ContainsAny(scope, " ") {
+			return ErrorInvalidDelegate(DefaultCodespace, fmt.Sprintf(
+				"delegate scope %s should be of the form <module> or <module>/<msg type>", scope))
+		}
+		for _, part := range parts {
+			if strings.TrimSpace(part) == "" {
+				return ErrorInvalidDelegate(DefaultCodespace, fmt.Sprintf(
+					"delegate scope %s should be of the form <module> or <module>/<msg type>", scope))
+			}
+		}
+		if coversNonDelegableMsg(scope) {
+			return ErrorInvalidDelegate(DefaultCodespace, fmt.Sprintf(
+				"delegate scope %s covers key management messages, which cannot be delegated", scope))
+		}
+	}
+
+	return nil
+}
+
+func ValidateMultisigKey(multisigKey exported.MultisigKey) sdk.Error {
+	if len(multisigKey.PubKeys) < 2 {
+		return ErrorInvalidMultisigKey(DefaultCodespace, "multisig key set should have at least 2 pubKeys")
+	} else if len(multisigKey.PubKeys) > exported.MaxMultisigPubKeys {
+		return ErrorInvalidMultisigKey(DefaultCodespace, fmt.Sprintf(
+			"multisig key set should have at most %d pubKeys", exported.MaxMultisigPubKeys))
+	} else if multisigKey.Threshold == 0 || multisigKey.Threshold > uint(len(multisigKey.PubKeys)) {
+		return ErrorInvalidMultisigKey(DefaultCodespace, fmt.Sprintf(
+			"multisig threshold should be between 1 and %d", len(multisigKey.PubKeys)))
+	}
+
+	pubKeys := make(map[string]bool)
+	for _, pubKey := range multisigKey.PubKeys {
+		if !IsValidPubKey(pubKey) {
+			return ErrorInvalidPubKey(DefaultCodespace, fmt.Sprintf("multisig pubKey %s is invalid", pubKey))
+		} else if pubKeys[pubKey] {
+			return ErrorInvalidMultisigKey(DefaultCodespace, fmt.Sprintf("duplicate multisig pubKey %s", pubKey))
+		}
+		pubKeys[pubKey] = true
+	}
+
+	return nil
+}
+
+func ValidateGuardians(did exported.Did, guardians exported.Guardians) sdk.Error {
+	if len(guardians.Dids) > exported.MaxGuardians {
+		return ErrorInvalidGuardians(DefaultCodespace, fmt.Sprintf(
+			"did should have at most %d guardians", exported.MaxGuardians))
+	} else if guardians.Threshold == 0 || guardians.Threshold > uint(len(guardians.Dids)) {
+		return ErrorInvalidGuardians(DefaultCodespace, fmt.Sprintf(
+			"guardian threshold should be between 1 and %d", len(guardians.Dids)))
+	} else if guardians.RecoveryDelay <= 0 {
+		return ErrorInvalidGuardians(DefaultCodespace, "recovery delay should be positive")
+	}
+
+	guardianDids := make(map[string]bool)
+	for _, guardian := range guardians.Dids {
+		if !IsValidDid(guardian) {
+			return ErrorInvalidGuardians(DefaultCodespace, fmt.Sprintf("guardian %s is invalid", guardian))
+		} else if guardian == did {
+			return ErrorInvalidGuardians(DefaultCodespace, "did cannot be its own guardian")
+		} else if guardianDids[guardian] {
+			return ErrorInvalidGuardians(DefaultCodespace, fmt.Sprintf("duplicate guardian %s", guardian))
+		}
+		guardianDids[guardian] = true
+	}
+
+	return nil
+}
+
+func ValidateEncryptedMemo(memo exported.EncryptedMemo) sdk.Error {
+	if !IsValidDid(memo.RecipientDid) {
+		return ErrorInvalidDid(DefaultCodespace, "memo recipient did is invalid")
+	} else if err := memo.Validate(); err != nil {
+		return ErrorInvalidEncryptedMemo(DefaultCodespace, err.Error())
+	}
+	return nil
+}
+
+// NewEncryptedMemoEvent returns the event emitted by modules whose messages
+// carry an encrypted memo, which allows recipients to search for their memos
+func NewEncryptedMemoEvent(senderDid exported.Did, memo exported.EncryptedMemo) sdk.Event {
+	return sdk.NewEvent(
+		EventTypeEncryptedMemo,
+		sdk.NewAttribute(AttributeKeySenderDid, senderDid),
+		sdk.NewAttribute(AttributeKeyRecipientDid, memo.RecipientDid),
+	)
+}
+
+// ValidateDidDoc validates the verification methods, services and delegates of a DID doc
+func ValidateDidDoc(didDoc BaseDidDoc) sdk.Error {
+	if !IsValidDid(didDoc.Did) {
+		return ErrorInvalidDid(DefaultCodespace, fmt.Sprintf("did %s is invalid", didDoc.Did))
+	} else if !IsValidKeyType(didDoc.KeyType) {
+		return ErrorInvalidPubKey(DefaultCodespace, fmt.Sprintf("keyType of did %s is invalid", didDoc.Did))
+	} else if !IsValidPubKeyOfType(didDoc.KeyType, didDoc.PubKey) {
+		return ErrorInvalidPubKey(DefaultCodespace, fmt.Sprintf("pubKey of did %s is invalid", didDoc.Did))
+	}
+
+	methodIds := make(map[string]bool)
+	for _, method := range didDoc.VerificationMethods {
+		if err := ValidateVerificationMethod(didDoc.Did, method); err != nil {
+			return err
+		} else if methodIds[method.Id] {
+			return ErrorInvalidVerificationMethod(DefaultCodespace, fmt.Sprintf(
+				"duplicate verification method %s", method.Id))
+		}
+		methodIds[method.Id] = true
+	}
+
+	serviceIds := make(map[string]bool)
+	for _, service := range didDoc.Services {
+		if err := ValidateService(didDoc.Did, service); err != nil {
+			return err
+		} else if serviceIds[service.Id] {
+			return ErrorInvalidService(DefaultCodespace, fmt.Sprintf(
+				"duplicate service %s", service.Id))
+		}
+		serviceIds[service.Id] = true
+	}
+
+	delegateDids := make(map[string]bool)
+	for _, delegate := range didDoc.Delegates {
+		if err := ValidateDelegate(didDoc.Did, delegate); err != nil {
+			return err
+		} else if delegateDids[delegate.Did] {
+			return ErrorInvalidDelegate(DefaultCodespace, fmt.Sprintf(
+				"duplicate delegate %s", delegate.Did))
+		}
+		delegateDids[delegate.Did] = true
+	}
+
+	if didDoc.IsMultisig() {
+		if err := ValidateMultisigKey(didDoc.MultisigKey); err != nil {
+			return err
+		}
+	}
+
+	if !didDoc.Guardians.IsEmpty() {
+		if err := ValidateGuardians(didDoc.Did, didDoc.Guardians); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ValidateAccessPolicy(policy exported.AccessPolicy) sdk.Error {
+	for _, credType := range policy.RequiredCredentialTypes {
+		if strings.TrimSpace(credType) == "" {
+			return ErrorInvalidCredentials(DefaultCodespace, "access policy credential type should not be empty")
+		}
+	}
+	for _, issuer := range policy.TrustedIssuers {
+		if !IsValidDid(issuer) {
+			return ErrorInvalidIssuer(DefaultCodespace, fmt.Sprintf("access policy trusted issuer %s is invalid", issuer))
+		}
+	}
+	return nil
+}
+
 func fromJsonString(jsonIxoDid string) (exported.IxoDid, error) {
 	var did exported.IxoDid
 	err := json.Unmarshal([]byte(jsonIxoDid), &did)
@@ -8,6 +8,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/gorilla/mux"
 	"github.com/spf13/cobra"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -61,6 +63,25 @@ func (AppModuleBasic) GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	didTxCmd.AddCommand(client.PostCommands(
 		cli.GetCmdAddDidDoc(cdc),
 		cli.GetCmdAddCredential(cdc),
+		cli.GetCmdRevokeCredential(cdc),
+		cli.GetCmdAddCredentialSchema(cdc),
+		cli.GetCmdUpdateDidPubKey(cdc),
+		cli.GetCmdDeactivateDid(cdc),
+		cli.GetCmdAddVerificationMethod(cdc),
+		cli.GetCmdAddKeyAgreementMethod(cdc),
+		cli.GetCmdRemoveVerificationMethod(cdc),
+		cli.GetCmdAddService(cdc),
+		cli.GetCmdRemoveService(cdc),
+		cli.GetCmdGrantDelegation(cdc),
+		cli.GetCmdRevokeDelegation(cdc),
+		cli.GetCmdSetMultisigKey(cdc),
+		cli.GetCmdRemoveMultisigKey(cdc),
+		cli.GetCmdSignMultisig(cdc),
+		cli.GetCmdMultisign(cdc),
+		cli.GetCmdSetGuardians(cdc),
+		cli.GetCmdStartRecovery(cdc),
+		cli.GetCmdApproveRecovery(cdc),
+		cli.GetCmdCancelRecovery(cdc),
 	)...)
 
 	return didTxCmd
@@ -78,9 +99,20 @@ func (AppModuleBasic) GetQueryCmd(cdc *codec.Codec) *cobra.Command {
 	didQueryCmd.AddCommand(client.GetCommands(
 		cli.GetCmdAddressFromBase58Pubkey(),
 		cli.GetCmdAddressFromDid(cdc),
+		cli.GetCmdDidFromAddress(cdc),
 		cli.GetCmdDidDoc(cdc),
+		cli.GetCmdDidDocHistory(cdc),
+		cli.GetCmdRecovery(cdc),
 		cli.GetCmdAllDids(cdc),
 		cli.GetCmdAllDidDocs(cdc),
+		cli.GetCmdResolveDid(cdc),
+		cli.GetCmdCredentialStatus(cdc),
+		cli.GetCmdCredentials(cdc),
+		cli.GetCmdCredentialSchema(cdc),
+		cli.GetCmdAllCredentialSchemas(cdc),
+		cli.GetCmdTrustedIssuers(cdc),
+		cli.GetCmdEncryptedMemos(cdc),
+		cli.GetParamsRequestHandler(cdc),
 	)...)
 
 	return didQueryCmd
@@ -88,13 +120,17 @@ func (AppModuleBasic) GetQueryCmd(cdc *codec.Codec) *cobra.Command {
 
 type AppModule struct {
 	AppModuleBasic
-	keeper keeper.Keeper
+	keeper        keeper.Keeper
+	bankKeeper    bank.Keeper
+	accountKeeper auth.AccountKeeper
 }
 
-func NewAppModule(keeper Keeper) AppModule {
+func NewAppModule(keeper Keeper, bankKeeper bank.Keeper, accountKeeper auth.AccountKeeper) AppModule {
 	return AppModule{
 		AppModuleBasic: AppModuleBasic{},
 		keeper:         keeper,
+		bankKeeper:     bankKeeper,
+		accountKeeper:  accountKeeper,
 	}
 }
 
@@ -108,7 +144,9 @@ func (AppModule) Route() string {
 	return RouterKey
 }
 
-func (am AppModule) NewHandler() sdk.Handler { return NewHandler(am.keeper) }
+func (am AppModule) NewHandler() sdk.Handler {
+	return NewHandler(am.keeper, am.bankKeeper, am.accountKeeper)
+}
 
 func (AppModule) QuerierRoute() string { return QuerierRoute }
 
@@ -131,6 +169,6 @@ func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
 func (am AppModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
 }
 
-func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
-	return []abci.ValidatorUpdate{}
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	return EndBlocker(ctx, am.keeper, am.bankKeeper, am.accountKeeper)
 }
@@ -0,0 +1,214 @@
+package vc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+	"github.com/tendermint/tendermint/crypto"
+	"strings"
+	"time"
+)
+
+// JWS headers of the detached (unencoded payload) JWS signatures
+// Ref: https://tools.ietf.org/html/rfc7797
+const (
+	ed25519JwsHeader   = `{"alg":"EdDSA","b64":false,"crit":["b64"]}`
+	secp256k1JwsHeader = `{"alg":"ES256K","b64":false,"crit":["b64"]}`
+)
+
+// CanonicalJSON returns the canonical form of the JSON-encoded value, i.e.
+// the JSON with the object keys sorted, without insignificant whitespace and
+// without HTML escaping
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(bz))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	// Encoding a generic value sorts the keys of its objects
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(generic); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// signingInput is the payload signed by a proof: the SHA-256 hash of the
+// canonical proof (without the JWS) followed by the SHA-256 hash of the
+// canonical document (without the proof)
+func signingInput(proof Proof, document interface{}) ([]byte, error) {
+	proof.Jws = ""
+	proofBz, err := CanonicalJSON(struct {
+		Context string `json:"@context"`
+		Proof
+	}{CredentialsContext, proof})
+	if err != nil {
+		return nil, err
+	}
+
+	documentBz, err := CanonicalJSON(document)
+	if err != nil {
+		return nil, err
+	}
+
+	proofHash := sha256.Sum256(proofBz)
+	documentHash := sha256.Sum256(documentBz)
+	return append(proofHash[:], documentHash[:]...), nil
+}
+
+func jwsHeader(proofType string) (string, error) {
+	switch proofType {
+	case Ed25519Signature2018:
+		return base64.RawURLEncoding.EncodeToString([]byte(ed25519JwsHeader)), nil
+	case EcdsaSecp256k1Signature2019:
+		return base64.RawURLEncoding.EncodeToString([]byte(secp256k1JwsHeader)), nil
+	default:
+		return "", fmt.Errorf("unsupported proof type %s", proofType)
+	}
+}
+
+// proofTypeOf returns the proof type used by a DID with the key type
+func proofTypeOf(keyType string) string {
+	if exported.IsSecp256k1(keyType) {
+		return EcdsaSecp256k1Signature2019
+	}
+	return Ed25519Signature2018
+}
+
+// verificationMethodTypeOf returns the type of the verification methods that
+// can make proofs of the proof type
+func verificationMethodTypeOf(proofType string) string {
+	if proofType == EcdsaSecp256k1Signature2019 {
+		return exported.Secp256k1VerificationKey2019
+	}
+	return exported.Ed25519VerificationKey2018
+}
+
+// createProof signs the document (which should not include a proof) using
+// the primary key (<did>#key-1) of the IxoDid
+func createProof(ixoDid exported.IxoDid, document interface{},
+	purpose, challenge, domain string, created time.Time) (*Proof, error) {
+	proof := Proof{
+		Type:               proofTypeOf(ixoDid.KeyType),
+		Created:            formatTime(created),
+		VerificationMethod: ixoDid.Did + "#" + types.PrimaryKeyFragment,
+		ProofPurpose:       purpose,
+		Challenge:          challenge,
+		Domain:             domain,
+	}
+
+	input, err := signingInput(proof, document)
+	if err != nil {
+		return nil, err
+	}
+	header, err := jwsHeader(proof.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := ixoDid.PrivKey().Sign(append([]byte(header+"."), input...))
+	if err != nil {
+		return nil, err
+	}
+
+	proof.Jws = header + ".." + base64.RawURLEncoding.EncodeToString(sig)
+	return &proof, nil
+}
+
+// verifyProof verifies the proof of the document (which should not include
+// the proof) against the verification method resolved from chain state. The
+// verification method has to belong to the controller DID and to be one of
+// its keys for the proof purpose.
+func verifyProof(proof *Proof, document interface{}, controller exported.Did,
+	purpose string, resolve Resolver) error {
+	if proof == nil {
+		return fmt.Errorf("proof is missing")
+	} else if proof.ProofPurpose != purpose {
+		return fmt.Errorf("proof purpose should be %s", purpose)
+	} else if !strings.HasPrefix(proof.VerificationMethod, controller+"#") {
+		return fmt.Errorf("verification method %s does not belong to %s",
+			proof.VerificationMethod, controller)
+	}
+
+	pubKey, err := resolvePubKey(resolve, controller, proof.VerificationMethod, purpose, proof.Type)
+	if err != nil {
+		return err
+	}
+
+	header, err := jwsHeader(proof.Type)
+	if err != nil {
+		return err
+	}
+	parts := strings.Split(proof.Jws, "..")
+	if len(parts) != 2 || parts[0] != header {
+		return fmt.Errorf("proof jws is invalid")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("proof jws signature is invalid")
+	}
+
+	input, err := signingInput(*proof, document)
+	if err != nil {
+		return err
+	}
+	if !pubKey.VerifyBytes(append([]byte(header+"."), input...), sig) {
+		return fmt.Errorf("proof signature verification failed")
+	}
+	return nil
+}
+
+func resolvePubKey(resolve Resolver, did exported.Did, methodId, purpose,
+	proofType string) (crypto.PubKey, error) {
+	result, err := resolve(did)
+	if err != nil {
+		return nil, err
+	} else if result.DidDocument == nil {
+		return nil, fmt.Errorf("did %s could not be resolved: %s",
+			did, result.DidResolutionMetadata.Error)
+	} else if result.DidDocumentMetadata.Deactivated {
+		return nil, fmt.Errorf("did %s is deactivated", did)
+	}
+
+	relationships := result.DidDocument.Authentication
+	if purpose == AssertionMethodPurpose {
+		relationships = result.DidDocument.AssertionMethod
+	}
+	if !contains(relationships, methodId) {
+		return nil, fmt.Errorf("verification method %s is not a %s key of %s",
+			methodId, purpose, did)
+	}
+
+	for _, method := range result.DidDocument.VerificationMethod {
+		if method.Id != methodId {
+			continue
+		} else if method.Type != verificationMethodTypeOf(proofType) {
+			return nil, fmt.Errorf("verification method %s cannot make %s proofs",
+				methodId, proofType)
+		}
+
+		keyType := exported.Ed25519KeyType
+		if proofType == EcdsaSecp256k1Signature2019 {
+			keyType = exported.Secp256k1KeyType
+		}
+		if !exported.IsValidPubKeyOfType(keyType, method.PublicKeyBase58) {
+			return nil, fmt.Errorf("verification method %s has an invalid pubKey", methodId)
+		}
+		return exported.PubKeyFromBase58(keyType, method.PublicKeyBase58), nil
+	}
+
+	return nil, fmt.Errorf("verification method %s not found", methodId)
+}
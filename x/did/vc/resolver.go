@@ -0,0 +1,37 @@
+package vc
+
+import (
+	"fmt"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/keeper"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+)
+
+type DidResolutionResult = types.DidResolutionResult
+
+// Resolver resolves a DID to its W3C DID document, from which the keys used
+// to verify proofs are taken. Off-chain services can use NewQuerierResolver,
+// or unmarshal the result of the DID resolution REST endpoint.
+type Resolver func(did exported.Did) (DidResolutionResult, error)
+
+// NewQuerierResolver resolves DIDs from chain state through the did querier
+func NewQuerierResolver(cliCtx context.CLIContext) Resolver {
+	return func(did exported.Did) (DidResolutionResult, error) {
+		if !types.IsValidDid(did) {
+			return types.NewDidResolutionError(types.ResolutionErrorInvalidDid), nil
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s",
+			types.QuerierRoute, keeper.QueryDidDoc, did), nil)
+		if err != nil || len(res) == 0 {
+			return types.NewDidResolutionError(types.ResolutionErrorNotFound), nil
+		}
+
+		var didDoc types.BaseDidDoc
+		if err := cliCtx.Codec.UnmarshalJSON(res, &didDoc); err != nil {
+			return DidResolutionResult{}, err
+		}
+		return types.NewDidResolutionResult(didDoc), nil
+	}
+}
@@ -0,0 +1,153 @@
+package vc
+
+import (
+	"fmt"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+	"time"
+)
+
+// W3C Verifiable Credentials signed off-chain using the keys of DIDs
+// Ref: https://www.w3.org/TR/vc-data-model/
+const (
+	CredentialsContext = "https://www.w3.org/2018/credentials/v1"
+
+	VerifiableCredentialType   = "VerifiableCredential"
+	VerifiablePresentationType = "VerifiablePresentation"
+
+	Ed25519Signature2018        = "Ed25519Signature2018"
+	EcdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
+
+	// Credentials are signed using assertion keys and presentations using
+	// authentication keys of the issuer and holder respectively
+	AssertionMethodPurpose = exported.AssertionMethod
+	AuthenticationPurpose  = exported.Authentication
+)
+
+// Proof is a linked data proof of type Ed25519Signature2018 (or, for DIDs
+// with secp256k1 keys, EcdsaSecp256k1Signature2019), with the signature as a
+// detached JWS
+type Proof struct {
+	Type               string `json:"type"`
+	Created            string `json:"created"`
+	VerificationMethod string `json:"verificationMethod"`
+	ProofPurpose       string `json:"proofPurpose"`
+	Challenge          string `json:"challenge,omitempty"`
+	Domain             string `json:"domain,omitempty"`
+	Jws                string `json:"jws,omitempty"`
+}
+
+type VerifiableCredential struct {
+	Context           []string               `json:"@context"`
+	Id                string                 `json:"id,omitempty"`
+	Type              []string               `json:"type"`
+	Issuer            exported.Did           `json:"issuer"`
+	IssuanceDate      string                 `json:"issuanceDate"`
+	ExpirationDate    string                 `json:"expirationDate,omitempty"`
+	CredentialSubject map[string]interface{} `json:"credentialSubject"`
+	Proof             *Proof                 `json:"proof,omitempty"`
+}
+
+// NewVerifiableCredential creates an unsigned credential. The subject DID is
+// added to the claims as the credential subject's id. The expiration date is
+// optional (zero).
+func NewVerifiableCredential(id string, credTypes []string, issuer exported.Did,
+	subject exported.Did, claims map[string]interface{},
+	issuanceDate, expirationDate time.Time) VerifiableCredential {
+
+	credentialSubject := map[string]interface{}{"id": subject}
+	for key, value := range claims {
+		if key != "id" {
+			credentialSubject[key] = value
+		}
+	}
+
+	credential := VerifiableCredential{
+		Context:           []string{CredentialsContext},
+		Id:                id,
+		Type:              append([]string{VerifiableCredentialType}, credTypes...),
+		Issuer:            issuer,
+		IssuanceDate:      formatTime(issuanceDate),
+		CredentialSubject: credentialSubject,
+	}
+	if !expirationDate.IsZero() {
+		credential.ExpirationDate = formatTime(expirationDate)
+	}
+	return credential
+}
+
+// GetSubject returns the DID of the credential subject, if any
+func (vc VerifiableCredential) GetSubject() exported.Did {
+	subject, _ := vc.CredentialSubject["id"].(string)
+	return subject
+}
+
+// IsExpired checks whether the credential has an expiration date before t
+func (vc VerifiableCredential) IsExpired(t time.Time) bool {
+	if vc.ExpirationDate == "" {
+		return false
+	}
+	expires, err := time.Parse(time.RFC3339, vc.ExpirationDate)
+	return err != nil || !t.Before(expires)
+}
+
+func (vc VerifiableCredential) Validate() error {
+	if len(vc.Context) == 0 || vc.Context[0] != CredentialsContext {
+		return fmt.Errorf("credential @context should start with %s", CredentialsContext)
+	} else if !contains(vc.Type, VerifiableCredentialType) {
+		return fmt.Errorf("credential type should include %s", VerifiableCredentialType)
+	} else if !types.IsValidDid(vc.Issuer) {
+		return fmt.Errorf("credential issuer %s is not a valid did", vc.Issuer)
+	} else if _, err := time.Parse(time.RFC3339, vc.IssuanceDate); err != nil {
+		return fmt.Errorf("credential issuance date is invalid")
+	} else if vc.CredentialSubject == nil {
+		return fmt.Errorf("credential subject is empty")
+	}
+	return nil
+}
+
+type VerifiablePresentation struct {
+	Context              []string               `json:"@context"`
+	Id                   string                 `json:"id,omitempty"`
+	Type                 []string               `json:"type"`
+	Holder               exported.Did           `json:"holder"`
+	VerifiableCredential []VerifiableCredential `json:"verifiableCredential"`
+	Proof                *Proof                 `json:"proof,omitempty"`
+}
+
+// NewVerifiablePresentation creates an unsigned presentation of the (signed)
+// credentials by the holder
+func NewVerifiablePresentation(id string, holder exported.Did,
+	credentials []VerifiableCredential) VerifiablePresentation {
+	return VerifiablePresentation{
+		Context:              []string{CredentialsContext},
+		Id:                   id,
+		Type:                 []string{VerifiablePresentationType},
+		Holder:               holder,
+		VerifiableCredential: credentials,
+	}
+}
+
+func (vp VerifiablePresentation) Validate() error {
+	if len(vp.Context) == 0 || vp.Context[0] != CredentialsContext {
+		return fmt.Errorf("presentation @context should start with %s", CredentialsContext)
+	} else if !contains(vp.Type, VerifiablePresentationType) {
+		return fmt.Errorf("presentation type should include %s", VerifiablePresentationType)
+	} else if !types.IsValidDid(vp.Holder) {
+		return fmt.Errorf("presentation holder %s is not a valid did", vp.Holder)
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, other := range values {
+		if other == value {
+			return true
+		}
+	}
+	return false
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,95 @@
+package vc
+
+import (
+	"fmt"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"time"
+)
+
+// SignCredential adds a proof to the credential, signed by the issuer IxoDid
+func SignCredential(credential VerifiableCredential, issuer exported.IxoDid,
+	created time.Time) (VerifiableCredential, error) {
+	if credential.Issuer != issuer.Did {
+		return VerifiableCredential{}, fmt.Errorf("credential issuer should be %s", issuer.Did)
+	} else if err := credential.Validate(); err != nil {
+		return VerifiableCredential{}, err
+	}
+
+	credential.Proof = nil
+	proof, err := createProof(issuer, credential, AssertionMethodPurpose, "", "", created)
+	if err != nil {
+		return VerifiableCredential{}, err
+	}
+
+	credential.Proof = proof
+	return credential, nil
+}
+
+// VerifyCredential verifies the credential's proof against the issuer's
+// assertion keys and checks that the credential has not expired at time t
+func VerifyCredential(credential VerifiableCredential, resolve Resolver, t time.Time) error {
+	if err := credential.Validate(); err != nil {
+		return err
+	} else if credential.IsExpired(t) {
+		return fmt.Errorf("credential has expired")
+	}
+
+	unsigned := credential
+	unsigned.Proof = nil
+	if err := verifyProof(credential.Proof, unsigned, credential.Issuer,
+		AssertionMethodPurpose, resolve); err != nil {
+		return fmt.Errorf("credential proof is invalid: %s", err.Error())
+	}
+	return nil
+}
+
+// SignPresentation adds a proof to the presentation, signed by the holder
+// IxoDid. The challenge and domain (both optional) are set by the verifier
+// to prevent replays of the presentation.
+func SignPresentation(presentation VerifiablePresentation, holder exported.IxoDid,
+	challenge, domain string, created time.Time) (VerifiablePresentation, error) {
+	if presentation.Holder != holder.Did {
+		return VerifiablePresentation{}, fmt.Errorf("presentation holder should be %s", holder.Did)
+	} else if err := presentation.Validate(); err != nil {
+		return VerifiablePresentation{}, err
+	}
+
+	presentation.Proof = nil
+	proof, err := createProof(holder, presentation, AuthenticationPurpose, challenge, domain, created)
+	if err != nil {
+		return VerifiablePresentation{}, err
+	}
+
+	presentation.Proof = proof
+	return presentation, nil
+}
+
+// VerifyPresentation verifies the presentation's proof against the holder's
+// authentication keys, checks the proof's challenge and domain (if expected),
+// and verifies each of the presented credentials
+func VerifyPresentation(presentation VerifiablePresentation, resolve Resolver,
+	challenge, domain string, t time.Time) error {
+	if err := presentation.Validate(); err != nil {
+		return err
+	} else if presentation.Proof == nil {
+		return fmt.Errorf("presentation proof is missing")
+	} else if challenge != "" && presentation.Proof.Challenge != challenge {
+		return fmt.Errorf("presentation challenge does not match")
+	} else if domain != "" && presentation.Proof.Domain != domain {
+		return fmt.Errorf("presentation domain does not match")
+	}
+
+	unsigned := presentation
+	unsigned.Proof = nil
+	if err := verifyProof(presentation.Proof, unsigned, presentation.Holder,
+		AuthenticationPurpose, resolve); err != nil {
+		return fmt.Errorf("presentation proof is invalid: %s", err.Error())
+	}
+
+	for i, credential := range presentation.VerifiableCredential {
+		if err := VerifyCredential(credential, resolve, t); err != nil {
+			return fmt.Errorf("credential %d: %s", i, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package vc
+
+import (
+	"encoding/json"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+	"github.com/ixofoundation/ixo-blockchain/x/did/internal/types"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func testResolver(didDocs ...types.BaseDidDoc) Resolver {
+	return func(did exported.Did) (DidResolutionResult, error) {
+		for _, didDoc := range didDocs {
+			if didDoc.Did == did {
+				return types.NewDidResolutionResult(didDoc), nil
+			}
+		}
+		return types.NewDidResolutionError(types.ResolutionErrorNotFound), nil
+	}
+}
+
+func didDocOf(ixoDid exported.IxoDid) types.BaseDidDoc {
+	didDoc := types.NewBaseDidDoc(ixoDid.Did, ixoDid.VerifyKey)
+	didDoc.KeyType = ixoDid.KeyType
+	return didDoc
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	bz, err := CanonicalJSON(map[string]interface{}{
+		"b": []interface{}{2, "<x>"}, "a": map[string]interface{}{"d": 1.5, "c": true},
+	})
+	require.Nil(t, err)
+	require.Equal(t, `{"a":{"c":true,"d":1.5},"b":[2,"<x>"]}`, string(bz))
+}
+
+func TestSignAndVerifyCredential(t *testing.T) {
+	issuer, err := exported.Gen()
+	require.Nil(t, err)
+	subject, err := exported.Gen()
+	require.Nil(t, err)
+	resolve := testResolver(didDocOf(issuer), didDocOf(subject))
+	now := time.Now()
+
+	credential := NewVerifiableCredential("urn:uuid:1", []string{"KYCCredential"},
+		issuer.Did, subject.Did, map[string]interface{}{"kycValidated": true},
+		now, now.Add(time.Hour))
+	require.Equal(t, subject.Did, credential.GetSubject())
+
+	// Only the issuer can sign the credential
+	_, err = SignCredential(credential, subject, now)
+	require.NotNil(t, err)
+	signed, err := SignCredential(credential, issuer, now)
+	require.Nil(t, err)
+	require.Equal(t, Ed25519Signature2018, signed.Proof.Type)
+	require.Nil(t, VerifyCredential(signed, resolve, now))
+
+	// Credential survives a JSON round trip
+	bz, err := json.Marshal(signed)
+	require.Nil(t, err)
+	var decoded VerifiableCredential
+	require.Nil(t, json.Unmarshal(bz, &decoded))
+	require.Nil(t, VerifyCredential(decoded, resolve, now))
+
+	// Tampered, expired and unresolvable credentials are invalid
+	tampered := decoded
+	tampered.CredentialSubject = map[string]interface{}{"id": subject.Did, "kycValidated": false}
+	require.NotNil(t, VerifyCredential(tampered, resolve, now))
+	require.NotNil(t, VerifyCredential(signed, resolve, now.Add(2*time.Hour)))
+	require.NotNil(t, VerifyCredential(signed, testResolver(didDocOf(subject)), now))
+
+	// Credentials of deactivated issuers are invalid
+	deactivated := didDocOf(issuer)
+	deactivated.Deactivate()
+	require.NotNil(t, VerifyCredential(signed, testResolver(deactivated), now))
+}
+
+func TestSignAndVerifyPresentation(t *testing.T) {
+	issuer, err := exported.Gen()
+	require.Nil(t, err)
+	holder, err := exported.GenSecp256k1()
+	require.Nil(t, err)
+	resolve := testResolver(didDocOf(issuer), didDocOf(holder))
+	now := time.Now()
+
+	credential, err := SignCredential(NewVerifiableCredential("", []string{"ClaimCredential"},
+		issuer.Did, holder.Did, nil, now, time.Time{}), issuer, now)
+	require.Nil(t, err)
+
+	presentation, err := SignPresentation(NewVerifiablePresentation("",
+		holder.Did, []VerifiableCredential{credential}), holder, "nonce-1", "cellnode.ixo.world", now)
+	require.Nil(t, err)
+	require.Equal(t, EcdsaSecp256k1Signature2019, presentation.Proof.Type)
+	require.Nil(t, VerifyPresentation(presentation, resolve, "nonce-1", "cellnode.ixo.world", now))
+
+	// Presentations cannot be replayed with a different challenge
+	require.NotNil(t, VerifyPresentation(presentation, resolve, "nonce-2", "", now))
+
+	// Presentations with invalid credentials are invalid
+	invalid := presentation
+	invalid.VerifiableCredential = []VerifiableCredential{credential}
+	invalid.VerifiableCredential[0].Issuer = holder.Did
+	require.NotNil(t, VerifyPresentation(invalid, resolve, "", "", now))
+}
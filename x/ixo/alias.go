@@ -4,10 +4,16 @@ import "github.com/ixofoundation/ixo-blockchain/x/ixo/internal/types"
 
 const (
 	IxoNativeToken = "uixo"
+
+	EventTypeDelegatedSigning = types.EventTypeDelegatedSigning
+	AttributeKeyPrincipalDid  = types.AttributeKeyPrincipalDid
+	AttributeKeySignerDid     = types.AttributeKeySignerDid
 )
 
 type (
 	PubKeyGetter = types.PubKeyGetter
+	DidPubKey    = types.DidPubKey
+	DelegateKey  = types.DelegateKey
 	IxoMsg       = types.IxoMsg
 )
 
@@ -16,10 +22,21 @@ var (
 
 	// Auth
 	NewDefaultPubKeyGetter           = types.NewDefaultPubKeyGetter
+	NewDidPubKey                     = types.NewDidPubKey
+	NewKeyManagementDidPubKey        = types.NewKeyManagementDidPubKey
+	NewDelegatedDidPubKey            = types.NewDelegatedDidPubKey
+	NewDelegateKey                   = types.NewDelegateKey
+	WithDelegateSigner               = types.WithDelegateSigner
+	GetDelegateSigner                = types.GetDelegateSigner
+	NewDelegationRouter              = types.NewDelegationRouter
 	ProcessSig                       = types.ProcessSig
 	NewDefaultAnteHandler            = types.NewDefaultAnteHandler
 	ApproximateFeeForTx              = types.ApproximateFeeForTx
 	GenerateOrBroadcastMsgs          = types.GenerateOrBroadcastMsgs
 	SignAndBroadcastTxFromStdSignMsg = types.SignAndBroadcastTxFromStdSignMsg
+	MakeDidSignature                 = types.MakeDidSignature
 	IxoSigVerificationGasConsumer    = types.IxoSigVerificationGasConsumer
+
+	// Keys
+	LengthPrefix = types.LengthPrefix
 )
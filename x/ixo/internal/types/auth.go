@@ -41,17 +41,134 @@ func init() {
 
 type PubKeyGetter func(ctx sdk.Context, msg IxoMsg) (crypto.PubKey, sdk.Result)
 
+var _ crypto.PubKey = DidPubKey{}
+
+// DidPubKey is the PubKey of a DID as seen by the ixo AnteHandler. It has the
+// address of the DID doc's (primary) PubKey, so that fees are always paid from
+// the DID's account, but it also verifies signatures made using any of the DID
+// doc's authentication verification methods or by any of its delegates. The
+// primary PubKey is either an ed25519 or a secp256k1 PubKey, depending on the
+// DID doc's key type.
+//
+// If the DID is backed by a multisig key set, the DID's address is instead
+// that of the multisig key set, and signatures (other than by delegates) have
+// to be multisignatures by the key set.
+type DidPubKey struct {
+	PubKey             crypto.PubKey
+	AuthenticationKeys []ed25519tm.PubKeyEd25519
+	Delegates          []DelegateKey
+	Multisig           multisig.PubKeyMultisigThreshold
+}
+
+func NewDidPubKey(didDoc exported.DidDoc) DidPubKey {
+	pubKey := DidPubKey{
+		PubKey: exported.PubKeyFromBase58(didDoc.GetKeyType(), didDoc.GetPubKey()),
+	}
+	if multisigKey := didDoc.GetMultisigKey(); !multisigKey.IsEmpty() {
+		pubKey.Multisig = multisigKey.PubKey()
+		return pubKey
+	}
+	for _, key := range didDoc.GetAuthenticationPubKeys() {
+		var authKey ed25519tm.PubKeyEd25519
+		copy(authKey[:], base58.Decode(key))
+		pubKey.AuthenticationKeys = append(pubKey.AuthenticationKeys, authKey)
+	}
+	return pubKey
+}
+
+// NewKeyManagementDidPubKey returns the DidPubKey of the DID doc for messages
+// that manage the DID's keys, which can only be signed by the DID doc's PubKey
+// (or multisig key set) and not by its other authentication keys
+func NewKeyManagementDidPubKey(didDoc exported.DidDoc) DidPubKey {
+	pubKey := NewDidPubKey(didDoc)
+	pubKey.AuthenticationKeys = nil
+	return pubKey
+}
+
+func (pk DidPubKey) IsMultisig() bool { return len(pk.Multisig.PubKeys) > 0 }
+
+// AccountPubKey returns the PubKey that is set as the DID account's PubKey
+func (pk DidPubKey) AccountPubKey() crypto.PubKey {
+	if pk.IsMultisig() {
+		return pk.Multisig
+	}
+	return pk.PubKey
+}
+
+func (pk DidPubKey) Address() crypto.Address { return pk.AccountPubKey().Address() }
+func (pk DidPubKey) Bytes() []byte           { return pk.AccountPubKey().Bytes() }
+
+func (pk DidPubKey) VerifyBytes(msg []byte, sig []byte) bool {
+	if pk.verifyOwnBytes(msg, sig) {
+		return true
+	}
+	_, isDelegate := pk.GetDelegateSigner(msg, sig)
+	return isDelegate
+}
+
+func (pk DidPubKey) verifyOwnBytes(msg []byte, sig []byte) bool {
+	if pk.IsMultisig() {
+		return pk.Multisig.VerifyBytes(msg, sig)
+	} else if pk.PubKey.VerifyBytes(msg, sig) {
+		return true
+	}
+	for _, authKey := range pk.AuthenticationKeys {
+		if authKey.VerifyBytes(msg, sig) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDelegateSigner returns the delegate that made the signature, if it was
+// not made by the DID itself
+func (pk DidPubKey) GetDelegateSigner(msg []byte, sig []byte) (exported.Did, bool) {
+	if len(pk.Delegates) == 0 || pk.verifyOwnBytes(msg, sig) {
+		return "", false
+	}
+	for _, delegate := range pk.Delegates {
+		if delegate.VerifyBytes(msg, sig) {
+			return delegate.Did, true
+		}
+	}
+	return "", false
+}
+
+// verificationKeys returns the keys that a signature may be verified against,
+// including the keys of delegates
+func (pk DidPubKey) verificationKeys() (keys []crypto.PubKey) {
+	if pk.IsMultisig() {
+		keys = append(keys, pk.Multisig.PubKeys...)
+	} else {
+		keys = append(keys, pk.PubKey)
+		for _, authKey := range pk.AuthenticationKeys {
+			keys = append(keys, authKey)
+		}
+	}
+	for _, delegate := range pk.Delegates {
+		keys = append(keys, delegate.PubKey.verificationKeys()...)
+	}
+	return keys
+}
+
+func (pk DidPubKey) Equals(other crypto.PubKey) bool {
+	if otherDidPubKey, ok := other.(DidPubKey); ok {
+		other = otherDidPubKey.AccountPubKey()
+	}
+	return pk.AccountPubKey().Equals(other)
+}
+
 func NewDefaultPubKeyGetter(didKeeper DidKeeper) PubKeyGetter {
 	return func(ctx sdk.Context, msg IxoMsg) (pubKey crypto.PubKey, res sdk.Result) {
 
 		signerDidDoc, err := didKeeper.GetDidDoc(ctx, msg.GetSignerDid())
 		if err != nil {
 			return pubKey, err.Result()
+		} else if signerDidDoc.IsDeactivated() {
+			return pubKey, sdk.ErrUnauthorized("signer did is deactivated").Result()
 		}
 
-		var pubKeyRaw ed25519tm.PubKeyEd25519
-		copy(pubKeyRaw[:], base58.Decode(signerDidDoc.GetPubKey()))
-		return pubKeyRaw, sdk.Result{}
+		return NewDelegatedDidPubKey(ctx, didKeeper, signerDidDoc, msg), sdk.Result{}
 	}
 }
 
@@ -77,6 +194,13 @@ func ProcessSig(
 	ctx sdk.Context, acc auth.Account, sig auth.StdSignature, signBytes []byte, simulate bool, params auth.Params,
 ) (updatedAcc auth.Account, res sdk.Result) {
 
+	// Only the primary (or multisig) PubKey of a DidPubKey is set as the
+	// account's PubKey
+	didPubKey, isDidPubKey := sig.PubKey.(DidPubKey)
+	if isDidPubKey {
+		sig.PubKey = didPubKey.AccountPubKey()
+	}
+
 	pubKey, res := auth.ProcessPubKey(acc, sig, simulate)
 	if !res.IsOK() {
 		return nil, res
@@ -95,11 +219,24 @@ func ProcessSig(
 		consumeSimSigGas(ctx.GasMeter(), pubKey, sig, params)
 	}
 
-	// Consume signature gas
-	ctx.GasMeter().ConsumeGas(params.SigVerifyCostED25519, "ante verify: ed25519")
+	// Consume signature gas. The signature of a DID can be verified using any
+	// authentication key, any delegate, or the multisig key set of the DID,
+	// so gas is consumed for each of these keys according to its key type.
+	var verifier crypto.PubKey = pubKey
+	verificationKeys := []crypto.PubKey{pubKey}
+	if isDidPubKey {
+		verifier = didPubKey
+		verificationKeys = didPubKey.verificationKeys()
+	}
+	for _, key := range verificationKeys {
+		res = IxoSigVerificationGasConsumer(ctx.GasMeter(), sig.Signature, key, params)
+		if !res.IsOK() {
+			return nil, res
+		}
+	}
 
 	// Verify signature
-	if !simulate && !pubKey.VerifyBytes(signBytes, sig.Signature) {
+	if !simulate && !verifier.VerifyBytes(signBytes, sig.Signature) {
 		return nil, sdk.ErrUnauthorized("Signature Verification failed").Result()
 	}
 
@@ -232,6 +369,13 @@ func NewDefaultAnteHandler(ak auth.AccountKeeper, sk supply.Keeper, pubKeyGetter
 
 		ak.SetAccount(newCtx, signerAcc)
 
+		// record the delegate (if any) that signed on behalf of the signer DID
+		if didPubKey, ok := pubKey.(DidPubKey); ok && !simulate {
+			if delegateDid, isDelegate := didPubKey.GetDelegateSigner(signBytes, ixoSig.Signature); isDelegate {
+				newCtx = WithDelegateSigner(newCtx, delegateDid)
+			}
+		}
+
 		return newCtx, sdk.Result{GasWanted: stdTx.Fee.Gas}, false // continue...
 	}
 }
@@ -277,11 +421,7 @@ func Sign(cliCtx context.CLIContext, msg auth.StdSignMsg,
 		panic("expected one message")
 	}
 
-	var privateKey ed25519tm.PrivKeyEd25519
-	copy(privateKey[:], base58.Decode(ixoDid.Secret.SignKey))
-	copy(privateKey[32:], base58.Decode(ixoDid.VerifyKey))
-
-	sig, err := MakeSignature(msg.Bytes(), privateKey)
+	sig, err := MakeDidSignature(msg, ixoDid)
 	if err != nil {
 		return nil, err
 	}
@@ -385,8 +525,14 @@ func SignAndBroadcastTxFromStdSignMsg(cliCtx context.CLIContext,
 	return res, nil
 }
 
+// MakeDidSignature signs the message using the IxoDid's sign key (of any key
+// type), for example to produce one of the signatures of a multisignature
+func MakeDidSignature(msg auth.StdSignMsg, ixoDid exported.IxoDid) (auth.StdSignature, error) {
+	return MakeSignature(msg.Bytes(), ixoDid.PrivKey())
+}
+
 func MakeSignature(signBytes []byte,
-	privateKey ed25519tm.PrivKeyEd25519) (auth.StdSignature, error) {
+	privateKey crypto.PrivKey) (auth.StdSignature, error) {
 	sig, err := privateKey.Sign(signBytes)
 	if err != nil {
 		return auth.StdSignature{}, err
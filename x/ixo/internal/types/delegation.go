@@ -0,0 +1,96 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did/exported"
+)
+
+const (
+	EventTypeDelegatedSigning = "delegated_signing"
+
+	AttributeKeyPrincipalDid = "principal_did"
+	AttributeKeySignerDid    = "signer_did"
+)
+
+type delegateSignerKey struct{}
+
+// DelegateKey holds the keys of a delegate that can sign on behalf of a DID,
+// i.e. the delegate DID doc's (primary) PubKey and authentication keys, or its
+// multisig key set.
+type DelegateKey struct {
+	Did    exported.Did
+	PubKey DidPubKey
+}
+
+func NewDelegateKey(delegateDidDoc exported.DidDoc) DelegateKey {
+	return DelegateKey{
+		Did:    delegateDidDoc.GetDid(),
+		PubKey: NewDidPubKey(delegateDidDoc),
+	}
+}
+
+func (dk DelegateKey) VerifyBytes(msg []byte, sig []byte) bool {
+	return dk.PubKey.verifyOwnBytes(msg, sig)
+}
+
+// NewDelegatedDidPubKey returns the DidPubKey of the DID doc, which also
+// verifies signatures made by any (active) delegate of the DID that is
+// authorised to sign the message on behalf of the DID.
+func NewDelegatedDidPubKey(ctx sdk.Context, didKeeper DidKeeper,
+	didDoc exported.DidDoc, msg IxoMsg) DidPubKey {
+	pubKey := NewDidPubKey(didDoc)
+	for _, delegateDid := range didDoc.GetDelegateDids(msg.Route(), msg.Type()) {
+		delegateDidDoc, err := didKeeper.GetDidDoc(ctx, delegateDid)
+		if err != nil || delegateDidDoc.IsDeactivated() {
+			continue
+		}
+		pubKey.Delegates = append(pubKey.Delegates, NewDelegateKey(delegateDidDoc))
+	}
+	return pubKey
+}
+
+// WithDelegateSigner records the delegate that signed the tx in the context
+func WithDelegateSigner(ctx sdk.Context, delegateDid exported.Did) sdk.Context {
+	return ctx.WithValue(delegateSignerKey{}, delegateDid)
+}
+
+// GetDelegateSigner returns the delegate that signed the tx on behalf of the
+// message's signer DID, if the tx was not signed by the signer DID itself
+func GetDelegateSigner(ctx sdk.Context) (exported.Did, bool) {
+	delegateDid, ok := ctx.Value(delegateSignerKey{}).(exported.Did)
+	return delegateDid, ok && delegateDid != ""
+}
+
+var _ sdk.Router = delegationRouter{}
+
+// delegationRouter wraps the handlers of a router so that messages signed by a
+// delegate emit an event recording both the principal and the actual signer.
+type delegationRouter struct {
+	router sdk.Router
+}
+
+func NewDelegationRouter(router sdk.Router) sdk.Router {
+	return delegationRouter{router: router}
+}
+
+func (r delegationRouter) AddRoute(path string, h sdk.Handler) sdk.Router {
+	r.router.AddRoute(path, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		res := h(ctx, msg)
+
+		ixoMsg, isIxoMsg := msg.(IxoMsg)
+		delegateDid, isDelegated := GetDelegateSigner(ctx)
+		if res.IsOK() && isIxoMsg && isDelegated {
+			res.Events = res.Events.AppendEvent(sdk.NewEvent(
+				EventTypeDelegatedSigning,
+				sdk.NewAttribute(AttributeKeyPrincipalDid, ixoMsg.GetSignerDid()),
+				sdk.NewAttribute(AttributeKeySignerDid, delegateDid),
+			))
+		}
+		return res
+	})
+	return r
+}
+
+func (r delegationRouter) Route(path string) sdk.Handler {
+	return r.router.Route(path)
+}
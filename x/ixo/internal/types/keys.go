@@ -0,0 +1,13 @@
+package types
+
+import "encoding/binary"
+
+// LengthPrefix prefixes the bytes with their uvarint-encoded length. This is
+// used for variable-length key components (e.g. DIDs, which can contain a
+// path) that are followed by other components, so that no key component can
+// be mistaken for the prefix of another.
+func LengthPrefix(bz []byte) []byte {
+	lenBz := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(lenBz, uint64(len(bz)))
+	return append(lenBz[:n], bz...)
+}
@@ -3,7 +3,10 @@ package cli
 import (
 	"fmt"
 	"github.com/ixofoundation/ixo-blockchain/x/did"
+	didcli "github.com/ixofoundation/ixo-blockchain/x/did/client/cli"
 	"github.com/ixofoundation/ixo-blockchain/x/ixo"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -17,6 +20,8 @@ import (
 const (
 	TRUE  = "true"
 	FALSE = "false"
+
+	FlagMemoRecipientDid = "memo-recipient-did"
 )
 
 func parseBool(boolStr, boolName string) (bool, sdk.Error) {
@@ -61,8 +66,29 @@ func GetCmdCreatePaymentTemplate(cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+// encryptedMemoFromFlags encrypts the --encrypted-memo (if any) for the
+// --memo-recipient-did
+func encryptedMemoFromFlags(cliCtx context.CLIContext, ixoDid did.IxoDid) (did.EncryptedMemo, error) {
+	memo := viper.GetString(didcli.FlagEncryptedMemo)
+	if memo == "" {
+		return did.EncryptedMemo{}, nil
+	}
+
+	recipientDid := viper.GetString(FlagMemoRecipientDid)
+	if !did.IsValidDid(recipientDid) {
+		return did.EncryptedMemo{}, errors.New("a valid memo recipient did is required")
+	}
+	return didcli.EncryptMemoForDid(cliCtx, ixoDid, recipientDid, memo)
+}
+
+func addEncryptedMemoFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(didcli.FlagEncryptedMemo, "", "Memo to encrypt for the memo recipient did")
+	cmd.Flags().String(FlagMemoRecipientDid, "", "Did that the memo is encrypted for")
+	return cmd
+}
+
 func GetCmdCreatePaymentContract(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	return addEncryptedMemoFlags(&cobra.Command{
 		Use: "create-payment-contract [payment-contract-id] [payment-template-id] " +
 			"[payer-addr] [recipients] [can-deauthorise] [discount-id] [creator-ixo-did]",
 		Short: "Create and sign a create-payment-contract tx using DIDs",
@@ -105,13 +131,18 @@ func GetCmdCreatePaymentContract(cdc *codec.Codec) *cobra.Command {
 			cliCtx := context.NewCLIContext().WithCodec(cdc).
 				WithFromAddress(ixoDid.Address())
 
+			encryptedMemo, err := encryptedMemoFromFlags(cliCtx, ixoDid)
+			if err != nil {
+				return err
+			}
+
 			msg := types.NewMsgCreatePaymentContract(templateIdStr,
 				contractIdStr, payerAddr, recipients, canDeauthorise,
-				discountId, ixoDid.Did)
+				discountId, ixoDid.Did, encryptedMemo)
 
 			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
 		},
-	}
+	})
 }
 
 func GetCmdCreateSubscription(cdc *codec.Codec) *cobra.Command {
@@ -256,7 +287,7 @@ func GetCmdRevokePaymentDiscount(cdc *codec.Codec) *cobra.Command {
 }
 
 func GetCmdEffectPayment(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	return addEncryptedMemoFlags(&cobra.Command{
 		Use:   "effect-payment [payment-contract-id] [creator-ixo-did]",
 		Short: "Create and sign a effect-payment tx using DIDs",
 		Args:  cobra.ExactArgs(2),
@@ -272,9 +303,14 @@ func GetCmdEffectPayment(cdc *codec.Codec) *cobra.Command {
 			cliCtx := context.NewCLIContext().WithCodec(cdc).
 				WithFromAddress(ixoDid.Address())
 
-			msg := types.NewMsgEffectPayment(contractIdStr, ixoDid.Did)
+			encryptedMemo, err := encryptedMemoFromFlags(cliCtx, ixoDid)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgEffectPayment(contractIdStr, ixoDid.Did, encryptedMemo)
 
 			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
 		},
-	}
+	})
 }
@@ -58,6 +58,7 @@ type createPaymentContractReq struct {
 	Recipients        types.Distribution `json:"recipients" yaml:"recipients"`
 	CanDeauthorise    bool               `json:"can_deauthorise" yaml:"can_deauthorise"`
 	DiscountId        sdk.Uint           `json:"discount_id" yaml:"discount_id"`
+	EncryptedMemo     did.EncryptedMemo  `json:"encrypted_memo" yaml:"encrypted_memo"`
 }
 
 func createPaymentContractRequestHandler(ctx context.CLIContext) http.HandlerFunc {
@@ -74,7 +75,7 @@ func createPaymentContractRequestHandler(ctx context.CLIContext) http.HandlerFun
 
 		msg := types.NewMsgCreatePaymentContract(req.PaymentTemplateId,
 			req.PaymentContractId, req.Payer, req.Recipients,
-			req.CanDeauthorise, req.DiscountId, req.CreatorDid)
+			req.CanDeauthorise, req.DiscountId, req.CreatorDid, req.EncryptedMemo)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -210,6 +211,8 @@ type effectPaymentReq struct {
 	BaseReq           rest.BaseReq `json:"base_req" yaml:"base_req"`
 	SenderDid         did.Did      `json:"sender_did" yaml:"sender_did"`
 	PaymentContractId string       `json:"payment_contract_id" yaml:"payment_contract_id"`
+
+	EncryptedMemo did.EncryptedMemo `json:"encrypted_memo" yaml:"encrypted_memo"`
 }
 
 func effectPaymentRequestHandler(ctx context.CLIContext) http.HandlerFunc {
@@ -224,7 +227,7 @@ func effectPaymentRequestHandler(ctx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgEffectPayment(req.PaymentContractId, req.SenderDid)
+		msg := types.NewMsgEffectPayment(req.PaymentContractId, req.SenderDid, req.EncryptedMemo)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
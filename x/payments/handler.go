@@ -4,6 +4,7 @@ import (
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
 	"github.com/ixofoundation/ixo-blockchain/x/payments/internal/keeper"
 	"github.com/ixofoundation/ixo-blockchain/x/payments/internal/types"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -91,6 +92,20 @@ func handleMsgSetPaymentContractAuthorisation(ctx sdk.Context, k Keeper, msg Msg
 		return sdk.ErrInvalidAddress("signer must be payment contract payer").Result()
 	}
 
+	// Confirm that payer holds the credentials required by the payment
+	// template (if any) when authorising. Deauthorising is always allowed.
+	if msg.Authorised {
+		template, err := k.GetPaymentTemplate(ctx, contract.PaymentTemplateId)
+		if err != nil {
+			return err.Result()
+		}
+
+		err = k.DidKeeper.CheckAccessPolicy(ctx, msg.PayerDid, template.AccessPolicy)
+		if err != nil {
+			return err.Result()
+		}
+	}
+
 	// Set authorised status
 	err = k.SetPaymentContractAuthorised(ctx, msg.PaymentContractId, msg.Authorised)
 	if err != nil {
@@ -187,6 +202,11 @@ func handleMsgCreatePaymentContract(ctx sdk.Context, k Keeper, bk bank.Keeper,
 	}
 	creatorAddr := cretorDidDoc.Address()
 
+	// Check that the memo (if any) can be decrypted by its recipient
+	if err := k.DidKeeper.CheckEncryptedMemo(ctx, msg.EncryptedMemo); err != nil {
+		return err.Result()
+	}
+
 	// Create payment contract and validate
 	authorised := false
 	contract := NewPaymentContract(
@@ -223,6 +243,9 @@ func handleMsgCreatePaymentContract(ctx sdk.Context, k Keeper, bk bank.Keeper,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
 		),
 	})
+	if !msg.EncryptedMemo.IsEmpty() {
+		ctx.EventManager().EmitEvent(did.NewEncryptedMemoEvent(msg.CreatorDid, msg.EncryptedMemo))
+	}
 
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
@@ -402,6 +425,11 @@ func handleMsgEffectPayment(ctx sdk.Context, k Keeper, bk bank.Keeper, msg MsgEf
 		return sdk.ErrInvalidAddress("signer must be payment contract creator").Result()
 	}
 
+	// Check that the memo (if any) can be decrypted by its recipient
+	if err := k.DidKeeper.CheckEncryptedMemo(ctx, msg.EncryptedMemo); err != nil {
+		return err.Result()
+	}
+
 	// Effect payment
 	effected, err := k.EffectPayment(ctx, bk, msg.PaymentContractId)
 	if err != nil {
@@ -424,6 +452,9 @@ func handleMsgEffectPayment(ctx sdk.Context, k Keeper, bk bank.Keeper, msg MsgEf
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
 		),
 	})
+	if !msg.EncryptedMemo.IsEmpty() {
+		ctx.EventManager().EmitEvent(did.NewEncryptedMemoEvent(msg.SenderDid, msg.EncryptedMemo))
+	}
 
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
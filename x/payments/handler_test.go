@@ -0,0 +1,68 @@
+package payments
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+	"github.com/ixofoundation/ixo-blockchain/x/payments/internal/keeper"
+	"github.com/ixofoundation/ixo-blockchain/x/payments/internal/types"
+)
+
+func TestHandlerSetPaymentContractAuthorisationAccessPolicy(t *testing.T) {
+	ctx, k, cdc, bk, ak := keeper.CreateTestInput()
+	did.RegisterCodec(cdc)
+
+	issuerDid := "did:ixo:4XJLBfGtWSGKSz4BeRxdun"
+	payerDid := "did:ixo:UKzkhVSHc3qEFva5EY2XHt"
+	pubKey := "47mm6LCDAyJmqkbUbqGoZKZkBixjBgvDFRMwQRF9HWMU"
+	creatorAddr := sdk.AccAddress(crypto.AddressHash([]byte("creatorAddr")))
+
+	// Create payer DID (without any credentials)
+	didHandler := did.NewHandler(k.DidKeeper, bk, ak)
+	res := didHandler(ctx, did.NewMsgAddDid(payerDid, pubKey, ""))
+	require.True(t, res.IsOK())
+	payerAddr := k.DidKeeper.MustGetDidDoc(ctx, payerDid).Address()
+
+	// Create template that can only be used by KYC validated payers
+	templateId := types.PaymentTemplateIdPrefix + "pt1"
+	template := types.NewPaymentTemplate(templateId,
+		sdk.NewCoins(sdk.NewInt64Coin("res", 10)), sdk.NewCoins(), sdk.NewCoins(),
+		types.NewDiscounts(), did.NewAccessPolicy(
+			[]string{"ProofOfKYC"}, []did.Did{issuerDid}))
+	require.Nil(t, template.Validate())
+	k.SetPaymentTemplate(ctx, template)
+
+	// Create unauthorised contract for the payer
+	contractId := types.PaymentContractIdPrefix + "pc1"
+	recipients := types.NewDistribution(
+		types.NewDistributionShare(creatorAddr, sdk.NewDec(100)))
+	k.SetPaymentContract(ctx, types.NewPaymentContractNoDiscount(contractId,
+		templateId, creatorAddr, payerAddr, recipients, true, false))
+
+	// Authorisation is rejected since payer does not hold the credential
+	msg := types.NewMsgSetPaymentContractAuthorisation(contractId, true, payerDid)
+	res = handleMsgSetPaymentContractAuthorisation(ctx, k, msg)
+	require.False(t, res.IsOK())
+	require.Equal(t, sdk.CodeType(did.CodeAccessDenied), res.Code)
+
+	// Deauthorisation does not require the credential
+	msg.Authorised = false
+	res = handleMsgSetPaymentContractAuthorisation(ctx, k, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	// Authorisation is accepted once the trusted issuer issues the credential
+	res = didHandler(ctx, did.NewMsgAddCredential(payerDid,
+		[]string{"Credential", "ProofOfKYC"}, issuerDid, "2020-01-01", ""))
+	require.True(t, res.IsOK())
+	msg.Authorised = true
+	res = handleMsgSetPaymentContractAuthorisation(ctx, k, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	contract, err := k.GetPaymentContract(ctx, contractId)
+	require.Nil(t, err)
+	require.True(t, contract.Authorised)
+}
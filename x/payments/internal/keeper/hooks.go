@@ -0,0 +1,35 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+	"github.com/ixofoundation/ixo-blockchain/x/payments/internal/types"
+)
+
+// Wrapper struct
+type Hooks struct {
+	k Keeper
+}
+
+var _ did.DidHooks = Hooks{}
+
+// Create new payments hooks
+func (k Keeper) Hooks() Hooks { return Hooks{k} }
+
+// AfterDidDeactivated emits a warning event for each payment contract created
+// by or paid by the DID's address
+func (h Hooks) AfterDidDeactivated(ctx sdk.Context, deactivatedDid did.Did) {
+	didDoc, err := h.k.DidKeeper.GetDidDoc(ctx, deactivatedDid)
+	if err != nil {
+		return
+	}
+	didAddr := didDoc.Address()
+
+	for _, contractId := range h.k.GetAddressPaymentContractIds(ctx, didAddr) {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeDeactivatedDidWarning,
+			sdk.NewAttribute(types.AttributeKeyPaymentContractId, contractId),
+			sdk.NewAttribute(types.AttributeKeyDeactivatedDid, deactivatedDid),
+		))
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 func TestKeeperIdReserver(t *testing.T) {
-	_, k, _ := CreateTestInput()
+	_, k, _, _, _ := CreateTestInput()
 
 	testTemplateId1 := types.PaymentTemplateIdPrefix + "test1"
 	testTemplateId2 := types.PaymentTemplateIdPrefix + "test2"
@@ -39,7 +39,7 @@ func TestKeeperIdReserver(t *testing.T) {
 }
 
 func TestKeeperSetGet(t *testing.T) {
-	ctx, k, _ := CreateTestInput()
+	ctx, k, _, _, _ := CreateTestInput()
 
 	// Check PaymentTemplate, PaymentContract, Subscription, existence
 	_, err := k.GetPaymentTemplate(ctx, "dummyId")
@@ -67,6 +67,18 @@ func TestKeeperSetGet(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, contract.Id, contractGet.Id)
 
+	// Check that PaymentContract is indexed by its creator and payer
+	require.Equal(t, []string{contract.Id}, k.GetAddressPaymentContractIds(ctx, templateCreatorAddr))
+	require.Equal(t, []string{contract.Id}, k.GetAddressPaymentContractIds(ctx, payerAddr))
+	require.Empty(t, k.GetAddressPaymentContractIds(ctx, shareAddr1))
+
+	// Contracts of an address that extends the payer address are not included
+	otherContract := validContract
+	otherContract.Id = contract.Id + "2"
+	otherContract.Payer = append(append(sdk.AccAddress{}, payerAddr...), 0x00)
+	k.SetPaymentContract(ctx, otherContract)
+	require.Equal(t, []string{contract.Id}, k.GetAddressPaymentContractIds(ctx, payerAddr))
+
 	// Create BlockPeriod Subscription
 	blockPeriod := types.NewBlockPeriod(100, 0)
 	blockSubscription := types.NewSubscription(validSubscriptionId1,
@@ -126,7 +138,7 @@ func TestKeeperSetGet(t *testing.T) {
 }
 
 func TestKeeperEffectPayment(t *testing.T) {
-	ctx, k, _ := CreateTestInput()
+	ctx, k, _, _, _ := CreateTestInput()
 
 	// Create and submit PaymentTemplate and PaymentContract
 	template := validTemplate
@@ -201,7 +213,7 @@ func TestKeeperEffectPayment(t *testing.T) {
 }
 
 func TestKeeperEffectPaymentWithDiscounts(t *testing.T) {
-	ctx, k, _ := CreateTestInput()
+	ctx, k, _, _, _ := CreateTestInput()
 
 	// Create and submit PaymentTemplate (!!double pay!!) and PaymentContract
 	template := validDoublePayTemplate
@@ -280,7 +292,7 @@ func TestKeeperEffectPaymentWithDiscounts(t *testing.T) {
 }
 
 func TestKeeperEffectSubscriptionPayment(t *testing.T) {
-	ctx, k, _ := CreateTestInput()
+	ctx, k, _, _, _ := CreateTestInput()
 
 	// Create and submit PaymentTemplate and PaymentContract
 	template := validTemplate
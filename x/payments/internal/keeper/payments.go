@@ -129,6 +129,21 @@ func (k Keeper) SetPaymentContract(ctx sdk.Context, contract types.PaymentContra
 	store := ctx.KVStore(k.storeKey)
 	key := types.GetPaymentContractKey(contract.Id)
 	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(contract))
+	store.Set(types.GetAddressContractKey(contract.Creator, contract.Id), []byte(contract.Id))
+	store.Set(types.GetAddressContractKey(contract.Payer, contract.Id), []byte(contract.Id))
+}
+
+// GetAddressPaymentContractIds returns the IDs of the payment contracts
+// created by or paid by the address
+func (k Keeper) GetAddressPaymentContractIds(ctx sdk.Context, address sdk.AccAddress) (contractIds []string) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetAddressContractsKey(address))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		contractIds = append(contractIds, string(iterator.Value()))
+	}
+	return contractIds
 }
 
 func (k Keeper) SetPaymentContractAuthorised(ctx sdk.Context, contractId string,
@@ -50,14 +50,16 @@ var (
 		validPaymentAmount,
 		validPaymentMinimum,
 		validPaymentMaximum,
-		validDiscounts)
+		validDiscounts,
+		did.AccessPolicy{})
 
 	validDoublePayTemplate = types.NewPaymentTemplate(
 		validTemplateId1,
 		validDoubledPaymentAmount,
 		validPaymentMinimum,
 		validPaymentMaximum,
-		validDiscounts)
+		validDiscounts,
+		did.AccessPolicy{})
 
 	validContract = types.NewPaymentContractNoDiscount(
 		validPaymentContractId1, validTemplateId1, templateCreatorAddr,
@@ -93,7 +95,7 @@ func ValidateVariables() sdk.Error {
 	return nil
 }
 
-func CreateTestInput() (sdk.Context, Keeper, *codec.Codec) {
+func CreateTestInput() (sdk.Context, Keeper, *codec.Codec, bank.Keeper, auth.AccountKeeper) {
 	if err := ValidateVariables(); err != nil {
 		panic(err)
 	}
@@ -101,12 +103,16 @@ func CreateTestInput() (sdk.Context, Keeper, *codec.Codec) {
 	storeKey := sdk.NewKVStoreKey(types.StoreKey)
 	actStoreKey := sdk.NewKVStoreKey(auth.StoreKey)
 	keyDid := sdk.NewKVStoreKey(did.StoreKey)
+	keyParams := sdk.NewKVStoreKey("subspace")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(storeKey, sdk.StoreTypeIAVL, nil)
 	ms.MountStoreWithDB(actStoreKey, sdk.StoreTypeIAVL, nil)
 	ms.MountStoreWithDB(keyDid, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeIAVL, nil)
 
 	_ = ms.LoadLatestVersion()
 	ctx := sdk.NewContext(ms, abci.Header{}, true, log.NewNopLogger())
@@ -118,15 +124,13 @@ func CreateTestInput() (sdk.Context, Keeper, *codec.Codec) {
 	types.RegisterCodec(cdc)
 	cdc.RegisterConcrete(types.TestPeriod{}, "payments/TestPeriod", nil)
 
-	keyParams := sdk.NewKVStoreKey("subspace")
-	tkeyParams := sdk.NewTransientStoreKey("transient_params")
-
 	pk1 := params.NewKeeper(cdc, keyParams, tkeyParams, params.DefaultCodespace)
 
 	accountKeeper := auth.NewAccountKeeper(cdc, actStoreKey, pk1.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
 	bankKeeper := bank.NewBaseKeeper(accountKeeper, pk1.Subspace(bank.DefaultParamspace), bank.DefaultCodespace, nil)
-	didKeeper := did.NewKeeper(cdc, keyDid)
+	didKeeper := did.NewKeeper(cdc, keyDid, pk1.Subspace(did.DefaultParamspace))
+	didKeeper.SetParams(ctx, did.DefaultParams())
 	keeper := NewKeeper(cdc, storeKey, bankKeeper, didKeeper, nil)
 
-	return ctx, keeper, cdc
+	return ctx, keeper, cdc, bankKeeper, accountKeeper
 }
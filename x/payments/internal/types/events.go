@@ -8,6 +8,7 @@ const (
 	EventTypeGrantDiscount                = "grant_discount"
 	EventTypeRevokeDiscount               = "revoke_discount"
 	EventTypeEffectPayment                = "effect_payment"
+	EventTypeDeactivatedDidWarning        = "deactivated_did_warning"
 
 	AttributeKeyPayerDid          = "payer_did"
 	AttributeKeyPaymentContractId = "payment_contract-id"
@@ -29,6 +30,7 @@ const (
 	AttributeKeyPaymentMinimum    = "payment_minimum"
 	AttributeKeyPaymentMaximum    = "payment_maximum"
 	AttributeKeyDiscounts         = "discounts"
+	AttributeKeyDeactivatedDid    = "deactivated_did"
 
 	AttributeKeyInputFromPayRemainderPool = "input_from_pay_remainder_pool"
 	AttributeKeyInputFromPayer            = "input_from_payer"
@@ -1,5 +1,10 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/ixo"
+)
+
 const (
 	ModuleName        = "payments"
 	DefaultParamspace = ModuleName
@@ -19,6 +24,7 @@ var (
 	PaymentTemplateKeyPrefix = []byte{0x00}
 	PaymentContractKeyPrefix = []byte{0x01}
 	SubscriptionKeyPrefix    = []byte{0x02}
+	AddressContractKeyPrefix = []byte{0x03}
 )
 
 func GetPaymentTemplateKey(templateId string) []byte {
@@ -29,6 +35,14 @@ func GetPaymentContractKey(contractId string) []byte {
 	return append(PaymentContractKeyPrefix, []byte(contractId)...)
 }
 
+func GetAddressContractsKey(address sdk.AccAddress) []byte {
+	return append(AddressContractKeyPrefix, ixo.LengthPrefix(address.Bytes())...)
+}
+
+func GetAddressContractKey(address sdk.AccAddress, contractId string) []byte {
+	return append(GetAddressContractsKey(address), []byte(contractId)...)
+}
+
 func GetSubscriptionKey(subscriptionId string) []byte {
 	return append(SubscriptionKeyPrefix, []byte(subscriptionId)...)
 }
@@ -27,6 +27,9 @@ var (
 	_ ixo.IxoMsg = MsgGrantDiscount{}
 	_ ixo.IxoMsg = MsgRevokeDiscount{}
 	_ ixo.IxoMsg = MsgEffectPayment{}
+
+	_ did.EncryptedMemoMsg = MsgCreatePaymentContract{}
+	_ did.EncryptedMemoMsg = MsgEffectPayment{}
 )
 
 type MsgCreatePaymentTemplate struct {
@@ -88,11 +91,14 @@ type MsgCreatePaymentContract struct {
 	Recipients        Distribution   `json:"recipients" yaml:"recipients"`
 	CanDeauthorise    bool           `json:"can_deauthorise" yaml:"can_deauthorise"`
 	DiscountId        sdk.Uint       `json:"discount_id" yaml:"discount_id"`
+
+	EncryptedMemo did.EncryptedMemo `json:"encrypted_memo,omitempty" yaml:"encrypted_memo,omitempty"`
 }
 
 func NewMsgCreatePaymentContract(templateId, contractId string,
 	payer sdk.AccAddress, recipients Distribution, canDeauthorise bool,
-	discountId sdk.Uint, creatorDid did.Did) MsgCreatePaymentContract {
+	discountId sdk.Uint, creatorDid did.Did,
+	encryptedMemo did.EncryptedMemo) MsgCreatePaymentContract {
 	return MsgCreatePaymentContract{
 		CreatorDid:        creatorDid,
 		PaymentTemplateId: templateId,
@@ -101,6 +107,7 @@ func NewMsgCreatePaymentContract(templateId, contractId string,
 		Recipients:        recipients,
 		CanDeauthorise:    canDeauthorise,
 		DiscountId:        discountId,
+		EncryptedMemo:     encryptedMemo,
 	}
 }
 
@@ -131,10 +138,20 @@ func (msg MsgCreatePaymentContract) ValidateBasic() sdk.Error {
 		return err
 	}
 
+	// Validate encrypted memo (if any)
+	if !msg.EncryptedMemo.IsEmpty() {
+		if err := did.ValidateEncryptedMemo(msg.EncryptedMemo); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (msg MsgCreatePaymentContract) GetSignerDid() did.Did { return msg.CreatorDid }
+func (msg MsgCreatePaymentContract) GetEncryptedMemo() did.EncryptedMemo {
+	return msg.EncryptedMemo
+}
 func (msg MsgCreatePaymentContract) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
 }
@@ -383,12 +400,16 @@ func (msg MsgRevokeDiscount) GetSignBytes() []byte {
 type MsgEffectPayment struct {
 	SenderDid         did.Did `json:"sender_did" yaml:"sender_did"`
 	PaymentContractId string  `json:"payment_contract_id" yaml:"payment_contract_id"`
+
+	EncryptedMemo did.EncryptedMemo `json:"encrypted_memo,omitempty" yaml:"encrypted_memo,omitempty"`
 }
 
-func NewMsgEffectPayment(contractId string, creatorDid did.Did) MsgEffectPayment {
+func NewMsgEffectPayment(contractId string, creatorDid did.Did,
+	encryptedMemo did.EncryptedMemo) MsgEffectPayment {
 	return MsgEffectPayment{
 		SenderDid:         creatorDid,
 		PaymentContractId: contractId,
+		EncryptedMemo:     encryptedMemo,
 	}
 }
 
@@ -410,10 +431,20 @@ func (msg MsgEffectPayment) ValidateBasic() sdk.Error {
 		return ErrInvalidId(DefaultCodespace, "payment contract id invalid")
 	}
 
+	// Validate encrypted memo (if any)
+	if !msg.EncryptedMemo.IsEmpty() {
+		if err := did.ValidateEncryptedMemo(msg.EncryptedMemo); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (msg MsgEffectPayment) GetSignerDid() did.Did { return msg.SenderDid }
+func (msg MsgEffectPayment) GetEncryptedMemo() did.EncryptedMemo {
+	return msg.EncryptedMemo
+}
 func (msg MsgEffectPayment) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
 }
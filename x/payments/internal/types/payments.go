@@ -1,23 +1,29 @@
 package types
 
-import sdk "github.com/cosmos/cosmos-sdk/types"
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+)
 
 type PaymentTemplate struct {
-	Id             string    `json:"id" yaml:"id"`
-	PaymentAmount  sdk.Coins `json:"payment_amount" yaml:"payment_amount"`
-	PaymentMinimum sdk.Coins `json:"payment_minimum" yaml:"payment_minimum"`
-	PaymentMaximum sdk.Coins `json:"payment_maximum" yaml:"payment_maximum"`
-	Discounts      Discounts `json:"discounts" yaml:"discounts"`
+	Id             string           `json:"id" yaml:"id"`
+	PaymentAmount  sdk.Coins        `json:"payment_amount" yaml:"payment_amount"`
+	PaymentMinimum sdk.Coins        `json:"payment_minimum" yaml:"payment_minimum"`
+	PaymentMaximum sdk.Coins        `json:"payment_maximum" yaml:"payment_maximum"`
+	Discounts      Discounts        `json:"discounts" yaml:"discounts"`
+	AccessPolicy   did.AccessPolicy `json:"access_policy" yaml:"access_policy"`
 }
 
 func NewPaymentTemplate(id string, paymentAmount, paymentMinimum,
-	paymentMaximum sdk.Coins, discounts Discounts) PaymentTemplate {
+	paymentMaximum sdk.Coins, discounts Discounts,
+	accessPolicy did.AccessPolicy) PaymentTemplate {
 	return PaymentTemplate{
 		Id:             id,
 		PaymentAmount:  paymentAmount,
 		PaymentMinimum: paymentMinimum,
 		PaymentMaximum: paymentMaximum,
 		Discounts:      discounts,
+		AccessPolicy:   accessPolicy,
 	}
 }
 
@@ -59,6 +65,11 @@ func (pt PaymentTemplate) Validate() sdk.Error {
 		return err
 	}
 
+	// Validate access policy (optional)
+	if err := did.ValidateAccessPolicy(pt.AccessPolicy); err != nil {
+		return err
+	}
+
 	return nil
 }
 
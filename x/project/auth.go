@@ -26,8 +26,10 @@ func GetPubKeyGetter(keeper Keeper, didKeeper did.Keeper) ixo.PubKeyGetter {
 			signerDoc, _ := didKeeper.GetDidDoc(ctx, signerDid)
 			if signerDoc == nil {
 				return pubKey, sdk.ErrUnauthorized("signer did not found").Result()
+			} else if signerDoc.IsDeactivated() {
+				return pubKey, sdk.ErrUnauthorized("signer did is deactivated").Result()
 			}
-			copy(pubKeyEd25519[:], base58.Decode(signerDoc.GetPubKey()))
+			return ixo.NewDelegatedDidPubKey(ctx, didKeeper, signerDoc, msg), sdk.Result{}
 		default:
 			// For the remaining messages, the project is the signer
 			projectDoc, err := keeper.GetProjectDoc(ctx, msg.GetSignerDid())
@@ -164,6 +166,8 @@ func NewProjectCreationAnteHandler(ak auth.AccountKeeper, sk supply.Keeper,
 			feePayerDidDoc, err := didKeeper.GetDidDoc(ctx, msg.SenderDid)
 			if err != nil {
 				return newCtx, err.Result(), true
+			} else if feePayerDidDoc.IsDeactivated() {
+				return newCtx, sdk.ErrUnauthorized("fee payer did is deactivated").Result(), true
 			}
 			feePayerAcc, res := auth.GetSignerAcc(ctx, ak, feePayerDidDoc.Address())
 			if !res.IsOK() {
@@ -267,6 +267,12 @@ func handleMsgCreateClaim(ctx sdk.Context, k Keeper, msg MsgCreateClaim) sdk.Res
 		return sdk.ErrUnauthorized("project not in STARTED status").Result()
 	}
 
+	// Check that claimer holds the credentials required by the project (if any)
+	err = k.DidKeeper.CheckAccessPolicy(ctx, msg.SenderDid, projectDoc.GetClaimAccessPolicy())
+	if err != nil {
+		return err.Result()
+	}
+
 	// Check if claim already exists
 	if k.ClaimExists(ctx, msg.ProjectDid, msg.Data.ClaimID) {
 		return sdk.ErrInternal("claim already exists").Result()
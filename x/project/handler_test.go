@@ -10,6 +10,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/exported"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ixofoundation/ixo-blockchain/x/did"
 	"github.com/ixofoundation/ixo-blockchain/x/project/internal/keeper"
 	"github.com/ixofoundation/ixo-blockchain/x/project/internal/types"
 )
@@ -168,3 +169,43 @@ func Test_WithdrawFunds(t *testing.T) {
 	res := handleMsgWithdrawFunds(ctx, k, bk, msg)
 	require.NotNil(t, res)
 }
+
+func TestHandler_CreateClaimAccessPolicy(t *testing.T) {
+	ctx, k, cdc, _, bk := keeper.CreateTestInput()
+	codec.RegisterCrypto(cdc)
+	types.RegisterCodec(cdc)
+	cdc.RegisterInterface((*did.DidDoc)(nil), nil)
+
+	issuerDid := "did:ixo:4XJLBfGtWSGKSz4BeRxdun"
+	senderDid := "did:ixo:UKzkhVSHc3qEFva5EY2XHt"
+	projectDid := "6iftm1hHdaU6LJGKayRMev"
+	pubKey := "47mm6LCDAyJmqkbUbqGoZKZkBixjBgvDFRMwQRF9HWMU"
+
+	// Create started project that only accepts claims from accredited DIDs
+	projectData := map[string]interface{}{
+		types.ClaimAccessPolicyDataKey: did.NewAccessPolicy(
+			[]string{"Accredited"}, []did.Did{issuerDid}),
+	}
+	projectDoc := types.NewProjectDoc("txHash", projectDid, "senderDid",
+		pubKey, types.StartedStatus, types.MustMarshalJson(projectData))
+	k.SetProjectDoc(ctx, projectDoc)
+
+	// Create claimer DID (without any credentials)
+	didHandler := did.NewHandler(k.DidKeeper, bk, k.AccountKeeper)
+	res := didHandler(ctx, did.NewMsgAddDid(senderDid, pubKey, ""))
+	require.True(t, res.IsOK())
+
+	// Claim is rejected since claimer does not hold the credential
+	msg := types.NewMsgCreateClaim("txHash", senderDid,
+		types.NewCreateClaimDoc("claim1"), projectDid)
+	res = handleMsgCreateClaim(ctx, k, msg)
+	require.False(t, res.IsOK())
+	require.Equal(t, sdk.CodeType(did.CodeAccessDenied), res.Code)
+
+	// Claim is accepted once the trusted issuer issues the credential
+	res = didHandler(ctx, did.NewMsgAddCredential(senderDid,
+		[]string{"Credential", "Accredited"}, issuerDid, "2020-01-01", ""))
+	require.True(t, res.IsOK())
+	res = handleMsgCreateClaim(ctx, k, msg)
+	require.True(t, res.IsOK())
+}
@@ -0,0 +1,28 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+	"github.com/ixofoundation/ixo-blockchain/x/project/internal/types"
+)
+
+// Wrapper struct
+type Hooks struct {
+	k Keeper
+}
+
+var _ did.DidHooks = Hooks{}
+
+// Create new project hooks
+func (k Keeper) Hooks() Hooks { return Hooks{k} }
+
+// AfterDidDeactivated emits a warning event for each project created by the DID
+func (h Hooks) AfterDidDeactivated(ctx sdk.Context, senderDid did.Did) {
+	for _, projectDid := range h.k.GetSenderProjectDids(ctx, senderDid) {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeDeactivatedDidWarning,
+			sdk.NewAttribute(types.AttributeKeyProjectDid, projectDid),
+			sdk.NewAttribute(types.AttributeKeySenderDid, senderDid),
+		))
+	}
+}
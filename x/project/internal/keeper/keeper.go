@@ -98,6 +98,20 @@ func (k Keeper) SetProjectDoc(ctx sdk.Context, projectDoc types.ProjectDoc) {
 	store := ctx.KVStore(k.storeKey)
 	key := types.GetProjectKey(projectDoc.ProjectDid)
 	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(projectDoc))
+	store.Set(types.GetSenderProjectKey(projectDoc.SenderDid, projectDoc.ProjectDid),
+		[]byte(projectDoc.ProjectDid))
+}
+
+// GetSenderProjectDids returns the DIDs of the projects created by the sender DID
+func (k Keeper) GetSenderProjectDids(ctx sdk.Context, senderDid did.Did) (projectDids []did.Did) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetSenderProjectsKey(senderDid))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		projectDids = append(projectDids, string(iterator.Value()))
+	}
+	return projectDids
 }
 
 func (k Keeper) SetAccountMap(ctx sdk.Context, projectDid did.Did, accountMap types.AccountMap) {
@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/exported"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ixofoundation/ixo-blockchain/x/did"
 	"github.com/ixofoundation/ixo-blockchain/x/project/internal/types"
 )
 
@@ -33,6 +34,18 @@ func TestProjectDoc(t *testing.T) {
 
 	_, err = k.GetProjectDoc(ctx, "Invalid Did")
 	require.NotNil(t, err)
+
+	// Project is indexed by its sender DID (once, even though it was updated)
+	require.Equal(t, []did.Did{types.ProjectDid}, k.GetSenderProjectDids(ctx, "SenderDid"))
+	require.Empty(t, k.GetSenderProjectDids(ctx, "Sender"))
+
+	// Projects of a sender DID that extends the sender DID are not included
+	otherDoc := types.ValidProjectDoc
+	otherDoc.SenderDid = "SenderDid/path"
+	otherDoc.ProjectDid = types.ProjectDid + "/path"
+	k.SetProjectDoc(ctx, otherDoc)
+	require.Equal(t, []did.Did{types.ProjectDid}, k.GetSenderProjectDids(ctx, "SenderDid"))
+	require.Equal(t, []did.Did{otherDoc.ProjectDid}, k.GetSenderProjectDids(ctx, "SenderDid/path"))
 }
 
 func TestKeeperAccountMap(t *testing.T) {
@@ -46,10 +46,12 @@ func CreateTestInput() (sdk.Context, Keeper, *codec.Codec,
 	projectSubspace := pk1.Subspace(types.DefaultParamspace)
 
 	bankKeeper := bank.NewBaseKeeper(accountKeeper, pk1.Subspace(bank.DefaultParamspace), bank.DefaultCodespace, nil)
-	didKeeper := did.NewKeeper(cdc, keyDid)
+	didKeeper := did.NewKeeper(cdc, keyDid, pk1.Subspace(did.DefaultParamspace))
 	paymentsKeeper := payments.NewKeeper(cdc, keyPayments, bankKeeper, didKeeper, nil)
 	keeper := NewKeeper(cdc, storeKey, projectSubspace, accountKeeper, didKeeper, paymentsKeeper)
 
+	didKeeper.SetParams(ctx, did.DefaultParams())
+
 	return ctx, keeper, cdc, paymentsKeeper, bankKeeper
 }
 
@@ -44,6 +44,20 @@ func (pd ProjectDoc) GetProjectFeesMap() (feesMap ProjectFeesMap) {
 	return feesMap
 }
 
+// GetClaimAccessPolicy returns the access policy (if any) that claimers have to
+// satisfy, as specified by the optional claimAccessPolicy project data field.
+func (pd ProjectDoc) GetClaimAccessPolicy() (policy did.AccessPolicy) {
+	policyRaw, found := pd.GetProjectData()[ClaimAccessPolicyDataKey]
+	if !found {
+		return did.AccessPolicy{}
+	}
+	err := json.Unmarshal(policyRaw, &policy)
+	if err != nil {
+		panic(err)
+	}
+	return policy
+}
+
 type UpdateProjectStatusDoc struct {
 	Status          ProjectStatus `json:"status" yaml:"status"`
 	EthFundingTxnID string        `json:"ethFundingTxnID" yaml:"ethFundingTxnID"`
@@ -1,12 +1,13 @@
 package types
 
 const (
-	EventTypeCreateProject       = "create_project"
-	EventTypeUpdateProjectStatus = "update_project_status"
-	EventTypeCreateAgent         = "create_agent"
-	EventTypeCreateClaim         = "create_claim"
-	EventTypeCreateEvaluation    = "create_evaluation"
-	EventTypeWithdrawFunds       = "withdraw_funds"
+	EventTypeCreateProject         = "create_project"
+	EventTypeUpdateProjectStatus   = "update_project_status"
+	EventTypeCreateAgent           = "create_agent"
+	EventTypeCreateClaim           = "create_claim"
+	EventTypeCreateEvaluation      = "create_evaluation"
+	EventTypeWithdrawFunds         = "withdraw_funds"
+	EventTypeDeactivatedDidWarning = "deactivated_did_warning"
 
 	AttributeKeyTxHash          = "tx_hash"
 	AttributeKeySenderDid       = "sender_did"
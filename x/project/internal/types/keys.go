@@ -1,6 +1,9 @@
 package types
 
-import "github.com/ixofoundation/ixo-blockchain/x/did"
+import (
+	"github.com/ixofoundation/ixo-blockchain/x/did"
+	"github.com/ixofoundation/ixo-blockchain/x/ixo"
+)
 
 const (
 	ModuleName        = "project"
@@ -16,12 +19,21 @@ var (
 	AccountMapKey  = []byte{0x03}
 	WithdrawalsKey = []byte{0x04}
 	ClaimsKey      = []byte{0x05}
+	SenderDidsKey  = []byte{0x06}
 )
 
 func GetProjectKey(projectDid did.Did) []byte {
 	return append(ProjectKey, []byte(projectDid)...)
 }
 
+func GetSenderProjectsKey(senderDid did.Did) []byte {
+	return append(SenderDidsKey, ixo.LengthPrefix([]byte(senderDid))...)
+}
+
+func GetSenderProjectKey(senderDid, projectDid did.Did) []byte {
+	return append(GetSenderProjectsKey(senderDid), []byte(projectDid)...)
+}
+
 func GetAccountMapKey(projectDid did.Did) []byte {
 	return append(AccountMapKey, []byte(projectDid)...)
 }
@@ -91,6 +91,16 @@ func (msg MsgCreateProject) ValidateBasic() sdk.Error {
 		return sdk.ErrInternal(err.Error())
 	}
 
+	// Check that claim access policy (if any) is valid
+	if policyRaw, found := dataMap[ClaimAccessPolicyDataKey]; found {
+		var policy did.AccessPolicy
+		if err := json.Unmarshal(policyRaw, &policy); err != nil {
+			return sdk.ErrInternal(err.Error())
+		} else if err := did.ValidateAccessPolicy(policy); err != nil {
+			return err
+		}
+	}
+
 	// Check that project DID matches the PubKey
 	unprefixedDid := exported.UnprefixedDid(msg.ProjectDid)
 	expectedUnprefixedDid := exported.UnprefixedDidFromPubKey(msg.PubKey)
@@ -24,6 +24,8 @@ type (
 	FeeType string
 )
 
+const ClaimAccessPolicyDataKey = "claimAccessPolicy"
+
 const (
 	FeeForService      FeeType = "FeeForService"
 	OracleFee          FeeType = "OracleFee"
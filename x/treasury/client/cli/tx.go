@@ -5,7 +5,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ixofoundation/ixo-blockchain/x/did"
+	didcli "github.com/ixofoundation/ixo-blockchain/x/did/client/cli"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/ixofoundation/ixo-blockchain/x/ixo"
 
@@ -13,10 +16,13 @@ import (
 )
 
 func GetCmdSend(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "send [to-did-or-address] [amount] [sender-ixo-did]",
 		Short: "Create and sign a send tx using DIDs",
-		Args:  cobra.ExactArgs(3),
+		Long: `Create and sign a send tx using DIDs. If the recipient is a DID, a memo can
+be encrypted for the recipient's encryption key (keyAgreement method) using
+--encrypted-memo.`,
+		Args: cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			toDidOrAddr := args[0]
 			coinsStr := args[1]
@@ -35,11 +41,24 @@ func GetCmdSend(cdc *codec.Codec) *cobra.Command {
 			cliCtx := context.NewCLIContext().WithCodec(cdc).
 				WithFromAddress(ixoDid.Address())
 
-			msg := types.NewMsgSend(toDidOrAddr, coins, ixoDid.Did)
+			var encryptedMemo did.EncryptedMemo
+			if memo := viper.GetString(didcli.FlagEncryptedMemo); memo != "" {
+				if !did.IsValidDid(toDidOrAddr) {
+					return errors.New("memos can only be encrypted for a recipient did")
+				}
+				encryptedMemo, err = didcli.EncryptMemoForDid(cliCtx, ixoDid, toDidOrAddr, memo)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgSend(toDidOrAddr, coins, ixoDid.Did, encryptedMemo)
 
 			return ixo.GenerateOrBroadcastMsgs(cliCtx, msg, ixoDid)
 		},
 	}
+	cmd.Flags().String(didcli.FlagEncryptedMemo, "", "Memo to encrypt for the recipient did")
+	return cmd
 }
 
 func GetCmdOracleTransfer(cdc *codec.Codec) *cobra.Command {
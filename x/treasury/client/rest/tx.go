@@ -24,6 +24,8 @@ type sendReq struct {
 	FromDid     did.Did      `json:"from_did" yaml:"from_did"`
 	ToDidOrAddr did.Did      `json:"to_did" yaml:"to_did"`
 	Amount      sdk.Coins    `json:"amount" yaml:"amount"`
+
+	EncryptedMemo did.EncryptedMemo `json:"encrypted_memo" yaml:"encrypted_memo"`
 }
 
 func sendRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -38,7 +40,7 @@ func sendRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgSend(req.ToDidOrAddr, req.Amount, req.FromDid)
+		msg := types.NewMsgSend(req.ToDidOrAddr, req.Amount, req.FromDid, req.EncryptedMemo)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
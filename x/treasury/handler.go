@@ -2,6 +2,7 @@ package treasury
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-blockchain/x/did"
 	"github.com/ixofoundation/ixo-blockchain/x/treasury/internal/keeper"
 	"github.com/ixofoundation/ixo-blockchain/x/treasury/internal/types"
 )
@@ -28,6 +29,10 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 
 func handleMsgSend(ctx sdk.Context, k keeper.Keeper, msg types.MsgSend) sdk.Result {
 
+	if err := k.CheckEncryptedMemo(ctx, msg.EncryptedMemo); err != nil {
+		return err.Result()
+	}
+
 	if err := k.Send(ctx, msg.FromDid, msg.ToDidOrAddr, msg.Amount); err != nil {
 		return err.Result()
 	}
@@ -44,6 +49,9 @@ func handleMsgSend(ctx sdk.Context, k keeper.Keeper, msg types.MsgSend) sdk.Resu
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
 		),
 	})
+	if !msg.EncryptedMemo.IsEmpty() {
+		ctx.EventManager().EmitEvent(did.NewEncryptedMemoEvent(msg.FromDid, msg.EncryptedMemo))
+	}
 
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
@@ -66,6 +66,10 @@ func (k Keeper) Send(ctx sdk.Context, fromDid did.Did, toDidOrAddr string, amoun
 	return nil
 }
 
+func (k Keeper) CheckEncryptedMemo(ctx sdk.Context, memo did.EncryptedMemo) sdk.Error {
+	return k.didKeeper.CheckEncryptedMemo(ctx, memo)
+}
+
 func (k Keeper) OracleTransfer(ctx sdk.Context, fromDid did.Did,
 	toDidOrAddr string, oracleDid did.Did, amount sdk.Coins) sdk.Error {
 
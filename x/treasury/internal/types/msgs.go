@@ -21,19 +21,25 @@ var (
 	_ ixo.IxoMsg = MsgOracleTransfer{}
 	_ ixo.IxoMsg = MsgOracleMint{}
 	_ ixo.IxoMsg = MsgOracleBurn{}
+
+	_ did.EncryptedMemoMsg = MsgSend{}
 )
 
 type MsgSend struct {
 	FromDid     did.Did   `json:"from_did" yaml:"from_did"`
 	ToDidOrAddr did.Did   `json:"to_did_or_addr" yaml:"to_did_or_addr"`
 	Amount      sdk.Coins `json:"amount" yaml:"amount"`
+
+	EncryptedMemo did.EncryptedMemo `json:"encrypted_memo,omitempty" yaml:"encrypted_memo,omitempty"`
 }
 
-func NewMsgSend(toDidOrAddr string, amount sdk.Coins, senderDid did.Did) MsgSend {
+func NewMsgSend(toDidOrAddr string, amount sdk.Coins, senderDid did.Did,
+	encryptedMemo did.EncryptedMemo) MsgSend {
 	return MsgSend{
-		FromDid:     senderDid,
-		ToDidOrAddr: toDidOrAddr,
-		Amount:      amount,
+		FromDid:       senderDid,
+		ToDidOrAddr:   toDidOrAddr,
+		Amount:        amount,
+		EncryptedMemo: encryptedMemo,
 	}
 }
 
@@ -61,10 +67,20 @@ func (msg MsgSend) ValidateBasic() sdk.Error {
 		return sdk.ErrInvalidCoins("send amount is invalid: " + msg.Amount.String())
 	}
 
+	// Check encrypted memo (if any), which has to be addressed to the recipient
+	if !msg.EncryptedMemo.IsEmpty() {
+		if err := did.ValidateEncryptedMemo(msg.EncryptedMemo); err != nil {
+			return err
+		} else if msg.EncryptedMemo.RecipientDid != msg.ToDidOrAddr {
+			return did.ErrorInvalidEncryptedMemo(DefaultCodespace, "memo is not addressed to the recipient")
+		}
+	}
+
 	return nil
 }
 
-func (msg MsgSend) GetSignerDid() did.Did { return msg.FromDid }
+func (msg MsgSend) GetSignerDid() did.Did               { return msg.FromDid }
+func (msg MsgSend) GetEncryptedMemo() did.EncryptedMemo { return msg.EncryptedMemo }
 func (msg MsgSend) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{nil} // not used in signature verification in ixo AnteHandler
 }